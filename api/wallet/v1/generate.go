@@ -0,0 +1,3 @@
+package walletv1
+
+//go:generate go -C ../../.. run github.com/bufbuild/buf/cmd/buf@v1.50.0 generate
@@ -0,0 +1,97 @@
+// Command migrate manages the wallet database schema.
+//
+// Usage:
+//
+//	migrate up          apply every pending migration
+//	migrate down [n]    revert the last n applied migrations (default 1)
+//	migrate status      list migrations and when they were applied
+//
+// The database is configured like walletcore, through DB_DSN.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/migrations"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/migrate"
+)
+
+const usage = "usage: migrate up | down [n] | status"
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	db, dialect, err := database.Open(getenv("DB_DSN", "file:wallet.db?_pragma=foreign_keys(1)"))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migrations.NewMigrator(db, dialect.String())
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations(out, "applied", applied)
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		printMigrations(out, "reverted", reverted)
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied() {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%04d_%s\t%s\n", status.Migration.Version, status.Migration.Name, appliedAt)
+		}
+		return nil
+	}
+	return errors.New(usage)
+}
+
+func printMigrations(out io.Writer, verb string, migrations []migrate.Migration) {
+	if len(migrations) == 0 {
+		fmt.Fprintf(out, "no migrations %s\n", verb)
+	}
+	for _, migration := range migrations {
+		fmt.Fprintf(out, "%s %04d_%s\n", verb, migration.Version, migration.Name)
+	}
+}
+
+func getenv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
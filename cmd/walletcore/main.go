@@ -0,0 +1,324 @@
+// Command walletcore serves the wallet over HTTP and gRPC.
+//
+// It is configured through the environment:
+//
+//	HTTP_ADDR  address the HTTP API listens on (default ":8080")
+//	GRPC_ADDR  address the gRPC API listens on (default ":9090")
+//	SCHEDULER_INTERVAL
+//	           how often due scheduled transfers are executed and expired
+//	           holds released (default "1m")
+//	DB_DSN     database to use, a postgres://, mysql://, sqlite: or file: DSN
+//	           (default "file:wallet.db?_pragma=foreign_keys(1)"), or
+//	           "memory:" to keep everything in memory until the server stops
+//	TREASURY_ACCOUNT_ID
+//	           account deposits are taken from and withdrawals paid into;
+//	           deposits and withdrawals fail with an internal error until it
+//	           names an existing account
+//	KAFKA_BROKERS
+//	           comma-separated Kafka brokers the outbox is relayed to, on
+//	           top of the balances projection it always feeds
+//	KAFKA_TOPIC
+//	           topic the events are published to (default "wallet.events")
+//	OUTBOX_RELAY
+//	           whether this instance relays the outbox. Two relays on one
+//	           outbox publish its messages twice, so on PostgreSQL and MySQL,
+//	           which several instances can share, it defaults to false and
+//	           must be set to true on exactly one of them; SQLite and memory
+//	           stores belong to a single process and default to true
+//
+// Pending migrations are applied on startup; see the migrate command to
+// manage them by hand.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	walletv1 "github.com/AntonioSabino/fc-ms-wallet/api/wallet/v1"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/migrations"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/grpcserver"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/kafka"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/outbox"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/projection"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/scheduler"
+	createaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_account"
+	createclient "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_client"
+	createtransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_transaction"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/usecase/deposit"
+	expireholds "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/expire_holds"
+	getaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_account"
+	getbalance "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_balance"
+	getclient "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_client"
+	gettransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_transaction"
+	listtransactions "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/list_transactions"
+	runscheduledtransfers "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/run_scheduled_transfers"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/usecase/withdraw"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/web"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/web/webserver"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	interval, err := time.ParseDuration(getenv("SCHEDULER_INTERVAL", "1m"))
+	if err == nil && interval <= 0 {
+		err = errors.New("must be positive")
+	}
+	if err != nil {
+		return fmt.Errorf("SCHEDULER_INTERVAL: %w", err)
+	}
+
+	store, err := openStore(ctx, getenv("DB_DSN", "file:wallet.db?_pragma=foreign_keys(1)"))
+	if err != nil {
+		return err
+	}
+	defer store.close()
+
+	balances := grpcserver.NewBalanceWatcher()
+	dispatcher := events.NewEventDispatcher()
+	if err := dispatcher.Register(events.BalanceUpdatedName, balances); err != nil {
+		return err
+	}
+
+	createClient := createclient.NewCreateClientUseCase(store.uow)
+	createAccount := createaccount.NewCreateAccountUseCase(store.uow)
+	createTransaction := createtransaction.NewCreateTransactionUseCase(store.uow)
+	createTransaction.Dispatcher = dispatcher
+	treasuryAccountID := os.Getenv("TREASURY_ACCOUNT_ID")
+	depositCash := deposit.NewDepositUseCase(store.uow, treasuryAccountID)
+	depositCash.Dispatcher = dispatcher
+	withdrawCash := withdraw.NewWithdrawUseCase(store.uow, treasuryAccountID)
+	withdrawCash.Dispatcher = dispatcher
+	getAccount := getaccount.NewGetAccountUseCase(store.accounts)
+	getBalance := getbalance.NewGetBalanceUseCase(store.balances)
+
+	server := webserver.NewWebServer(getenv("HTTP_ADDR", ":8080"))
+	web.RegisterRoutes(server,
+		web.NewWebClientHandler(createClient, getclient.NewGetClientUseCase(store.clients)),
+		web.NewWebAccountHandler(createAccount, getAccount, getBalance, depositCash, withdrawCash),
+		web.NewWebTransactionHandler(createTransaction, gettransaction.NewGetTransactionUseCase(store.transactions)),
+	)
+
+	grpcServer := grpc.NewServer()
+	walletv1.RegisterWalletServiceServer(grpcServer, grpcserver.NewWalletServer(
+		createClient,
+		createAccount,
+		createTransaction,
+		depositCash,
+		withdrawCash,
+		getAccount,
+		getBalance,
+		listtransactions.NewListTransactionsUseCase(store.transactions, store.accounts),
+		balances,
+	))
+	grpcListener, err := net.Listen("tcp", getenv("GRPC_ADDR", ":9090"))
+	if err != nil {
+		return err
+	}
+
+	runRelay, err := relayOutbox(store.shared, os.Getenv("OUTBOX_RELAY"))
+	if err != nil {
+		return err
+	}
+	var relay *outbox.Relay
+	if runRelay {
+		var closeRelay func()
+		relay, closeRelay, err = newRelay(store, os.Getenv("KAFKA_BROKERS"), getenv("KAFKA_TOPIC", "wallet.events"))
+		if err != nil {
+			return err
+		}
+		defer closeRelay()
+	}
+
+	jobs := newScheduler(store.uow, createTransaction, dispatcher, interval)
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		jobs.Run(ctx)
+	}()
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		if relay != nil {
+			relay.Run(ctx)
+		}
+	}()
+
+	errs := make(chan error, 2)
+	go func() {
+		log.Printf("HTTP listening on %s", server.Addr)
+		errs <- server.Start(ctx)
+	}()
+	go func() {
+		log.Printf("gRPC listening on %s", grpcListener.Addr())
+		errs <- grpcServer.Serve(grpcListener)
+	}()
+
+	running := 2
+	select {
+	case err = <-errs:
+		running--
+		stop()
+	case <-ctx.Done():
+	}
+
+	// Watches never end on their own; close them so GracefulStop only waits
+	// for the calls in flight.
+	balances.Close()
+	grpcServer.GracefulStop()
+	for ; running > 0; running-- {
+		if serveErr := <-errs; serveErr != nil && err == nil {
+			err = serveErr
+		}
+	}
+	// ctx is done by now, so the scheduler and the relay stop after their
+	// current run.
+	<-jobsDone
+	<-relayDone
+	if err != nil {
+		return err
+	}
+	log.Print("server stopped")
+	return nil
+}
+
+// newScheduler returns the scheduler running the background jobs every
+// interval: due scheduled transfers are executed and expired holds released.
+// A failing job does not keep the other from running. The events of the
+// expired holds are sent to dispatcher.
+func newScheduler(u uow.UnitOfWork, createTransaction runscheduledtransfers.TransactionCreator, dispatcher events.Dispatcher, interval time.Duration) *scheduler.Scheduler {
+	runScheduledTransfers := runscheduledtransfers.NewRunScheduledTransfersUseCase(u, createTransaction)
+	expireHolds := expireholds.NewExpireHoldsUseCase(u)
+	expireHolds.Dispatcher = dispatcher
+	jobs := scheduler.NewScheduler(func(ctx context.Context) error {
+		_, transfersErr := runScheduledTransfers.Execute(ctx, runscheduledtransfers.RunScheduledTransfersInputDTO{})
+		_, holdsErr := expireHolds.Execute(ctx, expireholds.ExpireHoldsInputDTO{})
+		return errors.Join(transfersErr, holdsErr)
+	}, interval)
+	jobs.OnError = func(err error) {
+		log.Printf("background jobs: %v", err)
+	}
+	return jobs
+}
+
+// newRelay returns the relay emptying the outbox of s: its messages are
+// applied to the balances projection and, when brokers is not empty, also
+// published to topic on the Kafka brokers, a comma-separated list. The
+// returned function releases the Kafka client.
+func newRelay(s *store, brokers, topic string) (*outbox.Relay, func(), error) {
+	publishers := outbox.Publishers{projection.NewBalanceProjection(s.balances)}
+	closeRelay := func() {}
+	if brokers != "" {
+		client, err := kgo.NewClient(kgo.SeedBrokers(strings.Split(brokers, ",")...))
+		if err != nil {
+			return nil, nil, fmt.Errorf("KAFKA_BROKERS: %w", err)
+		}
+		publishers = append(publishers, kafka.NewPublisher(client, topic))
+		closeRelay = client.Close
+	}
+
+	relay := outbox.NewRelay(s.outbox, publishers)
+	relay.OnError = func(err error) {
+		log.Printf("outbox relay: %v", err)
+	}
+	return relay, closeRelay, nil
+}
+
+// relayOutbox reports whether the outbox is relayed, as set by value, the
+// OUTBOX_RELAY variable. Unset, it is only relayed when the store is not
+// shared with other instances.
+func relayOutbox(shared bool, value string) (bool, error) {
+	if value == "" {
+		return !shared, nil
+	}
+	relay, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("OUTBOX_RELAY: %w", err)
+	}
+	return relay, nil
+}
+
+// store holds the gateways the server is built on. A shared store can be
+// used by several walletcore instances at once.
+type store struct {
+	clients      gateway.ClientGateway
+	accounts     gateway.AccountGateway
+	transactions gateway.TransactionGateway
+	outbox       gateway.OutboxGateway
+	balances     gateway.BalanceGateway
+	uow          uow.UnitOfWork
+	shared       bool
+	close        func() error
+}
+
+// openStore opens the database named by dsn and applies pending migrations.
+func openStore(ctx context.Context, dsn string) (*store, error) {
+	if dsn == "memory:" {
+		data := memory.NewStore()
+		return &store{
+			clients:      memory.NewClientGateway(data),
+			accounts:     memory.NewAccountGateway(data),
+			transactions: memory.NewTransactionGateway(data),
+			outbox:       memory.NewOutboxGateway(data),
+			balances:     memory.NewBalanceGateway(data),
+			uow:          memory.NewUow(data),
+			close:        func() error { return nil },
+		}, nil
+	}
+
+	db, dialect, err := database.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := migrations.NewMigrator(db, dialect.String())
+	if err == nil {
+		_, err = migrator.Up(ctx)
+	}
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	u := uow.NewUow(db)
+	database.RegisterRepositories(u, dialect)
+	return &store{
+		clients:      &database.ClientDB{DB: db, Dialect: dialect},
+		accounts:     &database.AccountDB{DB: db, Dialect: dialect},
+		transactions: &database.TransactionDB{DB: db, Dialect: dialect},
+		outbox:       &database.OutboxDB{DB: db, Dialect: dialect},
+		balances:     &database.BalanceDB{DB: db, Dialect: dialect},
+		uow:          u,
+		shared:       dialect != database.SQLite,
+		close:        db.Close,
+	}, nil
+}
+
+func getenv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
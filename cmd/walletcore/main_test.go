@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/kafka/kafkatest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/outbox"
+	createtransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerRunsBackgroundJobs(t *testing.T) {
+	data := memory.NewStore()
+	accounts := memory.NewAccountGateway(data)
+	client, _ := entity.NewClient("John Doe", "john@example.com")
+	assert.Nil(t, memory.NewClientGateway(data).Save(client))
+	accountFrom := entity.NewAccount(client)
+	assert.Nil(t, accountFrom.Credit(entity.NewMoney(100_00, entity.DefaultCurrency)))
+	accountTo := entity.NewAccount(client)
+
+	hold, err := entity.NewHold(accountFrom, entity.NewMoney(10_00, entity.DefaultCurrency), time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+	hold.ExpiresAt = time.Now().Add(-time.Minute)
+	assert.Nil(t, memory.NewHoldGateway(data).Save(hold))
+	assert.Nil(t, accounts.Save(accountFrom))
+	assert.Nil(t, accounts.Save(accountTo))
+
+	transfer, err := entity.NewScheduledTransfer(accountFrom.ID, accountTo.ID, entity.NewMoney(25_00, entity.DefaultCurrency), time.Now().Add(-time.Minute), "")
+	assert.Nil(t, err)
+	assert.Nil(t, memory.NewScheduledTransferGateway(data).Save(transfer))
+
+	u := memory.NewUow(data)
+	jobs := newScheduler(u, createtransaction.NewCreateTransactionUseCase(u), events.NewEventDispatcher(), time.Millisecond)
+	jobs.OnError = func(err error) { t.Errorf("background jobs: %v", err) }
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- jobs.Run(ctx) }()
+
+	assert.Eventually(t, func() bool {
+		account, err := accounts.FindByID(accountTo.ID)
+		return err == nil && account.Balance == entity.NewMoney(25_00, entity.DefaultCurrency)
+	}, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		hold, err := memory.NewHoldGateway(data).FindByID(hold.ID)
+		return err == nil && hold.Status == entity.HoldExpired
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	runs, err := memory.NewScheduledTransferGateway(data).FindRuns(transfer.ID)
+	assert.Nil(t, err)
+	assert.Len(t, runs, 1)
+	account, err := accounts.FindByID(accountFrom.ID)
+	assert.Nil(t, err)
+	assert.True(t, account.HeldAmount.IsZero())
+	assert.Equal(t, entity.NewMoney(75_00, entity.DefaultCurrency), account.Balance)
+}
+
+func TestRelayFeedsProjectionAndKafka(t *testing.T) {
+	broker := kafkatest.NewBroker(t, "wallet.events")
+	s, err := openStore(context.Background(), "memory:")
+	assert.Nil(t, err)
+	defer s.close()
+
+	client, _ := entity.NewClient("John Doe", "john@example.com")
+	account := entity.NewAccount(client)
+	assert.Nil(t, account.Credit(entity.NewMoney(12_34, entity.DefaultCurrency)))
+	account.Version = 1
+	assert.Nil(t, outbox.Write(s.outbox, events.NewBalanceUpdated(account)))
+
+	relay, closeRelay, err := newRelay(s, strings.Join(broker.Addrs(), ","), "wallet.events")
+	assert.Nil(t, err)
+	defer closeRelay()
+	n, err := relay.RelayBatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+
+	balance, err := s.balances.FindByAccountID(account.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.NewMoney(12_34, entity.DefaultCurrency), balance.Balance)
+	records := broker.Consume(t, "wallet.events", 1)
+	assert.Equal(t, account.ID, string(records[0].Key))
+}
+
+func TestRelayOutbox(t *testing.T) {
+	for _, test := range []struct {
+		shared bool
+		value  string
+		relay  bool
+	}{
+		{shared: false, value: "", relay: true},
+		{shared: true, value: "", relay: false},
+		{shared: true, value: "true", relay: true},
+		{shared: false, value: "false", relay: false},
+	} {
+		relay, err := relayOutbox(test.shared, test.value)
+		assert.Nil(t, err)
+		assert.Equal(t, test.relay, relay, "shared=%v value=%q", test.shared, test.value)
+	}
+
+	_, err := relayOutbox(true, "sometimes")
+	assert.ErrorContains(t, err, "OUTBOX_RELAY")
+}
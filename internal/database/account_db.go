@@ -4,24 +4,46 @@ import (
 	"database/sql"
 
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
 )
 
 type AccountDB struct {
-	DB *sql.DB
+	DB      DBTX
+	Dialect Dialect
 }
 
-func NewAccountDB(db *sql.DB) *AccountDB {
+func NewAccountDB(db DBTX) *AccountDB {
 	return &AccountDB{
 		DB: db,
 	}
 }
 
+const selectAccount = "SELECT id, client_id, balance, held_amount, overdraft_limit, currency, status, version, created_at FROM accounts WHERE id = ?"
+
+// FindByID loads an account with its client.
 func (a *AccountDB) FindByID(id string) (*entity.Account, error) {
+	return a.find(selectAccount, id)
+}
+
+// FindByIDForUpdate loads an account like FindByID. Where the dialect
+// supports it the account row stays locked until the enclosing transaction
+// ends, so concurrent transfers touching the account wait for each other
+// instead of failing the version check. The client row is read without
+// locking it. Losing a deadlock is reported as a *gateway.ConflictError.
+func (a *AccountDB) FindByIDForUpdate(id string) (*entity.Account, error) {
+	return a.find(a.Dialect.forUpdate(selectAccount), id)
+}
+
+func (a *AccountDB) find(query string, id string) (*entity.Account, error) {
 	var account entity.Account
-	var client entity.Client
-	account.Client = &client
+	var clientID string
+	var balance decimal
+	var heldAmount decimal
+	var overdraftLimit decimal
+	var currency string
+	var status string
 
-	stmt, err := a.DB.Prepare("SELECT a.id, a.client_id, a.balance, a.created_at, c.id, c.name, c.email, c.created_at FROM accounts a JOIN clients c ON a.client_id = c.id WHERE a.id = ?")
+	stmt, err := a.DB.Prepare(a.Dialect.rebind(query))
 	if err != nil {
 		return nil, err
 	}
@@ -29,27 +51,88 @@ func (a *AccountDB) FindByID(id string) (*entity.Account, error) {
 
 	row := stmt.QueryRow(id)
 
-	if err := row.Scan(&account.ID, &account.Client.ID, &account.Balance, &account.CreatedAt, &client.ID, &client.Name, &client.Email, &client.CreatedAt); err != nil {
+	if err := row.Scan(&account.ID, &clientID, &balance, &heldAmount, &overdraftLimit, &currency, &status, &account.Version, &account.CreatedAt); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil
+			return nil, &gateway.NotFoundError{Entity: "account", ID: id}
 		}
+		return nil, lockConflict(err, "account", id)
+	}
+
+	account.Client, err = (&ClientDB{DB: a.DB, Dialect: a.Dialect}).Get(clientID)
+	if err != nil {
+		return nil, err
+	}
+	account.Balance, err = balance.money(currency)
+	if err != nil {
+		return nil, err
+	}
+	account.HeldAmount, err = heldAmount.money(currency)
+	if err != nil {
+		return nil, err
+	}
+	account.OverdraftLimit, err = overdraftLimit.money(currency)
+	if err != nil {
 		return nil, err
 	}
+	account.Status = entity.AccountStatus(status)
 
 	return &account, nil
 }
 
 func (a *AccountDB) Save(account *entity.Account) error {
-	stmt, err := a.DB.Prepare("INSERT INTO accounts (id, client_id, balance, created_at) VALUES (?, ?, ?, ?)")
+	stmt, err := a.DB.Prepare(a.Dialect.rebind("INSERT INTO accounts (id, client_id, balance, held_amount, overdraft_limit, currency, status, version, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"))
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(account.ID, account.Client.ID, account.Balance, account.CreatedAt)
+	_, err = stmt.Exec(account.ID, account.Client.ID, account.Balance.Decimal(), account.HeldAmount.Decimal(), account.OverdraftLimit.Decimal(), account.Balance.Currency(), string(account.Status), account.Version, a.Dialect.timestamp(account.CreatedAt))
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// UpdateBalance writes the account balance and held amount only if the stored
+// version still matches account.Version, returning a *gateway.ConflictError
+// otherwise or when the update loses a deadlock.
+func (a *AccountDB) UpdateBalance(account *entity.Account) error {
+	return a.update(account, "UPDATE accounts SET balance = ?, held_amount = ?, version = version + 1 WHERE id = ? AND version = ?", account.Balance.Decimal(), account.HeldAmount.Decimal())
+}
+
+// UpdateStatus writes the account status with the same version check as
+// UpdateBalance.
+func (a *AccountDB) UpdateStatus(account *entity.Account) error {
+	return a.update(account, "UPDATE accounts SET status = ?, version = version + 1 WHERE id = ? AND version = ?", string(account.Status))
+}
+
+// UpdateOverdraftLimit writes the account overdraft limit with the same
+// version check as UpdateBalance.
+func (a *AccountDB) UpdateOverdraftLimit(account *entity.Account) error {
+	return a.update(account, "UPDATE accounts SET overdraft_limit = ?, version = version + 1 WHERE id = ? AND version = ?", account.OverdraftLimit.Decimal())
+}
+
+func (a *AccountDB) update(account *entity.Account, query string, values ...any) error {
+	stmt, err := a.DB.Prepare(a.Dialect.rebind(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(append(values, account.ID, account.Version)...)
+	if err != nil {
+		return lockConflict(err, "account", account.ID)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return &gateway.ConflictError{Entity: "account", ID: account.ID}
+	}
+
+	account.Version++
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -16,22 +18,13 @@ type AccountDBTestSuite struct {
 }
 
 func (s *AccountDBTestSuite) SetupTest() {
-	db, err := sql.Open("sqlite", "file::memory:?cache=shared")
-	s.Nil(err)
+	db := databasetest.NewDB(s.T())
 	s.db = db
-	db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), created_at date)")
-	db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance decimal, created_at date, FOREIGN KEY(client_id) REFERENCES clients(id))")
 
 	s.accountDB = NewAccountDB(db)
 	s.client, _ = entity.NewClient("Jane Doe", "jane.doe@example.com")
 }
 
-func (s *AccountDBTestSuite) TearDownTest() {
-	defer s.db.Close()
-	s.db.Exec("DROP TABLE accounts")
-	s.db.Exec("DROP TABLE clients")
-}
-
 func TestAccountDBTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountDBTestSuite))
 }
@@ -55,3 +48,112 @@ func (s *AccountDBTestSuite) TestFindByID() {
 	s.Equal(s.client.Name, retrievedAccount.Client.Name)
 	s.Equal(s.client.Email, retrievedAccount.Client.Email)
 }
+
+func (s *AccountDBTestSuite) TestFindByIDKeepsExactBalance() {
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", s.client.ID, s.client.Name, s.client.Email, s.client.CreatedAt)
+	account := entity.NewAccount(s.client)
+	account.Balance = entity.NewMoney(1234567_89, entity.DefaultCurrency)
+	err := s.accountDB.Save(account)
+	s.Nil(err)
+	retrievedAccount, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(account.Balance, retrievedAccount.Balance)
+}
+
+func (s *AccountDBTestSuite) TestUpdateBalance() {
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", s.client.ID, s.client.Name, s.client.Email, s.client.CreatedAt)
+	account := entity.NewAccount(s.client)
+	err := s.accountDB.Save(account)
+	s.Nil(err)
+
+	account.Credit(entity.NewMoney(150_25, entity.DefaultCurrency))
+	account.Hold(entity.NewMoney(50_00, entity.DefaultCurrency))
+	err = s.accountDB.UpdateBalance(account)
+	s.Nil(err)
+
+	retrievedAccount, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(150_25, entity.DefaultCurrency), retrievedAccount.Balance)
+	s.Equal(entity.NewMoney(50_00, entity.DefaultCurrency), retrievedAccount.HeldAmount)
+}
+
+func (s *AccountDBTestSuite) TestUpdateBalanceWithStaleVersion() {
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", s.client.ID, s.client.Name, s.client.Email, s.client.CreatedAt)
+	account := entity.NewAccount(s.client)
+	account.Credit(entity.NewMoney(100_00, entity.DefaultCurrency))
+	err := s.accountDB.Save(account)
+	s.Nil(err)
+
+	first, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	second, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+
+	first.Debit(entity.NewMoney(80_00, entity.DefaultCurrency))
+	err = s.accountDB.UpdateBalance(first)
+	s.Nil(err)
+	s.Equal(1, first.Version)
+
+	second.Debit(entity.NewMoney(80_00, entity.DefaultCurrency))
+	err = s.accountDB.UpdateBalance(second)
+	var conflict *gateway.ConflictError
+	s.ErrorAs(err, &conflict)
+	s.Equal(account.ID, conflict.ID)
+
+	retrievedAccount, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(20_00, entity.DefaultCurrency), retrievedAccount.Balance)
+	s.Equal(1, retrievedAccount.Version)
+}
+
+func (s *AccountDBTestSuite) TestFindByIDNotFound() {
+	retrievedAccount, err := s.accountDB.FindByID("unknown")
+	s.Nil(retrievedAccount)
+	s.ErrorIs(err, gateway.ErrNotFound)
+	var notFound *gateway.NotFoundError
+	s.ErrorAs(err, &notFound)
+	s.Equal("account", notFound.Entity)
+	s.Equal("unknown", notFound.ID)
+}
+
+func (s *AccountDBTestSuite) TestUpdateStatus() {
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", s.client.ID, s.client.Name, s.client.Email, s.client.CreatedAt)
+	account := entity.NewAccount(s.client)
+	err := s.accountDB.Save(account)
+	s.Nil(err)
+
+	retrievedAccount, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(entity.AccountActive, retrievedAccount.Status)
+
+	s.Nil(account.Freeze())
+	err = s.accountDB.UpdateStatus(account)
+	s.Nil(err)
+	s.Equal(1, account.Version)
+
+	retrievedAccount, err = s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(entity.AccountFrozen, retrievedAccount.Status)
+
+	stale := *retrievedAccount
+	stale.Version = 0
+	s.Nil(stale.Unfreeze())
+	err = s.accountDB.UpdateStatus(&stale)
+	s.ErrorIs(err, gateway.ErrConflict)
+}
+
+func (s *AccountDBTestSuite) TestUpdateOverdraftLimit() {
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", s.client.ID, s.client.Name, s.client.Email, s.client.CreatedAt)
+	account := entity.NewAccount(s.client)
+	err := s.accountDB.Save(account)
+	s.Nil(err)
+
+	s.Nil(account.SetOverdraftLimit(entity.NewMoney(250_00, entity.DefaultCurrency)))
+	err = s.accountDB.UpdateOverdraftLimit(account)
+	s.Nil(err)
+
+	retrievedAccount, err := s.accountDB.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(250_00, entity.DefaultCurrency), retrievedAccount.OverdraftLimit)
+	s.Equal(1, retrievedAccount.Version)
+}
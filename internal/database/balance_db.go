@@ -0,0 +1,96 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type BalanceDB struct {
+	DB      DBTX
+	Dialect Dialect
+}
+
+func NewBalanceDB(db DBTX) *BalanceDB {
+	return &BalanceDB{
+		DB: db,
+	}
+}
+
+func (b *BalanceDB) ApplyBalance(balance *entity.AccountBalance) error {
+	query := "INSERT INTO balances (account_id, balance, held_amount, currency, version, updated_at) VALUES (?, ?, ?, ?, ?, ?) " +
+		"ON CONFLICT (account_id) DO UPDATE SET balance = excluded.balance, held_amount = excluded.held_amount, currency = excluded.currency, version = excluded.version, updated_at = excluded.updated_at " +
+		"WHERE balances.version < excluded.version"
+	if b.Dialect == MySQL {
+		// ON DUPLICATE KEY UPDATE has no WHERE clause and assigns columns
+		// left to right, so each one repeats the check and version goes last.
+		// The inserted row is named through an alias, as VALUES() is
+		// deprecated since MySQL 8.0.20.
+		query = "INSERT INTO balances (account_id, balance, held_amount, currency, version, updated_at) VALUES (?, ?, ?, ?, ?, ?) AS new " +
+			"ON DUPLICATE KEY UPDATE balance = IF(version < new.version, new.balance, balance), held_amount = IF(version < new.version, new.held_amount, held_amount), " +
+			"currency = IF(version < new.version, new.currency, currency), updated_at = IF(version < new.version, new.updated_at, updated_at), " +
+			"version = IF(version < new.version, new.version, version)"
+	}
+	stmt, err := b.DB.Prepare(b.Dialect.rebind(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(balance.AccountID, balance.Balance.Decimal(), balance.HeldAmount.Decimal(), balance.Balance.Currency(), balance.Version, b.Dialect.timestamp(balance.UpdatedAt))
+	return err
+}
+
+func (b *BalanceDB) ApplyTransaction(accountID, currency, transactionID string, at time.Time) error {
+	query := "INSERT INTO balances (account_id, balance, held_amount, currency, version, last_transaction_id, last_transaction_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?) " +
+		"ON CONFLICT (account_id) DO UPDATE SET last_transaction_id = excluded.last_transaction_id, last_transaction_at = excluded.last_transaction_at " +
+		"WHERE balances.last_transaction_at IS NULL OR balances.last_transaction_at < excluded.last_transaction_at"
+	if b.Dialect == MySQL {
+		query = "INSERT INTO balances (account_id, balance, held_amount, currency, version, last_transaction_id, last_transaction_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?) AS new " +
+			"ON DUPLICATE KEY UPDATE last_transaction_id = IF(last_transaction_at IS NULL OR last_transaction_at < new.last_transaction_at, new.last_transaction_id, last_transaction_id), " +
+			"last_transaction_at = IF(last_transaction_at IS NULL OR last_transaction_at < new.last_transaction_at, new.last_transaction_at, last_transaction_at)"
+	}
+	stmt, err := b.DB.Prepare(b.Dialect.rebind(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	zero := entity.Zero(currency).Decimal()
+	_, err = stmt.Exec(accountID, zero, zero, currency, entity.UnprojectedVersion, transactionID, b.Dialect.timestamp(at), b.Dialect.timestamp(at))
+	return err
+}
+
+func (b *BalanceDB) FindByAccountID(accountID string) (*entity.AccountBalance, error) {
+	stmt, err := b.DB.Prepare(b.Dialect.rebind("SELECT account_id, balance, held_amount, currency, version, last_transaction_id, last_transaction_at, updated_at FROM balances WHERE account_id = ?"))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	balance := &entity.AccountBalance{}
+	var amount, held decimal
+	var currency string
+	var lastTransactionID sql.NullString
+	var lastTransactionAt sql.NullTime
+	err = stmt.QueryRow(accountID).Scan(&balance.AccountID, &amount, &held, &currency, &balance.Version, &lastTransactionID, &lastTransactionAt, &balance.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &gateway.NotFoundError{Entity: "balance", ID: accountID}
+		}
+		return nil, err
+	}
+	balance.Balance, err = amount.money(currency)
+	if err != nil {
+		return nil, err
+	}
+	balance.HeldAmount, err = held.money(currency)
+	if err != nil {
+		return nil, err
+	}
+	balance.LastTransactionID = lastTransactionID.String
+	balance.LastTransactionAt = lastTransactionAt.Time
+	return balance, nil
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+type BalanceDBTestSuite struct {
+	suite.Suite
+	db        *sql.DB
+	balanceDB *BalanceDB
+}
+
+func (s *BalanceDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+	s.balanceDB = NewBalanceDB(db)
+}
+
+func TestBalanceDBTestSuite(t *testing.T) {
+	suite.Run(t, new(BalanceDBTestSuite))
+}
+
+func newAccountBalance(minorUnits int64, version int) *entity.AccountBalance {
+	return &entity.AccountBalance{
+		AccountID:  "account-1",
+		Balance:    entity.NewMoney(minorUnits, entity.DefaultCurrency),
+		HeldAmount: entity.Zero(entity.DefaultCurrency),
+		Version:    version,
+		UpdatedAt:  time.Now(),
+	}
+}
+
+func (s *BalanceDBTestSuite) TestApplyBalanceKeepsNewestVersion() {
+	s.Nil(s.balanceDB.ApplyBalance(newAccountBalance(30_00, 3)))
+	s.Nil(s.balanceDB.ApplyBalance(newAccountBalance(10_00, 1)))
+	s.Nil(s.balanceDB.ApplyBalance(newAccountBalance(30_00, 3)))
+
+	balance, err := s.balanceDB.FindByAccountID("account-1")
+	s.Nil(err)
+	s.Equal(entity.NewMoney(30_00, entity.DefaultCurrency), balance.Balance)
+	s.Equal(3, balance.Version)
+
+	s.Nil(s.balanceDB.ApplyBalance(newAccountBalance(40_00, 4)))
+	balance, err = s.balanceDB.FindByAccountID("account-1")
+	s.Nil(err)
+	s.Equal(entity.NewMoney(40_00, entity.DefaultCurrency), balance.Balance)
+	s.Equal(4, balance.Version)
+}
+
+func (s *BalanceDBTestSuite) TestApplyTransactionBeforeBalance() {
+	at := time.Now()
+	s.Nil(s.balanceDB.ApplyTransaction("account-1", entity.DefaultCurrency, "transaction-2", at))
+	s.Nil(s.balanceDB.ApplyTransaction("account-1", entity.DefaultCurrency, "transaction-1", at.Add(-time.Minute)))
+
+	balance, err := s.balanceDB.FindByAccountID("account-1")
+	s.Nil(err)
+	s.True(balance.Balance.IsZero())
+	s.Equal(entity.UnprojectedVersion, balance.Version)
+	s.Equal("transaction-2", balance.LastTransactionID)
+
+	s.Nil(s.balanceDB.ApplyBalance(newAccountBalance(10_00, 1)))
+	balance, err = s.balanceDB.FindByAccountID("account-1")
+	s.Nil(err)
+	s.Equal(entity.NewMoney(10_00, entity.DefaultCurrency), balance.Balance)
+	s.Equal("transaction-2", balance.LastTransactionID)
+	s.Equal(at.UTC().Truncate(time.Second), balance.LastTransactionAt.UTC().Truncate(time.Second))
+}
+
+func (s *BalanceDBTestSuite) TestFindByAccountIDNotFound() {
+	balance, err := s.balanceDB.FindByAccountID("unknown")
+	s.Nil(balance)
+	s.ErrorIs(err, gateway.ErrNotFound)
+}
@@ -4,39 +4,58 @@ import (
 	"database/sql"
 
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
 )
 
 type ClientDB struct {
-	DB *sql.DB
+	DB      DBTX
+	Dialect Dialect
 }
 
-func NewClientDB(db *sql.DB) *ClientDB {
+func NewClientDB(db DBTX) *ClientDB {
 	return &ClientDB{
 		DB: db,
 	}
 }
 
+const selectClient = "SELECT id, name, email, tier, created_at FROM clients WHERE id = ?"
+
 func (c *ClientDB) Get(id string) (*entity.Client, error) {
+	return c.get(selectClient, id)
+}
+
+// GetForUpdate loads a client like Get. Where the dialect supports it the
+// client row stays locked until the enclosing transaction ends, so units of
+// work adding up the transfers of the client run one after the other.
+// Losing a deadlock is reported as a *gateway.ConflictError.
+func (c *ClientDB) GetForUpdate(id string) (*entity.Client, error) {
+	return c.get(c.Dialect.forUpdate(selectClient), id)
+}
+
+func (c *ClientDB) get(query string, id string) (*entity.Client, error) {
 	client := &entity.Client{}
-	stmt, err := c.DB.Prepare("SELECT id, name, email FROM clients WHERE id = ?")
+	stmt, err := c.DB.Prepare(c.Dialect.rebind(query))
 	if err != nil {
 		return nil, err
 	}
 	defer stmt.Close()
 	row := stmt.QueryRow(id)
-	if err := row.Scan(&client.ID, &client.Name, &client.Email); err != nil {
-		return nil, err
+	if err := row.Scan(&client.ID, &client.Name, &client.Email, &client.Tier, &client.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &gateway.NotFoundError{Entity: "client", ID: id}
+		}
+		return nil, lockConflict(err, "client", id)
 	}
 	return client, nil
 }
 
 func (c *ClientDB) Save(client *entity.Client) error {
-	stmt, err := c.DB.Prepare("INSERT INTO clients (id, name, email) VALUES (?, ?, ?)")
+	stmt, err := c.DB.Prepare(c.Dialect.rebind("INSERT INTO clients (id, name, email, tier, created_at) VALUES (?, ?, ?, ?, ?)"))
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(client.ID, client.Name, client.Email)
+	_, err = stmt.Exec(client.ID, client.Name, client.Email, string(client.Tier), c.Dialect.timestamp(client.CreatedAt))
 	if err != nil {
 		return err
 	}
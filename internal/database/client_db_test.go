@@ -4,9 +4,10 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
 	"github.com/stretchr/testify/suite"
-	_ "modernc.org/sqlite"
 )
 
 type ClientDBTestSuite struct {
@@ -16,18 +17,11 @@ type ClientDBTestSuite struct {
 }
 
 func (s *ClientDBTestSuite) SetupTest() {
-	db, err := sql.Open("sqlite", "file::memory:?cache=shared")
-	s.Nil(err)
+	db := databasetest.NewDB(s.T())
 	s.db = db
-	db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255))")
 	s.clientDB = NewClientDB(db)
 }
 
-func (s *ClientDBTestSuite) TearDownTest() {
-	defer s.db.Close()
-	s.db.Exec("DROP TABLE clients")
-}
-
 func TestClientDBTestSuite(t *testing.T) {
 	suite.Run(t, new(ClientDBTestSuite))
 }
@@ -40,6 +34,7 @@ func (s *ClientDBTestSuite) TestSaveClient() {
 	retrievedClient, err := s.clientDB.Get(client.ID)
 	s.Nil(err)
 	s.Equal(client.ID, retrievedClient.ID)
+	s.True(client.CreatedAt.Equal(retrievedClient.CreatedAt))
 }
 
 func (s *ClientDBTestSuite) TestGetClient() {
@@ -50,3 +45,23 @@ func (s *ClientDBTestSuite) TestGetClient() {
 	s.Nil(err)
 	s.Equal(client.ID, retrievedClient.ID)
 }
+
+func (s *ClientDBTestSuite) TestSaveClientTier() {
+	client, _ := entity.NewClient("Jane Doe", "jane.doe@example.com")
+	s.Nil(client.SetTier("premium"))
+	s.Nil(s.clientDB.Save(client))
+
+	retrievedClient, err := s.clientDB.Get(client.ID)
+	s.Nil(err)
+	s.Equal(entity.ClientTier("premium"), retrievedClient.Tier)
+}
+
+func (s *ClientDBTestSuite) TestGetClientNotFound() {
+	retrievedClient, err := s.clientDB.Get("unknown")
+	s.Nil(retrievedClient)
+	s.ErrorIs(err, gateway.ErrNotFound)
+	var notFound *gateway.NotFoundError
+	s.ErrorAs(err, &notFound)
+	s.Equal("client", notFound.Entity)
+	s.Equal("unknown", notFound.ID)
+}
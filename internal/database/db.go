@@ -0,0 +1,36 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// DBTX is implemented by both *sql.DB and *sql.Tx, so the gateways can run
+// standalone or as part of a unit of work.
+type DBTX interface {
+	Prepare(query string) (*sql.Stmt, error)
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// nullString stores an empty optional reference as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// lockConflict reports err as a *gateway.ConflictError when the database
+// aborted the transaction to break a deadlock or a serialization failure, so
+// the caller retries it like any other concurrent change.
+func lockConflict(err error, entity, id string) error {
+	if isMySQLDeadlock(err) || isPostgresConflict(err) {
+		return &gateway.ConflictError{Entity: entity, ID: id}
+	}
+	return err
+}
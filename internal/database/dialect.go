@@ -0,0 +1,70 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect is the SQL dialect the gateways write their queries in. The zero
+// value is SQLite.
+type Dialect int
+
+const (
+	SQLite Dialect = iota
+	MySQL
+	Postgres
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case SQLite:
+		return "sqlite"
+	case MySQL:
+		return "mysql"
+	case Postgres:
+		return "postgres"
+	}
+	return fmt.Sprintf("Dialect(%d)", int(d))
+}
+
+// rebind rewrites the ? placeholders the queries are written with into
+// PostgreSQL's $1, $2, ... Queries must not hold a literal question mark.
+func (d Dialect) rebind(query string) string {
+	if d != Postgres || !strings.Contains(query, "?") {
+		return query
+	}
+	var rebound strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			rebound.WriteRune(r)
+			continue
+		}
+		n++
+		rebound.WriteString("$" + strconv.Itoa(n))
+	}
+	return rebound.String()
+}
+
+// forUpdate makes a SELECT lock the rows it reads until the enclosing
+// transaction ends. SQLite serializes writers on the whole database and has
+// no such clause.
+func (d Dialect) forUpdate(query string) string {
+	if d == MySQL || d == Postgres {
+		return query + " FOR UPDATE"
+	}
+	return query
+}
+
+// timestamp converts t to the value stored in a DATETIME column. Times are
+// stored in UTC; MySQL and PostgreSQL keep microseconds and would round
+// anything finer, so the time is truncated to keep what is read back from
+// being later than what was written.
+func (d Dialect) timestamp(t time.Time) time.Time {
+	if d == MySQL || d == Postgres {
+		return t.UTC().Truncate(time.Microsecond)
+	}
+	return t.UTC()
+}
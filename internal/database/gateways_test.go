@@ -0,0 +1,43 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/gatewaytest"
+)
+
+func newGateways(db DBTX, dialect Dialect) gatewaytest.Gateways {
+	return gatewaytest.Gateways{
+		Clients:               &ClientDB{DB: db, Dialect: dialect},
+		Accounts:              &AccountDB{DB: db, Dialect: dialect},
+		Transactions:          &TransactionDB{DB: db, Dialect: dialect},
+		Ledger:                &LedgerDB{DB: db, Dialect: dialect},
+		IdempotencyKeys:       &IdempotencyKeyDB{DB: db, Dialect: dialect},
+		OverdraftLimitChanges: &OverdraftLimitChangeDB{DB: db, Dialect: dialect},
+		Holds:                 &HoldDB{DB: db, Dialect: dialect},
+		ScheduledTransfers:    &ScheduledTransferDB{DB: db, Dialect: dialect},
+		Outbox:                &OutboxDB{DB: db, Dialect: dialect},
+		Balances:              &BalanceDB{DB: db, Dialect: dialect},
+	}
+}
+
+func TestSQLiteGateways(t *testing.T) {
+	gatewaytest.Run(t, func(t *testing.T) gatewaytest.Gateways {
+		return newGateways(databasetest.NewDB(t), SQLite)
+	})
+}
+
+// TestMySQLGateways runs against the server named by MYSQL_TEST_DSN.
+func TestMySQLGateways(t *testing.T) {
+	gatewaytest.Run(t, func(t *testing.T) gatewaytest.Gateways {
+		return newGateways(databasetest.NewMySQLDB(t), MySQL)
+	})
+}
+
+// TestPostgresGateways runs against the server named by POSTGRES_TEST_DSN.
+func TestPostgresGateways(t *testing.T) {
+	gatewaytest.Run(t, func(t *testing.T) gatewaytest.Gateways {
+		return newGateways(databasetest.NewPostgresDB(t), Postgres)
+	})
+}
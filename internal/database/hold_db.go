@@ -0,0 +1,123 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type HoldDB struct {
+	DB      DBTX
+	Dialect Dialect
+}
+
+func NewHoldDB(db DBTX) *HoldDB {
+	return &HoldDB{
+		DB: db,
+	}
+}
+
+func (h *HoldDB) Save(hold *entity.Hold) error {
+	stmt, err := h.DB.Prepare(h.Dialect.rebind("INSERT INTO holds (id, account_id, amount, captured_amount, currency, status, transaction_id, expires_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(hold.ID, hold.AccountID, hold.Amount.Decimal(), hold.CapturedAmount.Decimal(), hold.Amount.Currency(), string(hold.Status), nullString(hold.TransactionID), h.Dialect.timestamp(hold.ExpiresAt), h.Dialect.timestamp(hold.CreatedAt), h.Dialect.timestamp(hold.UpdatedAt))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (h *HoldDB) FindByID(id string) (*entity.Hold, error) {
+	stmt, err := h.DB.Prepare(h.Dialect.rebind("SELECT id, account_id, amount, captured_amount, currency, status, transaction_id, expires_at, created_at, updated_at FROM holds WHERE id = ?"))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	hold, err := scanHold(stmt.QueryRow(id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &gateway.NotFoundError{Entity: "hold", ID: id}
+		}
+		return nil, err
+	}
+	return hold, nil
+}
+
+func (h *HoldDB) Update(hold *entity.Hold) error {
+	stmt, err := h.DB.Prepare(h.Dialect.rebind("UPDATE holds SET status = ?, captured_amount = ?, transaction_id = ?, updated_at = ? WHERE id = ? AND status = ?"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(string(hold.Status), hold.CapturedAmount.Decimal(), nullString(hold.TransactionID), h.Dialect.timestamp(hold.UpdatedAt), hold.ID, string(entity.HoldActive))
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return &gateway.ConflictError{Entity: "hold", ID: hold.ID}
+	}
+	return nil
+}
+
+// FindExpired returns the active holds whose expiry is not after now, oldest
+// expiry first.
+func (h *HoldDB) FindExpired(now time.Time) ([]*entity.Hold, error) {
+	stmt, err := h.DB.Prepare(h.Dialect.rebind("SELECT id, account_id, amount, captured_amount, currency, status, transaction_id, expires_at, created_at, updated_at FROM holds WHERE status = ? AND expires_at <= ? ORDER BY expires_at, id"))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(string(entity.HoldActive), h.Dialect.timestamp(now))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holds := []*entity.Hold{}
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+func scanHold(row scanner) (*entity.Hold, error) {
+	var hold entity.Hold
+	var amount, capturedAmount decimal
+	var currency, status string
+	var transactionID sql.NullString
+	err := row.Scan(&hold.ID, &hold.AccountID, &amount, &capturedAmount, &currency, &status, &transactionID, &hold.ExpiresAt, &hold.CreatedAt, &hold.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	hold.Amount, err = amount.money(currency)
+	if err != nil {
+		return nil, err
+	}
+	hold.CapturedAmount, err = capturedAmount.money(currency)
+	if err != nil {
+		return nil, err
+	}
+	hold.Status = entity.HoldStatus(status)
+	hold.TransactionID = transactionID.String
+	return &hold, nil
+}
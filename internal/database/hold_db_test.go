@@ -0,0 +1,94 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+type HoldDBTestSuite struct {
+	suite.Suite
+	db      *sql.DB
+	account *entity.Account
+	holdDB  *HoldDB
+}
+
+func (s *HoldDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+
+	client, _ := entity.NewClient("John Doe", "john@example.com")
+	s.account = entity.NewAccount(client)
+	s.account.Credit(entity.NewMoney(1000_00, entity.DefaultCurrency))
+	s.holdDB = NewHoldDB(db)
+}
+
+func TestHoldDBTestSuite(t *testing.T) {
+	suite.Run(t, new(HoldDBTestSuite))
+}
+
+func (s *HoldDBTestSuite) TestSaveAndFindByID() {
+	hold, err := entity.NewHold(s.account, entity.NewMoney(100_00, entity.DefaultCurrency), time.Now().Add(time.Hour))
+	s.Nil(err)
+	s.Nil(s.holdDB.Save(hold))
+
+	retrievedHold, err := s.holdDB.FindByID(hold.ID)
+	s.Nil(err)
+	s.Equal(hold.ID, retrievedHold.ID)
+	s.Equal(s.account.ID, retrievedHold.AccountID)
+	s.Equal(entity.NewMoney(100_00, entity.DefaultCurrency), retrievedHold.Amount)
+	s.True(retrievedHold.CapturedAmount.IsZero())
+	s.Equal(entity.HoldActive, retrievedHold.Status)
+	s.Empty(retrievedHold.TransactionID)
+	s.True(hold.ExpiresAt.Equal(retrievedHold.ExpiresAt))
+}
+
+func (s *HoldDBTestSuite) TestFindByIDNotFound() {
+	hold, err := s.holdDB.FindByID("unknown")
+	s.Nil(hold)
+	s.ErrorIs(err, gateway.ErrNotFound)
+}
+
+func (s *HoldDBTestSuite) TestUpdateOnlyActiveHolds() {
+	hold, _ := entity.NewHold(s.account, entity.NewMoney(100_00, entity.DefaultCurrency), time.Now().Add(time.Hour))
+	s.Nil(s.holdDB.Save(hold))
+
+	merchant := entity.NewAccount(s.account.Client)
+	transaction, err := hold.Capture(s.account, merchant, entity.NewMoney(40_00, entity.DefaultCurrency), time.Now())
+	s.Nil(err)
+	s.Nil(s.holdDB.Update(hold))
+
+	retrievedHold, err := s.holdDB.FindByID(hold.ID)
+	s.Nil(err)
+	s.Equal(entity.HoldCaptured, retrievedHold.Status)
+	s.Equal(entity.NewMoney(40_00, entity.DefaultCurrency), retrievedHold.CapturedAmount)
+	s.Equal(transaction.ID, retrievedHold.TransactionID)
+
+	err = s.holdDB.Update(hold)
+	s.ErrorIs(err, gateway.ErrConflict)
+}
+
+func (s *HoldDBTestSuite) TestFindExpired() {
+	now := time.Now()
+	expiring, _ := entity.NewHold(s.account, entity.NewMoney(10_00, entity.DefaultCurrency), now.Add(time.Minute))
+	later, _ := entity.NewHold(s.account, entity.NewMoney(10_00, entity.DefaultCurrency), now.Add(time.Hour))
+	voided, _ := entity.NewHold(s.account, entity.NewMoney(10_00, entity.DefaultCurrency), now.Add(time.Minute))
+	voided.Void(s.account, now)
+	for _, hold := range []*entity.Hold{expiring, later, voided} {
+		s.Nil(s.holdDB.Save(hold))
+	}
+
+	holds, err := s.holdDB.FindExpired(now)
+	s.Nil(err)
+	s.Empty(holds)
+
+	holds, err = s.holdDB.FindExpired(now.Add(2 * time.Minute))
+	s.Nil(err)
+	s.Len(holds, 1)
+	s.Equal(expiring.ID, holds[0].ID)
+}
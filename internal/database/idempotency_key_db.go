@@ -0,0 +1,69 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type IdempotencyKeyDB struct {
+	DB      DBTX
+	Dialect Dialect
+}
+
+func NewIdempotencyKeyDB(db DBTX) *IdempotencyKeyDB {
+	return &IdempotencyKeyDB{
+		DB: db,
+	}
+}
+
+// Save relies on the unique constraint on idempotency_key: a key that is
+// already stored, possibly by a concurrent request, is reported as a
+// *gateway.ConflictError. MySQL has no ON CONFLICT clause, but a duplicate
+// key there fails the statement without aborting the transaction.
+func (i *IdempotencyKeyDB) Save(key *entity.IdempotencyKey) error {
+	query := "INSERT INTO idempotency_keys (idempotency_key, request_hash, transaction_id, created_at) VALUES (?, ?, ?, ?)"
+	if i.Dialect != MySQL {
+		query += " ON CONFLICT (idempotency_key) DO NOTHING"
+	}
+	stmt, err := i.DB.Prepare(i.Dialect.rebind(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(key.Key, key.RequestHash, key.TransactionID, i.Dialect.timestamp(key.CreatedAt))
+	if err != nil {
+		if isDuplicateKey(err) {
+			return &gateway.ConflictError{Entity: "idempotency key", ID: key.Key}
+		}
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return &gateway.ConflictError{Entity: "idempotency key", ID: key.Key}
+	}
+	return nil
+}
+
+func (i *IdempotencyKeyDB) FindByKey(key string) (*entity.IdempotencyKey, error) {
+	stmt, err := i.DB.Prepare(i.Dialect.rebind("SELECT idempotency_key, request_hash, transaction_id, created_at FROM idempotency_keys WHERE idempotency_key = ?"))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var idempotencyKey entity.IdempotencyKey
+	row := stmt.QueryRow(key)
+	if err := row.Scan(&idempotencyKey.Key, &idempotencyKey.RequestHash, &idempotencyKey.TransactionID, &idempotencyKey.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &gateway.NotFoundError{Entity: "idempotency key", ID: key}
+		}
+		return nil, err
+	}
+	return &idempotencyKey, nil
+}
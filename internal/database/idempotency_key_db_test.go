@@ -0,0 +1,58 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyKeyDBTestSuite struct {
+	suite.Suite
+	db               *sql.DB
+	idempotencyKeyDB *IdempotencyKeyDB
+}
+
+func (s *IdempotencyKeyDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+	s.idempotencyKeyDB = NewIdempotencyKeyDB(db)
+}
+
+func TestIdempotencyKeyDBTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyKeyDBTestSuite))
+}
+
+func (s *IdempotencyKeyDBTestSuite) TestSaveAndFindByKey() {
+	key, _ := entity.NewIdempotencyKey("key-1", "hash", "transaction-1")
+	err := s.idempotencyKeyDB.Save(key)
+	s.Nil(err)
+
+	retrievedKey, err := s.idempotencyKeyDB.FindByKey("key-1")
+	s.Nil(err)
+	s.Equal("key-1", retrievedKey.Key)
+	s.Equal("hash", retrievedKey.RequestHash)
+	s.Equal("transaction-1", retrievedKey.TransactionID)
+}
+
+func (s *IdempotencyKeyDBTestSuite) TestSaveWithDuplicateKey() {
+	key, _ := entity.NewIdempotencyKey("key-1", "hash", "transaction-1")
+	s.Nil(s.idempotencyKeyDB.Save(key))
+
+	duplicate, _ := entity.NewIdempotencyKey("key-1", "other-hash", "transaction-2")
+	err := s.idempotencyKeyDB.Save(duplicate)
+	s.ErrorIs(err, gateway.ErrConflict)
+
+	retrievedKey, err := s.idempotencyKeyDB.FindByKey("key-1")
+	s.Nil(err)
+	s.Equal("transaction-1", retrievedKey.TransactionID)
+}
+
+func (s *IdempotencyKeyDBTestSuite) TestFindByKeyNotFound() {
+	retrievedKey, err := s.idempotencyKeyDB.FindByKey("unknown")
+	s.Nil(retrievedKey)
+	s.ErrorIs(err, gateway.ErrNotFound)
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+type LedgerDB struct {
+	DB      DBTX
+	Dialect Dialect
+}
+
+func NewLedgerDB(db DBTX) *LedgerDB {
+	return &LedgerDB{
+		DB: db,
+	}
+}
+
+// Save inserts a balanced journal. Unbalanced entries are rejected before
+// anything is written.
+func (l *LedgerDB) Save(entries []*entity.LedgerEntry) error {
+	if err := entity.ValidateJournal(entries); err != nil {
+		return err
+	}
+
+	stmt, err := l.DB.Prepare(l.Dialect.rebind("INSERT INTO ledger_entries (id, transaction_id, account_id, direction, amount, currency, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, entry := range entries {
+		_, err = stmt.Exec(entry.ID, entry.TransactionID, entry.AccountID, string(entry.Direction), entry.Amount.Decimal(), entry.Amount.Currency(), l.Dialect.timestamp(entry.CreatedAt))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByAccountID returns the entries of an account, oldest first.
+func (l *LedgerDB) FindByAccountID(accountID string) ([]*entity.LedgerEntry, error) {
+	stmt, err := l.DB.Prepare(l.Dialect.rebind("SELECT id, transaction_id, account_id, direction, amount, currency, created_at FROM ledger_entries WHERE account_id = ? ORDER BY created_at, id"))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*entity.LedgerEntry{}
+	for rows.Next() {
+		var entry entity.LedgerEntry
+		var direction string
+		var amount decimal
+		var currency string
+		if err := rows.Scan(&entry.ID, &entry.TransactionID, &entry.AccountID, &direction, &amount, &currency, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entry.Direction = entity.EntryDirection(direction)
+		entry.Amount, err = amount.money(currency)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/stretchr/testify/suite"
+)
+
+type LedgerDBTestSuite struct {
+	suite.Suite
+	db       *sql.DB
+	ledgerDB *LedgerDB
+}
+
+func (s *LedgerDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+	s.ledgerDB = NewLedgerDB(db)
+}
+
+func TestLedgerDBTestSuite(t *testing.T) {
+	suite.Run(t, new(LedgerDBTestSuite))
+}
+
+func (s *LedgerDBTestSuite) TestSaveAndFindByAccountID() {
+	first := time.Now()
+	second := first.Add(time.Second)
+	err := s.ledgerDB.Save([]*entity.LedgerEntry{
+		entity.NewLedgerEntry("t1", "a1", entity.EntryDebit, entity.NewMoney(10_50, entity.DefaultCurrency), first),
+		entity.NewLedgerEntry("t1", "a2", entity.EntryCredit, entity.NewMoney(10_50, entity.DefaultCurrency), first),
+	})
+	s.Nil(err)
+	err = s.ledgerDB.Save([]*entity.LedgerEntry{
+		entity.NewLedgerEntry("t2", "a2", entity.EntryDebit, entity.NewMoney(3_25, entity.DefaultCurrency), second),
+		entity.NewLedgerEntry("t2", "a1", entity.EntryCredit, entity.NewMoney(3_25, entity.DefaultCurrency), second),
+	})
+	s.Nil(err)
+
+	entries, err := s.ledgerDB.FindByAccountID("a1")
+	s.Nil(err)
+	s.Len(entries, 2)
+	s.Equal("t1", entries[0].TransactionID)
+	s.Equal(entity.EntryDebit, entries[0].Direction)
+	s.Equal(entity.NewMoney(10_50, entity.DefaultCurrency), entries[0].Amount)
+	s.Equal("t2", entries[1].TransactionID)
+	s.Equal(entity.EntryCredit, entries[1].Direction)
+
+	balance, err := entity.BalanceFromEntries(entity.DefaultCurrency, entries)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(-7_25, entity.DefaultCurrency), balance)
+}
+
+func (s *LedgerDBTestSuite) TestSaveRejectsUnbalancedEntries() {
+	err := s.ledgerDB.Save([]*entity.LedgerEntry{
+		entity.NewLedgerEntry("t1", "a1", entity.EntryDebit, entity.NewMoney(10_50, entity.DefaultCurrency), time.Now()),
+	})
+	s.ErrorIs(err, entity.ErrUnbalancedJournal)
+
+	entries, err := s.ledgerDB.FindByAccountID("a1")
+	s.Nil(err)
+	s.Empty(entries)
+}
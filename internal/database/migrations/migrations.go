@@ -0,0 +1,55 @@
+// Package migrations embeds the SQL migrations defining the wallet schema,
+// one set per supported SQL dialect.
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/migrate"
+)
+
+//go:embed sqlite/*.sql mysql/*.sql postgres/*.sql
+var files embed.FS
+
+// Locks held while migrating MySQL and PostgreSQL, which several walletcore
+// instances starting together may migrate at once. SQLite needs none: it
+// takes one writer at a time and database.Open gives it one connection.
+const (
+	mysqlLockName   = "wallet_schema_migrations"
+	postgresLockKey = 0x77616c6c6574 // "wallet"
+)
+
+// FS returns the migrations for the named dialect, "sqlite", "mysql" or
+// "postgres".
+func FS(dialect string) (fs.FS, error) {
+	switch dialect {
+	case "sqlite", "mysql", "postgres":
+		return fs.Sub(files, dialect)
+	}
+	return nil, fmt.Errorf("no migrations for dialect %q", dialect)
+}
+
+// NewMigrator returns a migrator applying the migrations for the named
+// dialect to db. On MySQL, where DDL statements commit implicitly, a
+// migration failing halfway is not rolled back; see migrate.Migrator.
+func NewMigrator(db *sql.DB, dialect string) (*migrate.Migrator, error) {
+	fsys, err := FS(dialect)
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := migrate.NewMigrator(db, fsys)
+	if err != nil {
+		return nil, err
+	}
+	switch dialect {
+	case "mysql":
+		migrator.Lock = migrate.MySQLLock(mysqlLockName)
+	case "postgres":
+		migrator.Placeholder = migrate.Dollar
+		migrator.Lock = migrate.PostgresLock(postgresLockKey)
+	}
+	return migrator, nil
+}
@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/migrate"
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+)
+
+func TestMigrationsRoundTrip(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	ctx := context.Background()
+
+	migrator, err := NewMigrator(db, "sqlite")
+	assert.Nil(t, err)
+
+	applied, err := migrator.Up(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, applied, len(migrator.Migrations))
+
+	reverted, err := migrator.Down(ctx, len(migrator.Migrations))
+	assert.Nil(t, err)
+	assert.Len(t, reverted, len(migrator.Migrations))
+
+	var tables int
+	assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'schema_migrations'").Scan(&tables))
+	assert.Equal(t, 0, tables)
+
+	_, err = migrator.Up(ctx)
+	assert.Nil(t, err)
+}
+
+func TestDialectsDefineTheSameMigrations(t *testing.T) {
+	sqlite, err := FS("sqlite")
+	assert.Nil(t, err)
+	expected, err := migrate.Load(sqlite)
+	assert.Nil(t, err)
+
+	for _, dialect := range []string{"mysql", "postgres"} {
+		fsys, err := FS(dialect)
+		assert.Nil(t, err)
+		actual, err := migrate.Load(fsys)
+		assert.Nil(t, err)
+
+		assert.Len(t, actual, len(expected), dialect)
+		for i := range expected {
+			assert.Equal(t, expected[i].Version, actual[i].Version, dialect)
+			assert.Equal(t, expected[i].Name, actual[i].Name, dialect)
+		}
+	}
+
+	_, err = FS("oracle")
+	assert.Error(t, err)
+}
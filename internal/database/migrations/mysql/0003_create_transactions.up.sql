@@ -0,0 +1,20 @@
+CREATE TABLE transactions (
+    id varchar(255) PRIMARY KEY,
+    kind varchar(10) NOT NULL DEFAULT 'transfer',
+    account_id_from varchar(255) NOT NULL,
+    account_id_to varchar(255) NOT NULL,
+    amount decimal(20, 2) NOT NULL,
+    currency varchar(3) NOT NULL,
+    reversal_of varchar(255),
+    fee_of varchar(255),
+    created_at datetime(6) NOT NULL,
+    CONSTRAINT transactions_account_id_from_fk FOREIGN KEY (account_id_from) REFERENCES accounts (id),
+    CONSTRAINT transactions_account_id_to_fk FOREIGN KEY (account_id_to) REFERENCES accounts (id),
+    CONSTRAINT transactions_reversal_of_fk FOREIGN KEY (reversal_of) REFERENCES transactions (id),
+    CONSTRAINT transactions_fee_of_fk FOREIGN KEY (fee_of) REFERENCES transactions (id)
+);
+
+CREATE INDEX transactions_account_id_from ON transactions (account_id_from, created_at);
+CREATE INDEX transactions_account_id_to ON transactions (account_id_to, created_at);
+CREATE INDEX transactions_reversal_of ON transactions (reversal_of);
+CREATE INDEX transactions_fee_of ON transactions (fee_of);
@@ -0,0 +1,13 @@
+CREATE TABLE ledger_entries (
+    id varchar(255) PRIMARY KEY,
+    transaction_id varchar(255) NOT NULL,
+    account_id varchar(255) NOT NULL,
+    direction varchar(6) NOT NULL,
+    amount decimal(20, 2) NOT NULL,
+    currency varchar(3) NOT NULL,
+    created_at datetime(6) NOT NULL,
+    CONSTRAINT ledger_entries_transaction_id_fk FOREIGN KEY (transaction_id) REFERENCES transactions (id),
+    CONSTRAINT ledger_entries_account_id_fk FOREIGN KEY (account_id) REFERENCES accounts (id)
+);
+
+CREATE INDEX ledger_entries_account_id ON ledger_entries (account_id, created_at);
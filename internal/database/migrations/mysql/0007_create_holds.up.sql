@@ -0,0 +1,15 @@
+CREATE TABLE holds (
+    id varchar(255) PRIMARY KEY,
+    account_id varchar(255) NOT NULL,
+    amount decimal(20, 2) NOT NULL,
+    captured_amount decimal(20, 2) NOT NULL,
+    currency varchar(3) NOT NULL,
+    status varchar(10) NOT NULL,
+    transaction_id varchar(255),
+    expires_at datetime(6) NOT NULL,
+    created_at datetime(6) NOT NULL,
+    updated_at datetime(6) NOT NULL,
+    CONSTRAINT holds_account_id_fk FOREIGN KEY (account_id) REFERENCES accounts (id)
+);
+
+CREATE INDEX holds_status_expires_at ON holds (status, expires_at);
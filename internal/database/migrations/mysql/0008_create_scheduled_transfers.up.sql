@@ -0,0 +1,30 @@
+CREATE TABLE scheduled_transfers (
+    id varchar(255) PRIMARY KEY,
+    account_id_from varchar(255) NOT NULL,
+    account_id_to varchar(255) NOT NULL,
+    amount decimal(20, 2) NOT NULL,
+    currency varchar(3) NOT NULL,
+    recurrence varchar(255),
+    next_run_at datetime(6) NOT NULL,
+    status varchar(10) NOT NULL,
+    consecutive_failures int NOT NULL DEFAULT 0,
+    created_at datetime(6) NOT NULL,
+    updated_at datetime(6) NOT NULL,
+    CONSTRAINT scheduled_transfers_account_id_from_fk FOREIGN KEY (account_id_from) REFERENCES accounts (id),
+    CONSTRAINT scheduled_transfers_account_id_to_fk FOREIGN KEY (account_id_to) REFERENCES accounts (id)
+);
+
+CREATE INDEX scheduled_transfers_status_next_run_at ON scheduled_transfers (status, next_run_at);
+
+CREATE TABLE scheduled_transfer_runs (
+    id varchar(255) PRIMARY KEY,
+    scheduled_transfer_id varchar(255) NOT NULL,
+    status varchar(10) NOT NULL,
+    transaction_id varchar(255),
+    error text,
+    scheduled_for datetime(6) NOT NULL,
+    ran_at datetime(6) NOT NULL,
+    CONSTRAINT scheduled_transfer_runs_scheduled_transfer_id_fk FOREIGN KEY (scheduled_transfer_id) REFERENCES scheduled_transfers (id)
+);
+
+CREATE INDEX scheduled_transfer_runs_scheduled_transfer_id ON scheduled_transfer_runs (scheduled_transfer_id, ran_at);
@@ -0,0 +1,13 @@
+CREATE TABLE outbox (
+    sequence bigint PRIMARY KEY AUTO_INCREMENT,
+    id varchar(255) NOT NULL UNIQUE,
+    event_name varchar(255) NOT NULL,
+    event_key varchar(255) NOT NULL,
+    payload blob NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    last_error text,
+    created_at datetime(6) NOT NULL,
+    sent_at datetime(6)
+);
+
+CREATE INDEX outbox_sent_at ON outbox (sent_at, sequence);
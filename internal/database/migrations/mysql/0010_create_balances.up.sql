@@ -0,0 +1,10 @@
+CREATE TABLE balances (
+    account_id varchar(255) PRIMARY KEY,
+    balance decimal(20, 2) NOT NULL,
+    held_amount decimal(20, 2) NOT NULL,
+    currency varchar(3) NOT NULL,
+    version int NOT NULL,
+    last_transaction_id varchar(255),
+    last_transaction_at datetime(6),
+    updated_at datetime(6) NOT NULL
+);
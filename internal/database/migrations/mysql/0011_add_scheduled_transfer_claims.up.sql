@@ -0,0 +1 @@
+ALTER TABLE scheduled_transfers ADD COLUMN claimed_until datetime(6);
@@ -0,0 +1 @@
+UPDATE balances SET version = 0 WHERE version = -1;
@@ -0,0 +1,7 @@
+CREATE TABLE clients (
+    id varchar(255) PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    email varchar(255) NOT NULL,
+    tier varchar(20) NOT NULL DEFAULT 'standard',
+    created_at timestamp
+);
@@ -0,0 +1,16 @@
+CREATE TABLE transactions (
+    id varchar(255) PRIMARY KEY,
+    kind varchar(10) NOT NULL DEFAULT 'transfer',
+    account_id_from varchar(255) NOT NULL REFERENCES accounts (id),
+    account_id_to varchar(255) NOT NULL REFERENCES accounts (id),
+    amount numeric(20, 2) NOT NULL,
+    currency varchar(3) NOT NULL,
+    reversal_of varchar(255) REFERENCES transactions (id),
+    fee_of varchar(255) REFERENCES transactions (id),
+    created_at timestamp NOT NULL
+);
+
+CREATE INDEX transactions_account_id_from ON transactions (account_id_from, created_at);
+CREATE INDEX transactions_account_id_to ON transactions (account_id_to, created_at);
+CREATE INDEX transactions_reversal_of ON transactions (reversal_of);
+CREATE INDEX transactions_fee_of ON transactions (fee_of);
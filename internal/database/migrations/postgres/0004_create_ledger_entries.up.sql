@@ -0,0 +1,11 @@
+CREATE TABLE ledger_entries (
+    id varchar(255) PRIMARY KEY,
+    transaction_id varchar(255) NOT NULL REFERENCES transactions (id),
+    account_id varchar(255) NOT NULL REFERENCES accounts (id),
+    direction varchar(6) NOT NULL,
+    amount numeric(20, 2) NOT NULL,
+    currency varchar(3) NOT NULL,
+    created_at timestamp NOT NULL
+);
+
+CREATE INDEX ledger_entries_account_id ON ledger_entries (account_id, created_at);
@@ -0,0 +1,6 @@
+CREATE TABLE idempotency_keys (
+    idempotency_key varchar(255) PRIMARY KEY,
+    request_hash varchar(64) NOT NULL,
+    transaction_id varchar(255) NOT NULL,
+    created_at timestamp NOT NULL
+);
@@ -0,0 +1,12 @@
+CREATE TABLE overdraft_limit_changes (
+    id varchar(255) PRIMARY KEY,
+    account_id varchar(255) NOT NULL REFERENCES accounts (id),
+    previous_limit numeric(20, 2) NOT NULL,
+    new_limit numeric(20, 2) NOT NULL,
+    currency varchar(3) NOT NULL,
+    changed_by varchar(255),
+    reason varchar(255),
+    created_at timestamp NOT NULL
+);
+
+CREATE INDEX overdraft_limit_changes_account_id ON overdraft_limit_changes (account_id, created_at);
@@ -0,0 +1,14 @@
+CREATE TABLE holds (
+    id varchar(255) PRIMARY KEY,
+    account_id varchar(255) NOT NULL REFERENCES accounts (id),
+    amount numeric(20, 2) NOT NULL,
+    captured_amount numeric(20, 2) NOT NULL,
+    currency varchar(3) NOT NULL,
+    status varchar(10) NOT NULL,
+    transaction_id varchar(255),
+    expires_at timestamp NOT NULL,
+    created_at timestamp NOT NULL,
+    updated_at timestamp NOT NULL
+);
+
+CREATE INDEX holds_status_expires_at ON holds (status, expires_at);
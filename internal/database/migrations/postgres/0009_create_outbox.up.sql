@@ -0,0 +1,13 @@
+CREATE TABLE outbox (
+    sequence bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    id varchar(255) NOT NULL UNIQUE,
+    event_name varchar(255) NOT NULL,
+    event_key varchar(255) NOT NULL,
+    payload bytea NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    last_error text,
+    created_at timestamp NOT NULL,
+    sent_at timestamp
+);
+
+CREATE INDEX outbox_sent_at ON outbox (sent_at, sequence);
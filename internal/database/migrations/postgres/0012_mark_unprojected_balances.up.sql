@@ -0,0 +1 @@
+UPDATE balances SET version = -1 WHERE version = 0;
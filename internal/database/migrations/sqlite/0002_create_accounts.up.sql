@@ -0,0 +1,13 @@
+CREATE TABLE accounts (
+    id varchar(255) PRIMARY KEY,
+    client_id varchar(255) NOT NULL REFERENCES clients (id),
+    balance decimal(20, 2),
+    held_amount decimal(20, 2),
+    overdraft_limit decimal(20, 2),
+    currency varchar(3),
+    status varchar(10),
+    version int NOT NULL DEFAULT 0,
+    created_at datetime
+);
+
+CREATE INDEX accounts_client_id ON accounts (client_id);
@@ -0,0 +1 @@
+DROP TABLE overdraft_limit_changes;
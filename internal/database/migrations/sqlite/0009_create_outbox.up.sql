@@ -0,0 +1,13 @@
+CREATE TABLE outbox (
+    sequence INTEGER PRIMARY KEY AUTOINCREMENT,
+    id varchar(255) NOT NULL UNIQUE,
+    event_name varchar(255) NOT NULL,
+    event_key varchar(255) NOT NULL,
+    payload blob NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    last_error text,
+    created_at datetime NOT NULL,
+    sent_at datetime
+);
+
+CREATE INDEX outbox_sent_at ON outbox (sent_at, sequence);
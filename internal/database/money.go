@@ -0,0 +1,34 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+// decimal scans a DECIMAL/NUMERIC column as its textual representation.
+// Drivers return such columns as strings, bytes, integers or floats
+// (SQLite stores them with numeric affinity), and the default conversion
+// of floats to strings may use exponent notation, which Money cannot parse.
+type decimal string
+
+func (d *decimal) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		*d = decimal(v)
+	case []byte:
+		*d = decimal(v)
+	case int64:
+		*d = decimal(strconv.FormatInt(v, 10))
+	case float64:
+		*d = decimal(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("cannot scan %T into decimal", src)
+	}
+	return nil
+}
+
+func (d decimal) money(currency string) (entity.Money, error) {
+	return entity.ParseMoney(string(d), currency)
+}
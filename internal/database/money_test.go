@@ -0,0 +1,30 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimalScan(t *testing.T) {
+	tests := []struct {
+		src      any
+		expected entity.Money
+	}{
+		{"10.50", entity.NewMoney(10_50, entity.DefaultCurrency)},
+		{[]byte("0.07"), entity.NewMoney(7, entity.DefaultCurrency)},
+		{int64(1000000), entity.NewMoney(1000000_00, entity.DefaultCurrency)},
+		{float64(1234567.5), entity.NewMoney(1234567_50, entity.DefaultCurrency)},
+	}
+	for _, tt := range tests {
+		var d decimal
+		assert.NoError(t, d.Scan(tt.src))
+		money, err := d.money(entity.DefaultCurrency)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, money)
+	}
+
+	var d decimal
+	assert.Error(t, d.Scan(nil))
+}
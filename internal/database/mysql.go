@@ -0,0 +1,35 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/mysqldsn"
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL error numbers for a duplicate key, and for a transaction rolled back
+// to break a deadlock.
+const (
+	erDupEntry     = 1062
+	erLockDeadlock = 1213
+)
+
+// mysqlDSN adds the options of mysqldsn.Configure to dsn.
+func mysqlDSN(dsn string) (string, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return "", err
+	}
+	mysqldsn.Configure(cfg)
+	return cfg.FormatDSN(), nil
+}
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == erDupEntry
+}
+
+func isMySQLDeadlock(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == erLockDeadlock
+}
@@ -0,0 +1,27 @@
+// Package mysqldsn holds the MySQL driver options the database gateways rely
+// on, shared by database.Open and the test databases of databasetest. It is a
+// package of its own because the tests of package database import
+// databasetest, which therefore cannot import package database.
+package mysqldsn
+
+import (
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Configure sets the driver options the gateways rely on: DATETIME columns
+// are scanned as time.Time in UTC, and UPDATE reports the rows it matched
+// rather than the rows it changed, so an update writing the stored values
+// again is not mistaken for a missing row. Transactions run at READ
+// COMMITTED, as on PostgreSQL, so that a read made after taking a row lock
+// sees what the previous holder of the lock committed.
+func Configure(cfg *mysql.Config) {
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+	cfg.ClientFoundRows = true
+	if cfg.Params == nil {
+		cfg.Params = map[string]string{}
+	}
+	cfg.Params["transaction_isolation"] = "'READ-COMMITTED'"
+}
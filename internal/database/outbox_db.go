@@ -0,0 +1,97 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// OutboxDB stores messages in the outbox table, whose auto-incremented
+// sequence column keeps the order they were saved in.
+type OutboxDB struct {
+	DB      DBTX
+	Dialect Dialect
+}
+
+func NewOutboxDB(db DBTX) *OutboxDB {
+	return &OutboxDB{
+		DB: db,
+	}
+}
+
+func (o *OutboxDB) Save(messages []*entity.OutboxMessage) error {
+	stmt, err := o.DB.Prepare(o.Dialect.rebind("INSERT INTO outbox (id, event_name, event_key, payload, attempts, created_at) VALUES (?, ?, ?, ?, ?, ?)"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, message := range messages {
+		_, err = stmt.Exec(message.ID, message.EventName, message.Key, message.Payload, message.Attempts, o.Dialect.timestamp(message.CreatedAt))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *OutboxDB) FindUnsent(limit int) ([]*entity.OutboxMessage, error) {
+	stmt, err := o.DB.Prepare(o.Dialect.rebind("SELECT id, event_name, event_key, payload, attempts, last_error, created_at FROM outbox WHERE sent_at IS NULL ORDER BY sequence LIMIT ?"))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []*entity.OutboxMessage{}
+	for rows.Next() {
+		message := &entity.OutboxMessage{}
+		var lastError sql.NullString
+		err := rows.Scan(&message.ID, &message.EventName, &message.Key, &message.Payload, &message.Attempts, &lastError, &message.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		message.LastError = lastError.String
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (o *OutboxDB) MarkSent(id string, sentAt time.Time) error {
+	return o.update(id, "UPDATE outbox SET sent_at = ? WHERE id = ?", o.Dialect.timestamp(sentAt), id)
+}
+
+func (o *OutboxDB) RecordFailure(id string, cause string) error {
+	return o.update(id, "UPDATE outbox SET attempts = attempts + 1, last_error = ? WHERE id = ?", cause, id)
+}
+
+func (o *OutboxDB) update(id string, query string, values ...any) error {
+	stmt, err := o.DB.Prepare(o.Dialect.rebind(query))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(values...)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return &gateway.NotFoundError{Entity: "outbox message", ID: id}
+	}
+	return nil
+}
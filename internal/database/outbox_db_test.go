@@ -0,0 +1,87 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxDBTestSuite struct {
+	suite.Suite
+	db       *sql.DB
+	outboxDB *OutboxDB
+}
+
+func (s *OutboxDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+	s.outboxDB = NewOutboxDB(db)
+}
+
+func TestOutboxDBTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxDBTestSuite))
+}
+
+func (s *OutboxDBTestSuite) TestSaveAndFindUnsentInOrder() {
+	first, _ := entity.NewOutboxMessage("TransactionCreated", "account-1", []byte(`{"n":1}`))
+	second, _ := entity.NewOutboxMessage("BalanceUpdated", "account-1", []byte(`{"n":2}`))
+	third, _ := entity.NewOutboxMessage("BalanceUpdated", "account-2", []byte(`{"n":3}`))
+	// Saved out of creation order on purpose: the sequence decides.
+	third.CreatedAt = first.CreatedAt.Add(-time.Hour)
+	s.Nil(s.outboxDB.Save([]*entity.OutboxMessage{first, second}))
+	s.Nil(s.outboxDB.Save([]*entity.OutboxMessage{third}))
+
+	messages, err := s.outboxDB.FindUnsent(10)
+	s.Nil(err)
+	s.Len(messages, 3)
+	s.Equal(first.ID, messages[0].ID)
+	s.Equal("TransactionCreated", messages[0].EventName)
+	s.Equal("account-1", messages[0].Key)
+	s.Equal([]byte(`{"n":1}`), messages[0].Payload)
+	s.Equal(second.ID, messages[1].ID)
+	s.Equal(third.ID, messages[2].ID)
+
+	messages, err = s.outboxDB.FindUnsent(1)
+	s.Nil(err)
+	s.Len(messages, 1)
+}
+
+func (s *OutboxDBTestSuite) TestMarkSent() {
+	first, _ := entity.NewOutboxMessage("TransactionCreated", "account-1", []byte(`{}`))
+	second, _ := entity.NewOutboxMessage("BalanceUpdated", "account-1", []byte(`{}`))
+	s.Nil(s.outboxDB.Save([]*entity.OutboxMessage{first, second}))
+
+	s.Nil(s.outboxDB.MarkSent(first.ID, time.Now()))
+
+	messages, err := s.outboxDB.FindUnsent(10)
+	s.Nil(err)
+	s.Len(messages, 1)
+	s.Equal(second.ID, messages[0].ID)
+}
+
+func (s *OutboxDBTestSuite) TestRecordFailure() {
+	message, _ := entity.NewOutboxMessage("TransactionCreated", "account-1", []byte(`{}`))
+	s.Nil(s.outboxDB.Save([]*entity.OutboxMessage{message}))
+
+	s.Nil(s.outboxDB.RecordFailure(message.ID, "broker unavailable"))
+	s.Nil(s.outboxDB.RecordFailure(message.ID, "timeout"))
+
+	messages, err := s.outboxDB.FindUnsent(10)
+	s.Nil(err)
+	s.Len(messages, 1)
+	s.Equal(2, messages[0].Attempts)
+	s.Equal("timeout", messages[0].LastError)
+}
+
+func (s *OutboxDBTestSuite) TestUpdateUnknownMessage() {
+	err := s.outboxDB.MarkSent("unknown", time.Now())
+	s.ErrorIs(err, gateway.ErrNotFound)
+
+	err = s.outboxDB.RecordFailure("unknown", "cause")
+	s.ErrorIs(err, gateway.ErrNotFound)
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+type OverdraftLimitChangeDB struct {
+	DB      DBTX
+	Dialect Dialect
+}
+
+func NewOverdraftLimitChangeDB(db DBTX) *OverdraftLimitChangeDB {
+	return &OverdraftLimitChangeDB{
+		DB: db,
+	}
+}
+
+func (o *OverdraftLimitChangeDB) Save(change *entity.OverdraftLimitChange) error {
+	stmt, err := o.DB.Prepare(o.Dialect.rebind("INSERT INTO overdraft_limit_changes (id, account_id, previous_limit, new_limit, currency, changed_by, reason, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(change.ID, change.AccountID, change.PreviousLimit.Decimal(), change.NewLimit.Decimal(), change.NewLimit.Currency(), change.ChangedBy, change.Reason, o.Dialect.timestamp(change.CreatedAt))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindByAccountID returns the changes made to an account, oldest first.
+func (o *OverdraftLimitChangeDB) FindByAccountID(accountID string) ([]*entity.OverdraftLimitChange, error) {
+	stmt, err := o.DB.Prepare(o.Dialect.rebind("SELECT id, account_id, previous_limit, new_limit, currency, changed_by, reason, created_at FROM overdraft_limit_changes WHERE account_id = ? ORDER BY created_at, id"))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []*entity.OverdraftLimitChange{}
+	for rows.Next() {
+		var change entity.OverdraftLimitChange
+		var previousLimit, newLimit decimal
+		var currency string
+		if err := rows.Scan(&change.ID, &change.AccountID, &previousLimit, &newLimit, &currency, &change.ChangedBy, &change.Reason, &change.CreatedAt); err != nil {
+			return nil, err
+		}
+		change.PreviousLimit, err = previousLimit.money(currency)
+		if err != nil {
+			return nil, err
+		}
+		change.NewLimit, err = newLimit.money(currency)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
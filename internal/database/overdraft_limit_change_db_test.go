@@ -0,0 +1,46 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/stretchr/testify/suite"
+)
+
+type OverdraftLimitChangeDBTestSuite struct {
+	suite.Suite
+	db                     *sql.DB
+	overdraftLimitChangeDB *OverdraftLimitChangeDB
+}
+
+func (s *OverdraftLimitChangeDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+	s.overdraftLimitChangeDB = NewOverdraftLimitChangeDB(db)
+}
+
+func TestOverdraftLimitChangeDBTestSuite(t *testing.T) {
+	suite.Run(t, new(OverdraftLimitChangeDBTestSuite))
+}
+
+func (s *OverdraftLimitChangeDBTestSuite) TestSaveAndFindByAccountID() {
+	change, err := entity.NewOverdraftLimitChange("a1", entity.Zero(entity.DefaultCurrency), entity.NewMoney(500_00, entity.DefaultCurrency), "ops@example.com", "business account")
+	s.Nil(err)
+	err = s.overdraftLimitChangeDB.Save(change)
+	s.Nil(err)
+
+	changes, err := s.overdraftLimitChangeDB.FindByAccountID("a1")
+	s.Nil(err)
+	s.Len(changes, 1)
+	s.Equal(change.ID, changes[0].ID)
+	s.Equal(entity.Zero(entity.DefaultCurrency), changes[0].PreviousLimit)
+	s.Equal(entity.NewMoney(500_00, entity.DefaultCurrency), changes[0].NewLimit)
+	s.Equal("ops@example.com", changes[0].ChangedBy)
+	s.Equal("business account", changes[0].Reason)
+
+	changes, err = s.overdraftLimitChangeDB.FindByAccountID("a2")
+	s.Nil(err)
+	s.Empty(changes)
+}
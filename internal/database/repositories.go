@@ -0,0 +1,43 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+)
+
+// RegisterRepositories registers every gateway of this package in u under
+// its gateway repository name, writing its queries in dialect.
+func RegisterRepositories(u *uow.Uow, dialect Dialect) {
+	u.Register(gateway.ClientRepository, func(tx *sql.Tx) interface{} {
+		return &ClientDB{DB: tx, Dialect: dialect}
+	})
+	u.Register(gateway.AccountRepository, func(tx *sql.Tx) interface{} {
+		return &AccountDB{DB: tx, Dialect: dialect}
+	})
+	u.Register(gateway.TransactionRepository, func(tx *sql.Tx) interface{} {
+		return &TransactionDB{DB: tx, Dialect: dialect}
+	})
+	u.Register(gateway.LedgerRepository, func(tx *sql.Tx) interface{} {
+		return &LedgerDB{DB: tx, Dialect: dialect}
+	})
+	u.Register(gateway.IdempotencyKeyRepository, func(tx *sql.Tx) interface{} {
+		return &IdempotencyKeyDB{DB: tx, Dialect: dialect}
+	})
+	u.Register(gateway.OverdraftLimitChangeRepository, func(tx *sql.Tx) interface{} {
+		return &OverdraftLimitChangeDB{DB: tx, Dialect: dialect}
+	})
+	u.Register(gateway.HoldRepository, func(tx *sql.Tx) interface{} {
+		return &HoldDB{DB: tx, Dialect: dialect}
+	})
+	u.Register(gateway.ScheduledTransferRepository, func(tx *sql.Tx) interface{} {
+		return &ScheduledTransferDB{DB: tx, Dialect: dialect}
+	})
+	u.Register(gateway.OutboxRepository, func(tx *sql.Tx) interface{} {
+		return &OutboxDB{DB: tx, Dialect: dialect}
+	})
+	u.Register(gateway.BalanceRepository, func(tx *sql.Tx) interface{} {
+		return &BalanceDB{DB: tx, Dialect: dialect}
+	})
+}
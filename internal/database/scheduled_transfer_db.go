@@ -0,0 +1,184 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type ScheduledTransferDB struct {
+	DB      DBTX
+	Dialect Dialect
+}
+
+func NewScheduledTransferDB(db DBTX) *ScheduledTransferDB {
+	return &ScheduledTransferDB{
+		DB: db,
+	}
+}
+
+func (s *ScheduledTransferDB) Save(transfer *entity.ScheduledTransfer) error {
+	stmt, err := s.DB.Prepare(s.Dialect.rebind("INSERT INTO scheduled_transfers (id, account_id_from, account_id_to, amount, currency, recurrence, next_run_at, status, consecutive_failures, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(transfer.ID, transfer.AccountIDFrom, transfer.AccountIDTo, transfer.Amount.Decimal(), transfer.Amount.Currency(), transfer.Recurrence, s.Dialect.timestamp(transfer.NextRunAt), string(transfer.Status), transfer.ConsecutiveFailures, s.Dialect.timestamp(transfer.CreatedAt), s.Dialect.timestamp(transfer.UpdatedAt))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *ScheduledTransferDB) FindByID(id string) (*entity.ScheduledTransfer, error) {
+	stmt, err := s.DB.Prepare(s.Dialect.rebind("SELECT id, account_id_from, account_id_to, amount, currency, recurrence, next_run_at, status, consecutive_failures, created_at, updated_at FROM scheduled_transfers WHERE id = ?"))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	transfer, err := scanScheduledTransfer(stmt.QueryRow(id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &gateway.NotFoundError{Entity: "scheduled transfer", ID: id}
+		}
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (s *ScheduledTransferDB) Update(transfer *entity.ScheduledTransfer) error {
+	stmt, err := s.DB.Prepare(s.Dialect.rebind("UPDATE scheduled_transfers SET next_run_at = ?, status = ?, consecutive_failures = ?, updated_at = ?, claimed_until = NULL WHERE id = ?"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(s.Dialect.timestamp(transfer.NextRunAt), string(transfer.Status), transfer.ConsecutiveFailures, s.Dialect.timestamp(transfer.UpdatedAt), transfer.ID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return &gateway.NotFoundError{Entity: "scheduled transfer", ID: transfer.ID}
+	}
+	return nil
+}
+
+func (s *ScheduledTransferDB) FindDue(now time.Time, limit int) ([]*entity.ScheduledTransfer, error) {
+	stmt, err := s.DB.Prepare(s.Dialect.rebind("SELECT id, account_id_from, account_id_to, amount, currency, recurrence, next_run_at, status, consecutive_failures, created_at, updated_at FROM scheduled_transfers WHERE status = ? AND next_run_at <= ? AND (claimed_until IS NULL OR claimed_until <= ?) ORDER BY next_run_at, id LIMIT ?"))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(string(entity.ScheduledTransferActive), s.Dialect.timestamp(now), s.Dialect.timestamp(now), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := []*entity.ScheduledTransfer{}
+	for rows.Next() {
+		transfer, err := scanScheduledTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// Claim takes the transfer with a single conditional update, so of two
+// runners claiming it at once only one changes the row.
+func (s *ScheduledTransferDB) Claim(id string, now, until time.Time) error {
+	stmt, err := s.DB.Prepare(s.Dialect.rebind("UPDATE scheduled_transfers SET claimed_until = ? WHERE id = ? AND status = ? AND next_run_at <= ? AND (claimed_until IS NULL OR claimed_until <= ?)"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(s.Dialect.timestamp(until), id, string(entity.ScheduledTransferActive), s.Dialect.timestamp(now), s.Dialect.timestamp(now))
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return &gateway.ConflictError{Entity: "scheduled transfer", ID: id}
+	}
+	return nil
+}
+
+func (s *ScheduledTransferDB) SaveRun(run *entity.ScheduledTransferRun) error {
+	stmt, err := s.DB.Prepare(s.Dialect.rebind("INSERT INTO scheduled_transfer_runs (id, scheduled_transfer_id, status, transaction_id, error, scheduled_for, ran_at) VALUES (?, ?, ?, ?, ?, ?, ?)"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(run.ID, run.ScheduledTransferID, string(run.Status), nullString(run.TransactionID), run.Error, s.Dialect.timestamp(run.ScheduledFor), s.Dialect.timestamp(run.RanAt))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindRuns returns the runs of a transfer, oldest first.
+func (s *ScheduledTransferDB) FindRuns(transferID string) ([]*entity.ScheduledTransferRun, error) {
+	stmt, err := s.DB.Prepare(s.Dialect.rebind("SELECT id, scheduled_transfer_id, status, transaction_id, error, scheduled_for, ran_at FROM scheduled_transfer_runs WHERE scheduled_transfer_id = ? ORDER BY ran_at, id"))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []*entity.ScheduledTransferRun{}
+	for rows.Next() {
+		var run entity.ScheduledTransferRun
+		var status string
+		var transactionID sql.NullString
+		if err := rows.Scan(&run.ID, &run.ScheduledTransferID, &status, &transactionID, &run.Error, &run.ScheduledFor, &run.RanAt); err != nil {
+			return nil, err
+		}
+		run.Status = entity.ScheduledTransferRunStatus(status)
+		run.TransactionID = transactionID.String
+		runs = append(runs, &run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+func scanScheduledTransfer(row scanner) (*entity.ScheduledTransfer, error) {
+	var transfer entity.ScheduledTransfer
+	var amount decimal
+	var currency, status string
+	err := row.Scan(&transfer.ID, &transfer.AccountIDFrom, &transfer.AccountIDTo, &amount, &currency, &transfer.Recurrence, &transfer.NextRunAt, &status, &transfer.ConsecutiveFailures, &transfer.CreatedAt, &transfer.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	transfer.Amount, err = amount.money(currency)
+	if err != nil {
+		return nil, err
+	}
+	transfer.Status = entity.ScheduledTransferStatus(status)
+	return &transfer, nil
+}
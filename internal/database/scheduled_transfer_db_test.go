@@ -0,0 +1,88 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScheduledTransferDBTestSuite struct {
+	suite.Suite
+	db                  *sql.DB
+	scheduledTransferDB *ScheduledTransferDB
+}
+
+func (s *ScheduledTransferDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+	s.scheduledTransferDB = NewScheduledTransferDB(db)
+}
+
+func TestScheduledTransferDBTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledTransferDBTestSuite))
+}
+
+func (s *ScheduledTransferDBTestSuite) TestSaveAndFindByID() {
+	transfer, err := entity.NewScheduledTransfer("a1", "a2", entity.NewMoney(100_00, entity.DefaultCurrency), time.Time{}, "0 9 5 * *")
+	s.Nil(err)
+	s.Nil(s.scheduledTransferDB.Save(transfer))
+
+	retrievedTransfer, err := s.scheduledTransferDB.FindByID(transfer.ID)
+	s.Nil(err)
+	s.Equal("a1", retrievedTransfer.AccountIDFrom)
+	s.Equal("a2", retrievedTransfer.AccountIDTo)
+	s.Equal(entity.NewMoney(100_00, entity.DefaultCurrency), retrievedTransfer.Amount)
+	s.Equal("0 9 5 * *", retrievedTransfer.Recurrence)
+	s.True(transfer.NextRunAt.Equal(retrievedTransfer.NextRunAt))
+	s.Equal(entity.ScheduledTransferActive, retrievedTransfer.Status)
+
+	_, err = s.scheduledTransferDB.FindByID("unknown")
+	s.ErrorIs(err, gateway.ErrNotFound)
+}
+
+func (s *ScheduledTransferDBTestSuite) TestFindDueAndUpdate() {
+	now := time.Now()
+	due, _ := entity.NewScheduledTransfer("a1", "a2", entity.NewMoney(10_00, entity.DefaultCurrency), now.Add(-time.Hour), "")
+	later, _ := entity.NewScheduledTransfer("a1", "a2", entity.NewMoney(10_00, entity.DefaultCurrency), now.Add(time.Hour), "")
+	s.Nil(s.scheduledTransferDB.Save(due))
+	s.Nil(s.scheduledTransferDB.Save(later))
+
+	transfers, err := s.scheduledTransferDB.FindDue(now, 10)
+	s.Nil(err)
+	s.Len(transfers, 1)
+	s.Equal(due.ID, transfers[0].ID)
+
+	due.RecordFailure(entity.ErrInsufficientFunds, now, 3, 2*time.Hour)
+	s.Nil(s.scheduledTransferDB.Update(due))
+
+	transfers, err = s.scheduledTransferDB.FindDue(now.Add(90*time.Minute), 10)
+	s.Nil(err)
+	s.Len(transfers, 1)
+	s.Equal(later.ID, transfers[0].ID)
+
+	retrievedTransfer, err := s.scheduledTransferDB.FindByID(due.ID)
+	s.Nil(err)
+	s.Equal(1, retrievedTransfer.ConsecutiveFailures)
+}
+
+func (s *ScheduledTransferDBTestSuite) TestSaveRunAndFindRuns() {
+	transfer, _ := entity.NewScheduledTransfer("a1", "a2", entity.NewMoney(10_00, entity.DefaultCurrency), time.Now(), "")
+	failed := entity.NewScheduledTransferRun(transfer, "", entity.ErrInsufficientFunds, time.Now())
+	succeeded := entity.NewScheduledTransferRun(transfer, "t1", nil, time.Now().Add(time.Minute))
+	s.Nil(s.scheduledTransferDB.SaveRun(failed))
+	s.Nil(s.scheduledTransferDB.SaveRun(succeeded))
+
+	runs, err := s.scheduledTransferDB.FindRuns(transfer.ID)
+	s.Nil(err)
+	s.Len(runs, 2)
+	s.Equal(entity.ScheduledTransferRunFailed, runs[0].Status)
+	s.Equal("insufficient funds", runs[0].Error)
+	s.Empty(runs[0].TransactionID)
+	s.Equal(entity.ScheduledTransferRunSucceeded, runs[1].Status)
+	s.Equal("t1", runs[1].TransactionID)
+}
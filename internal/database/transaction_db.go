@@ -2,30 +2,164 @@ package database
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
 )
 
 type TransactionDB struct {
-	DB *sql.DB
+	DB      DBTX
+	Dialect Dialect
 }
 
-func NewTransactionDB(db *sql.DB) *TransactionDB {
+func NewTransactionDB(db DBTX) *TransactionDB {
 	return &TransactionDB{
 		DB: db,
 	}
 }
 
 func (t *TransactionDB) Save(transaction *entity.Transaction) error {
-	stmt, err := t.DB.Prepare("INSERT INTO transactions (id, account_id_from, account_id_to, amount, created_at) VALUES (?, ?, ?, ?, ?)")
+	stmt, err := t.DB.Prepare(t.Dialect.rebind("INSERT INTO transactions (id, kind, account_id_from, account_id_to, amount, currency, reversal_of, fee_of, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"))
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(transaction.ID, transaction.AccountFrom.ID, transaction.AccountTo.ID, transaction.Amount, transaction.CreatedAt)
+	_, err = stmt.Exec(transaction.ID, string(transaction.Kind), transaction.AccountFrom.ID, transaction.AccountTo.ID, transaction.Amount.Decimal(), transaction.Amount.Currency(), nullString(transaction.ReversalOf), nullString(transaction.FeeOf), t.Dialect.timestamp(transaction.CreatedAt))
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+const selectTransaction = "SELECT id, kind, account_id_from, account_id_to, amount, currency, reversal_of, fee_of, created_at FROM transactions WHERE id = ?"
+
+func (t *TransactionDB) FindByID(id string) (*entity.Transaction, error) {
+	return t.find(selectTransaction, id)
+}
+
+// FindByIDForUpdate loads a transaction like FindByID. Where the dialect
+// supports it the transaction row stays locked until the enclosing
+// transaction ends, so units of work acting on the same transaction run one
+// after the other. Losing a deadlock is reported as a *gateway.ConflictError.
+func (t *TransactionDB) FindByIDForUpdate(id string) (*entity.Transaction, error) {
+	return t.find(t.Dialect.forUpdate(selectTransaction), id)
+}
+
+func (t *TransactionDB) find(query string, id string) (*entity.Transaction, error) {
+	stmt, err := t.DB.Prepare(t.Dialect.rebind(query))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	transaction, err := scanTransaction(stmt.QueryRow(id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &gateway.NotFoundError{Entity: "transaction", ID: id}
+		}
+		return nil, lockConflict(err, "transaction", id)
+	}
+	return transaction, nil
+}
+
+// FindReversals returns the transactions reversing the given one, oldest
+// first.
+func (t *TransactionDB) FindReversals(transactionID string) ([]*entity.Transaction, error) {
+	return t.findAll("SELECT id, kind, account_id_from, account_id_to, amount, currency, reversal_of, fee_of, created_at FROM transactions WHERE reversal_of = ? ORDER BY created_at, id", transactionID)
+}
+
+// FindFees returns the fees charged for the given transaction.
+func (t *TransactionDB) FindFees(transactionID string) ([]*entity.Transaction, error) {
+	return t.findAll("SELECT id, kind, account_id_from, account_id_to, amount, currency, reversal_of, fee_of, created_at FROM transactions WHERE fee_of = ? ORDER BY created_at, id", transactionID)
+}
+
+// FindByAccountID returns the transactions sent or received by an account,
+// oldest first.
+func (t *TransactionDB) FindByAccountID(accountID string) ([]*entity.Transaction, error) {
+	return t.findAll("SELECT id, kind, account_id_from, account_id_to, amount, currency, reversal_of, fee_of, created_at FROM transactions WHERE account_id_from = ? OR account_id_to = ? ORDER BY created_at, id", accountID, accountID)
+}
+
+// OutgoingVolumeByAccount aggregates the transfers sent from an account.
+func (t *TransactionDB) OutgoingVolumeByAccount(accountID, currency string, since time.Time) (gateway.Volume, error) {
+	return t.outgoingVolume("SELECT COALESCE(ROUND(SUM(amount), 2), 0), COUNT(*) FROM transactions WHERE account_id_from = ? AND currency = ? AND created_at >= ? AND kind = 'transfer' AND reversal_of IS NULL AND fee_of IS NULL", accountID, currency, since)
+}
+
+// OutgoingVolumeByClient aggregates the transfers sent from every account of
+// a client.
+func (t *TransactionDB) OutgoingVolumeByClient(clientID, currency string, since time.Time) (gateway.Volume, error) {
+	return t.outgoingVolume("SELECT COALESCE(ROUND(SUM(t.amount), 2), 0), COUNT(*) FROM transactions t INNER JOIN accounts a ON a.id = t.account_id_from WHERE a.client_id = ? AND t.currency = ? AND t.created_at >= ? AND t.kind = 'transfer' AND t.reversal_of IS NULL AND t.fee_of IS NULL", clientID, currency, since)
+}
+
+// outgoingVolume runs an aggregate query. The sum is rounded to the minor
+// unit because SQLite adds decimals as floating point.
+func (t *TransactionDB) outgoingVolume(query, id, currency string, since time.Time) (gateway.Volume, error) {
+	stmt, err := t.DB.Prepare(t.Dialect.rebind(query))
+	if err != nil {
+		return gateway.Volume{}, err
+	}
+	defer stmt.Close()
+
+	var total decimal
+	var volume gateway.Volume
+	err = stmt.QueryRow(id, currency, t.Dialect.timestamp(since)).Scan(&total, &volume.Count)
+	if err != nil {
+		return gateway.Volume{}, err
+	}
+	volume.Total, err = total.money(currency)
+	if err != nil {
+		return gateway.Volume{}, err
+	}
+	return volume, nil
+}
+
+func (t *TransactionDB) findAll(query string, args ...any) ([]*entity.Transaction, error) {
+	stmt, err := t.DB.Prepare(t.Dialect.rebind(query))
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := []*entity.Transaction{}
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func scanTransaction(row scanner) (*entity.Transaction, error) {
+	transaction := &entity.Transaction{
+		AccountFrom: &entity.Account{},
+		AccountTo:   &entity.Account{},
+	}
+	var amount decimal
+	var currency string
+	var kind string
+	var reversalOf, feeOf sql.NullString
+	err := row.Scan(&transaction.ID, &kind, &transaction.AccountFrom.ID, &transaction.AccountTo.ID, &amount, &currency, &reversalOf, &feeOf, &transaction.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Amount, err = amount.money(currency)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Kind = entity.TransactionKind(kind)
+	transaction.ReversalOf = reversalOf.String
+	transaction.FeeOf = feeOf.String
+	return transaction, nil
+}
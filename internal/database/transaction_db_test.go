@@ -3,8 +3,11 @@ package database
 import (
 	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -19,12 +22,8 @@ type TransactionDBTestSuite struct {
 }
 
 func (s *TransactionDBTestSuite) SetupTest() {
-	db, err := sql.Open("sqlite", "file::memory:?cache=shared")
-	s.Nil(err)
+	db := databasetest.NewDB(s.T())
 	s.db = db
-	db.Exec("CREATE TABLE clients (id varchar(255), name varchar(255), email varchar(255), created_at date)")
-	db.Exec("CREATE TABLE accounts (id varchar(255), client_id varchar(255), balance decimal, created_at date, FOREIGN KEY(client_id) REFERENCES clients(id))")
-	db.Exec("CREATE TABLE transactions (id varchar(255), account_id_from varchar(255), account_id_to varchar(255), amount decimal, created_at date, FOREIGN KEY(account_id_from) REFERENCES accounts(id), FOREIGN KEY(account_id_to) REFERENCES accounts(id))")
 
 	client, err := entity.NewClient("John Doe", "john@example.com")
 	s.Nil(err)
@@ -34,30 +33,127 @@ func (s *TransactionDBTestSuite) SetupTest() {
 	s.client2 = client2
 
 	accountFrom := entity.NewAccount(s.client)
-	accountFrom.Balance = 1000
+	accountFrom.Balance = entity.NewMoney(1000_00, entity.DefaultCurrency)
 	s.accountFrom = accountFrom
 
 	accountTo := entity.NewAccount(s.client2)
-	accountTo.Balance = 500
+	accountTo.Balance = entity.NewMoney(500_00, entity.DefaultCurrency)
 	s.accountTo = accountTo
 
 	s.transactionDB = NewTransactionDB(db)
 }
 
-func (s *TransactionDBTestSuite) TearDownTest() {
-	defer s.db.Close()
-	s.db.Exec("DROP TABLE transactions")
-	s.db.Exec("DROP TABLE accounts")
-	s.db.Exec("DROP TABLE clients")
-}
-
 func TestTransactionDBTestSuite(t *testing.T) {
 	suite.Run(t, new(TransactionDBTestSuite))
 }
 
 func (s *TransactionDBTestSuite) TestSaveTransaction() {
-	transaction, err := entity.NewTransaction(s.accountFrom, s.accountTo, 100)
+	transaction, err := entity.NewTransaction(s.accountFrom, s.accountTo, entity.NewMoney(100_00, entity.DefaultCurrency))
 	s.Nil(err)
 	err = s.transactionDB.Save(transaction)
 	s.Nil(err)
 }
+
+func (s *TransactionDBTestSuite) TestFindByID() {
+	transaction, err := entity.NewTransaction(s.accountFrom, s.accountTo, entity.NewMoney(100_00, entity.DefaultCurrency))
+	s.Nil(err)
+	s.Nil(s.transactionDB.Save(transaction))
+
+	retrievedTransaction, err := s.transactionDB.FindByID(transaction.ID)
+	s.Nil(err)
+	s.Equal(transaction.ID, retrievedTransaction.ID)
+	s.Equal(s.accountFrom.ID, retrievedTransaction.AccountFrom.ID)
+	s.Equal(s.accountTo.ID, retrievedTransaction.AccountTo.ID)
+	s.Equal(entity.NewMoney(100_00, entity.DefaultCurrency), retrievedTransaction.Amount)
+	s.Empty(retrievedTransaction.ReversalOf)
+	s.Equal(entity.TransactionTransfer, retrievedTransaction.Kind)
+}
+
+func (s *TransactionDBTestSuite) TestFindByIDNotFound() {
+	transaction, err := s.transactionDB.FindByID("unknown")
+	s.Nil(transaction)
+	s.ErrorIs(err, gateway.ErrNotFound)
+}
+
+func (s *TransactionDBTestSuite) TestFindReversals() {
+	transaction, err := entity.NewTransaction(s.accountFrom, s.accountTo, entity.NewMoney(100_00, entity.DefaultCurrency))
+	s.Nil(err)
+	s.Nil(s.transactionDB.Save(transaction))
+
+	reversal, err := entity.NewReversal(transaction, s.accountTo, s.accountFrom, entity.NewMoney(30_00, entity.DefaultCurrency), nil)
+	s.Nil(err)
+	s.Nil(s.transactionDB.Save(reversal))
+
+	reversals, err := s.transactionDB.FindReversals(transaction.ID)
+	s.Nil(err)
+	s.Len(reversals, 1)
+	s.Equal(reversal.ID, reversals[0].ID)
+	s.Equal(transaction.ID, reversals[0].ReversalOf)
+	s.Equal(entity.NewMoney(30_00, entity.DefaultCurrency), reversals[0].Amount)
+
+	reversals, err = s.transactionDB.FindReversals(reversal.ID)
+	s.Nil(err)
+	s.Empty(reversals)
+}
+
+func (s *TransactionDBTestSuite) TestFindByAccountID() {
+	other := entity.NewAccount(s.client)
+	first, err := entity.NewTransaction(s.accountFrom, s.accountTo, entity.NewMoney(10_00, entity.DefaultCurrency))
+	s.Nil(err)
+	second, err := entity.NewTransaction(s.accountTo, s.accountFrom, entity.NewMoney(5_00, entity.DefaultCurrency))
+	s.Nil(err)
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+	unrelated, err := entity.NewTransaction(s.accountTo, other, entity.NewMoney(1_00, entity.DefaultCurrency))
+	s.Nil(err)
+	s.Nil(s.transactionDB.Save(second))
+	s.Nil(s.transactionDB.Save(first))
+	s.Nil(s.transactionDB.Save(unrelated))
+
+	transactions, err := s.transactionDB.FindByAccountID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Len(transactions, 2)
+	s.Equal(first.ID, transactions[0].ID)
+	s.Equal(second.ID, transactions[1].ID)
+
+	transactions, err = s.transactionDB.FindByAccountID("unknown")
+	s.Nil(err)
+	s.Empty(transactions)
+}
+
+func (s *TransactionDBTestSuite) TestOutgoingVolume() {
+	s.db.Exec("INSERT INTO accounts (id, client_id) VALUES (?, ?)", s.accountFrom.ID, s.client.ID)
+	s.db.Exec("INSERT INTO accounts (id, client_id) VALUES (?, ?)", s.accountTo.ID, s.client2.ID)
+	otherAccount := entity.NewAccount(s.client)
+	otherAccount.Balance = entity.NewMoney(100_00, entity.DefaultCurrency)
+	s.db.Exec("INSERT INTO accounts (id, client_id) VALUES (?, ?)", otherAccount.ID, s.client.ID)
+
+	old, _ := entity.NewTransaction(s.accountFrom, s.accountTo, entity.NewMoney(500_00, entity.DefaultCurrency))
+	old.CreatedAt = time.Now().Add(-48 * time.Hour)
+	s.Nil(s.transactionDB.Save(old))
+
+	first, _ := entity.NewTransaction(s.accountFrom, s.accountTo, entity.NewMoney(10_10, entity.DefaultCurrency))
+	s.Nil(s.transactionDB.Save(first))
+	second, _ := entity.NewTransaction(s.accountFrom, s.accountTo, entity.NewMoney(20_20, entity.DefaultCurrency))
+	s.Nil(s.transactionDB.Save(second))
+	fromOther, _ := entity.NewTransaction(otherAccount, s.accountTo, entity.NewMoney(5_00, entity.DefaultCurrency))
+	s.Nil(s.transactionDB.Save(fromOther))
+	reversal, _ := entity.NewReversal(first, s.accountTo, s.accountFrom, entity.NewMoney(1_00, entity.DefaultCurrency), nil)
+	s.Nil(s.transactionDB.Save(reversal))
+
+	since := time.Now().Add(-24 * time.Hour)
+
+	volume, err := s.transactionDB.OutgoingVolumeByAccount(s.accountFrom.ID, entity.DefaultCurrency, since)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(30_30, entity.DefaultCurrency), volume.Total)
+	s.Equal(2, volume.Count)
+
+	volume, err = s.transactionDB.OutgoingVolumeByClient(s.client.ID, entity.DefaultCurrency, since)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(35_30, entity.DefaultCurrency), volume.Total)
+	s.Equal(3, volume.Count)
+
+	volume, err = s.transactionDB.OutgoingVolumeByAccount(s.accountTo.ID, entity.DefaultCurrency, since)
+	s.Nil(err)
+	s.True(volume.Total.IsZero())
+	s.Equal(0, volume.Count)
+}
@@ -1,17 +1,33 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type AccountStatus string
+
+const (
+	AccountActive AccountStatus = "active"
+	AccountFrozen AccountStatus = "frozen"
+	AccountClosed AccountStatus = "closed"
+)
+
+// Account balances: Balance is the ledger balance, moved only by
+// transactions; HeldAmount is the part of it reserved by active holds and not
+// available to be spent.
 type Account struct {
-	ID        string
-	Client    *Client
-	Balance   float64
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID             string
+	Client         *Client
+	Balance        Money
+	HeldAmount     Money
+	OverdraftLimit Money
+	Status         AccountStatus
+	Version        int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 func NewAccount(client *Client) *Account {
@@ -19,27 +35,164 @@ func NewAccount(client *Client) *Account {
 		return nil
 	}
 	account := &Account{
-		ID:        uuid.New().String(),
-		Client:    client,
-		Balance:   0,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:             uuid.New().String(),
+		Client:         client,
+		Balance:        Zero(DefaultCurrency),
+		HeldAmount:     Zero(DefaultCurrency),
+		OverdraftLimit: Zero(DefaultCurrency),
+		Status:         AccountActive,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 	return account
 }
 
-func (a *Account) Credit(amount float64) {
-	if amount <= 0 {
-		return
+func (a *Account) Credit(amount Money) error {
+	if !amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	balance, err := a.Balance.Add(amount)
+	if err != nil {
+		return err
+	}
+	a.Balance = balance
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+func (a *Account) Debit(amount Money) error {
+	if !amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	available, err := a.AvailableBalance()
+	if err != nil {
+		return err
+	}
+	cmp, err := amount.Cmp(available)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return fmt.Errorf("%w in account %s", ErrInsufficientFunds, a.ID)
+	}
+	return a.subtract(amount)
+}
+
+// subtract lowers the balance without checking the available funds.
+func (a *Account) subtract(amount Money) error {
+	balance, err := a.Balance.Sub(amount)
+	if err != nil {
+		return err
+	}
+	a.Balance = balance
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// AvailableBalance is how much can be debited from the account: its balance
+// plus the overdraft limit, minus the amount held.
+func (a *Account) AvailableBalance() (Money, error) {
+	available, err := a.Balance.Add(a.OverdraftLimit)
+	if err != nil {
+		return Money{}, err
+	}
+	return available.Sub(a.HeldAmount)
+}
+
+// Hold reserves amount out of the available balance.
+func (a *Account) Hold(amount Money) error {
+	if !amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	available, err := a.AvailableBalance()
+	if err != nil {
+		return err
+	}
+	cmp, err := amount.Cmp(available)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return fmt.Errorf("%w in account %s", ErrInsufficientFunds, a.ID)
+	}
+	held, err := a.HeldAmount.Add(amount)
+	if err != nil {
+		return err
+	}
+	a.HeldAmount = held
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// Release makes a previously held amount available again.
+func (a *Account) Release(amount Money) error {
+	if !amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	held, err := a.HeldAmount.Sub(amount)
+	if err != nil {
+		return err
+	}
+	if held.IsNegative() {
+		return fmt.Errorf("%w: cannot release %s, only %s is held", ErrInvalidAmount, amount, a.HeldAmount)
+	}
+	a.HeldAmount = held
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetOverdraftLimit lets the balance go down to -limit. The new limit must
+// still cover an overdraft already in use, including held amounts.
+func (a *Account) SetOverdraftLimit(limit Money) error {
+	if limit.IsNegative() {
+		return NewValidationError("overdraft_limit", "cannot be negative")
+	}
+	available, err := a.Balance.Add(limit)
+	if err != nil {
+		return err
 	}
-	a.Balance += amount
+	available, err = available.Sub(a.HeldAmount)
+	if err != nil {
+		return err
+	}
+	if available.IsNegative() {
+		return fmt.Errorf("%w: balance %s exceeds overdraft limit %s", ErrOverdraftLimitTooLow, a.Balance, limit)
+	}
+	a.OverdraftLimit = limit
 	a.UpdatedAt = time.Now()
+	return nil
+}
+
+func (a *Account) IsActive() bool {
+	return a.Status == AccountActive
+}
+
+// Freeze blocks an active account from sending or receiving funds.
+func (a *Account) Freeze() error {
+	return a.transition(AccountActive, AccountFrozen)
+}
+
+func (a *Account) Unfreeze() error {
+	return a.transition(AccountFrozen, AccountActive)
+}
+
+// Close permanently closes an active account. The balance must be moved out
+// and holds released beforehand.
+func (a *Account) Close() error {
+	if !a.Balance.IsZero() {
+		return fmt.Errorf("%w: %s", ErrAccountHasBalance, a.Balance)
+	}
+	if !a.HeldAmount.IsZero() {
+		return fmt.Errorf("%w: %s is held", ErrAccountHasBalance, a.HeldAmount)
+	}
+	return a.transition(AccountActive, AccountClosed)
 }
 
-func (a *Account) Debit(amount float64) {
-	if amount <= 0 || amount > a.Balance {
-		return
+func (a *Account) transition(from, to AccountStatus) error {
+	if a.Status != from {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, a.Status, to)
 	}
-	a.Balance -= amount
+	a.Status = to
 	a.UpdatedAt = time.Now()
+	return nil
 }
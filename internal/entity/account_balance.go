@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// AccountBalance is the read model of an account's balance, built from the
+// events the wallet publishes instead of being read from the account.
+// Version is the account version of the last balance applied and
+// LastTransactionAt the time of the last transaction seen; they decide
+// whether an incoming event is newer than what is stored.
+type AccountBalance struct {
+	AccountID         string
+	Balance           Money
+	HeldAmount        Money
+	Version           int
+	LastTransactionID string
+	LastTransactionAt time.Time
+	UpdatedAt         time.Time
+}
+
+// UnprojectedVersion is the version of a balance created by a transaction
+// before any balance of the account was applied; its amounts are
+// placeholders. Accounts start at version 0, so any applied balance replaces
+// it.
+const UnprojectedVersion = -1
+
+// Projected reports whether the amounts come from an applied balance.
+func (b *AccountBalance) Projected() bool {
+	return b.Version != UnprojectedVersion
+}
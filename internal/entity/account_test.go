@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -24,8 +25,12 @@ func TestNewAccount(t *testing.T) {
 			t.Error("expected client to be set")
 		}
 
-		if account.Balance != 0 {
-			t.Errorf("expected initial balance to be 0, got %f", account.Balance)
+		if !account.Balance.IsZero() {
+			t.Errorf("expected initial balance to be 0, got %s", account.Balance)
+		}
+
+		if account.Balance.Currency() != DefaultCurrency {
+			t.Errorf("expected currency to be %s, got %s", DefaultCurrency, account.Balance.Currency())
 		}
 
 		if account.CreatedAt.IsZero() {
@@ -54,10 +59,14 @@ func TestAccount_Credit(t *testing.T) {
 		previousUpdatedAt := account.UpdatedAt
 		time.Sleep(1 * time.Millisecond) // ensure time difference
 
-		account.Credit(100)
+		err := account.Credit(NewMoney(100_00, DefaultCurrency))
+
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
 
-		if account.Balance != 100 {
-			t.Errorf("expected balance to be 100, got %f", account.Balance)
+		if account.Balance != NewMoney(100_00, DefaultCurrency) {
+			t.Errorf("expected balance to be 100.00 BRL, got %s", account.Balance)
 		}
 
 		if !account.UpdatedAt.After(previousUpdatedAt) {
@@ -70,10 +79,14 @@ func TestAccount_Credit(t *testing.T) {
 		previousUpdatedAt := account.UpdatedAt
 		time.Sleep(1 * time.Millisecond)
 
-		account.Credit(0)
+		err := account.Credit(NewMoney(0, DefaultCurrency))
+
+		if !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("expected ErrInvalidAmount, got %v", err)
+		}
 
 		if account.Balance != previousBalance {
-			t.Errorf("expected balance to remain %f, got %f", previousBalance, account.Balance)
+			t.Errorf("expected balance to remain %s, got %s", previousBalance, account.Balance)
 		}
 
 		if account.UpdatedAt != previousUpdatedAt {
@@ -86,31 +99,53 @@ func TestAccount_Credit(t *testing.T) {
 		previousUpdatedAt := account.UpdatedAt
 		time.Sleep(1 * time.Millisecond)
 
-		account.Credit(-50)
+		err := account.Credit(NewMoney(-50_00, DefaultCurrency))
+
+		if !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("expected ErrInvalidAmount, got %v", err)
+		}
 
 		if account.Balance != previousBalance {
-			t.Errorf("expected balance to remain %f, got %f", previousBalance, account.Balance)
+			t.Errorf("expected balance to remain %s, got %s", previousBalance, account.Balance)
 		}
 
 		if account.UpdatedAt != previousUpdatedAt {
 			t.Error("expected UpdatedAt to remain unchanged")
 		}
 	})
+
+	t.Run("should not credit amount in another currency", func(t *testing.T) {
+		previousBalance := account.Balance
+
+		err := account.Credit(NewMoney(10_00, "USD"))
+
+		if !errors.Is(err, ErrCurrencyMismatch) {
+			t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+		}
+
+		if account.Balance != previousBalance {
+			t.Errorf("expected balance to remain %s, got %s", previousBalance, account.Balance)
+		}
+	})
 }
 
 func TestAccount_Debit(t *testing.T) {
 	client := &Client{ID: "1", Name: "Test", Email: "test@example.com"}
 	account := NewAccount(client)
-	account.Credit(100) // Set initial balance
+	account.Credit(NewMoney(100_00, DefaultCurrency)) // Set initial balance
 
 	t.Run("should debit valid amount", func(t *testing.T) {
 		previousUpdatedAt := account.UpdatedAt
 		time.Sleep(1 * time.Millisecond)
 
-		account.Debit(50)
+		err := account.Debit(NewMoney(50_00, DefaultCurrency))
 
-		if account.Balance != 50 {
-			t.Errorf("expected balance to be 50, got %f", account.Balance)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		if account.Balance != NewMoney(50_00, DefaultCurrency) {
+			t.Errorf("expected balance to be 50.00 BRL, got %s", account.Balance)
 		}
 
 		if !account.UpdatedAt.After(previousUpdatedAt) {
@@ -123,10 +158,14 @@ func TestAccount_Debit(t *testing.T) {
 		previousUpdatedAt := account.UpdatedAt
 		time.Sleep(1 * time.Millisecond)
 
-		account.Debit(100)
+		err := account.Debit(NewMoney(100_00, DefaultCurrency))
+
+		if !errors.Is(err, ErrInsufficientFunds) {
+			t.Errorf("expected ErrInsufficientFunds, got %v", err)
+		}
 
 		if account.Balance != previousBalance {
-			t.Errorf("expected balance to remain %f, got %f", previousBalance, account.Balance)
+			t.Errorf("expected balance to remain %s, got %s", previousBalance, account.Balance)
 		}
 
 		if account.UpdatedAt != previousUpdatedAt {
@@ -139,10 +178,14 @@ func TestAccount_Debit(t *testing.T) {
 		previousUpdatedAt := account.UpdatedAt
 		time.Sleep(1 * time.Millisecond)
 
-		account.Debit(0)
+		err := account.Debit(NewMoney(0, DefaultCurrency))
+
+		if !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("expected ErrInvalidAmount, got %v", err)
+		}
 
 		if account.Balance != previousBalance {
-			t.Errorf("expected balance to remain %f, got %f", previousBalance, account.Balance)
+			t.Errorf("expected balance to remain %s, got %s", previousBalance, account.Balance)
 		}
 
 		if account.UpdatedAt != previousUpdatedAt {
@@ -155,10 +198,14 @@ func TestAccount_Debit(t *testing.T) {
 		previousUpdatedAt := account.UpdatedAt
 		time.Sleep(1 * time.Millisecond)
 
-		account.Debit(-10)
+		err := account.Debit(NewMoney(-10_00, DefaultCurrency))
+
+		if !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("expected ErrInvalidAmount, got %v", err)
+		}
 
 		if account.Balance != previousBalance {
-			t.Errorf("expected balance to remain %f, got %f", previousBalance, account.Balance)
+			t.Errorf("expected balance to remain %s, got %s", previousBalance, account.Balance)
 		}
 
 		if account.UpdatedAt != previousUpdatedAt {
@@ -166,3 +213,155 @@ func TestAccount_Debit(t *testing.T) {
 		}
 	})
 }
+
+func TestAccount_StatusTransitions(t *testing.T) {
+	client := &Client{ID: "1", Name: "Test", Email: "test@example.com"}
+
+	t.Run("should start active", func(t *testing.T) {
+		account := NewAccount(client)
+
+		if account.Status != AccountActive {
+			t.Errorf("expected status to be active, got %s", account.Status)
+		}
+	})
+
+	t.Run("should freeze and unfreeze an account", func(t *testing.T) {
+		account := NewAccount(client)
+
+		if err := account.Freeze(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if account.Status != AccountFrozen {
+			t.Errorf("expected status to be frozen, got %s", account.Status)
+		}
+
+		if err := account.Freeze(); !errors.Is(err, ErrInvalidStatusTransition) {
+			t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+		}
+
+		if err := account.Unfreeze(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if account.Status != AccountActive {
+			t.Errorf("expected status to be active, got %s", account.Status)
+		}
+	})
+
+	t.Run("should not unfreeze an active account", func(t *testing.T) {
+		account := NewAccount(client)
+
+		if err := account.Unfreeze(); !errors.Is(err, ErrInvalidStatusTransition) {
+			t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+		}
+	})
+
+	t.Run("should close an active account with zero balance", func(t *testing.T) {
+		account := NewAccount(client)
+
+		if err := account.Close(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if account.Status != AccountClosed {
+			t.Errorf("expected status to be closed, got %s", account.Status)
+		}
+
+		if err := account.Freeze(); !errors.Is(err, ErrInvalidStatusTransition) {
+			t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+		}
+	})
+
+	t.Run("should not close an account with balance", func(t *testing.T) {
+		account := NewAccount(client)
+		account.Credit(NewMoney(10_00, DefaultCurrency))
+
+		if err := account.Close(); !errors.Is(err, ErrAccountHasBalance) {
+			t.Errorf("expected ErrAccountHasBalance, got %v", err)
+		}
+		if account.Status != AccountActive {
+			t.Errorf("expected status to remain active, got %s", account.Status)
+		}
+	})
+
+	t.Run("should not close an account with held funds", func(t *testing.T) {
+		account := NewAccount(client)
+		account.SetOverdraftLimit(NewMoney(10_00, DefaultCurrency))
+		account.Hold(NewMoney(10_00, DefaultCurrency))
+
+		if err := account.Close(); !errors.Is(err, ErrAccountHasBalance) {
+			t.Errorf("expected ErrAccountHasBalance, got %v", err)
+		}
+	})
+
+	t.Run("should not close a frozen account", func(t *testing.T) {
+		account := NewAccount(client)
+		account.Freeze()
+
+		if err := account.Close(); !errors.Is(err, ErrInvalidStatusTransition) {
+			t.Errorf("expected ErrInvalidStatusTransition, got %v", err)
+		}
+	})
+}
+
+func TestAccount_Overdraft(t *testing.T) {
+	client := &Client{ID: "1", Name: "Test", Email: "test@example.com"}
+
+	t.Run("should debit down to the overdraft limit", func(t *testing.T) {
+		account := NewAccount(client)
+		account.Credit(NewMoney(10_00, DefaultCurrency))
+
+		if err := account.SetOverdraftLimit(NewMoney(50_00, DefaultCurrency)); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := account.Debit(NewMoney(60_00, DefaultCurrency)); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if account.Balance != NewMoney(-50_00, DefaultCurrency) {
+			t.Errorf("expected balance to be -50.00 BRL, got %s", account.Balance)
+		}
+
+		if err := account.Debit(NewMoney(1, DefaultCurrency)); !errors.Is(err, ErrInsufficientFunds) {
+			t.Errorf("expected ErrInsufficientFunds, got %v", err)
+		}
+	})
+
+	t.Run("should report available balance", func(t *testing.T) {
+		account := NewAccount(client)
+		account.Credit(NewMoney(10_00, DefaultCurrency))
+		account.SetOverdraftLimit(NewMoney(5_00, DefaultCurrency))
+
+		available, err := account.AvailableBalance()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if available != NewMoney(15_00, DefaultCurrency) {
+			t.Errorf("expected available balance to be 15.00 BRL, got %s", available)
+		}
+	})
+
+	t.Run("should not set a negative limit", func(t *testing.T) {
+		account := NewAccount(client)
+
+		var validationErr *ValidationError
+		if err := account.SetOverdraftLimit(NewMoney(-1_00, DefaultCurrency)); !errors.As(err, &validationErr) {
+			t.Errorf("expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("should not lower the limit below the overdraft in use", func(t *testing.T) {
+		account := NewAccount(client)
+		account.SetOverdraftLimit(NewMoney(50_00, DefaultCurrency))
+		account.Debit(NewMoney(30_00, DefaultCurrency))
+
+		if err := account.SetOverdraftLimit(NewMoney(20_00, DefaultCurrency)); !errors.Is(err, ErrOverdraftLimitTooLow) {
+			t.Errorf("expected ErrOverdraftLimitTooLow, got %v", err)
+		}
+		if account.OverdraftLimit != NewMoney(50_00, DefaultCurrency) {
+			t.Errorf("expected limit to remain 50.00 BRL, got %s", account.OverdraftLimit)
+		}
+
+		if err := account.SetOverdraftLimit(NewMoney(30_00, DefaultCurrency)); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
@@ -1,16 +1,22 @@
 package entity
 
 import (
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ClientTier groups clients that share commercial conditions such as
+// transfer fees.
+type ClientTier string
+
+const ClientTierStandard ClientTier = "standard"
+
 type Client struct {
 	ID        string
 	Name      string
 	Email     string
+	Tier      ClientTier
 	Accounts  []*Account
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -21,6 +27,7 @@ func NewClient(name, email string) (*Client, error) {
 		ID:        uuid.New().String(),
 		Name:      name,
 		Email:     email,
+		Tier:      ClientTierStandard,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -32,10 +39,10 @@ func NewClient(name, email string) (*Client, error) {
 
 func (c *Client) Validate() error {
 	if c.Name == "" {
-		return errors.New("name is required")
+		return NewValidationError("name", "is required")
 	}
 	if c.Email == "" {
-		return errors.New("email is required")
+		return NewValidationError("email", "is required")
 	}
 	return nil
 }
@@ -50,12 +57,21 @@ func (c *Client) Update(name, email string) error {
 	return nil
 }
 
-func (c *Client) AddAccount(account *Account) error {
-	if account.Client.ID != c.ID {
-		return errors.New("account does not belong to this client")
+func (c *Client) SetTier(tier ClientTier) error {
+	if tier == "" {
+		return NewValidationError("tier", "is required")
 	}
+	c.Tier = tier
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+func (c *Client) AddAccount(account *Account) error {
 	if account == nil {
-		return errors.New("account cannot be nil")
+		return NewValidationError("account", "cannot be nil")
+	}
+	if account.Client == nil || account.Client.ID != c.ID {
+		return NewValidationError("account", "does not belong to this client")
 	}
 	c.Accounts = append(c.Accounts, account)
 	return nil
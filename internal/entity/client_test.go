@@ -20,6 +20,14 @@ func TestCreateNewClientWhenArgsAreInvalid(t *testing.T) {
 	client, err := NewClient("", "")
 	assert.Error(t, err)
 	assert.Nil(t, client)
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "name", validationErr.Field)
+
+	_, err = NewClient("John Doe", "")
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "email", validationErr.Field)
 }
 
 func TestUpdateClient(t *testing.T) {
@@ -43,3 +51,18 @@ func TestAddAccount(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, client.Accounts, account)
 }
+
+func TestAddAccountWhenAccountIsInvalid(t *testing.T) {
+	client, _ := NewClient("Alice", "alice@example.com")
+	other, _ := NewClient("Bob", "bob@example.com")
+
+	var validationErr *ValidationError
+	err := client.AddAccount(nil)
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "account", validationErr.Field)
+
+	err = client.AddAccount(NewAccount(other))
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "account does not belong to this client", err.Error())
+	assert.Empty(t, client.Accounts)
+}
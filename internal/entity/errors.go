@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrInvalidAmount     = errors.New("amount must be greater than zero")
+	ErrAccountNotFound   = errors.New("account not found")
+	ErrClientNotFound    = errors.New("client not found")
+
+	ErrAccountNotActive        = errors.New("account is not active")
+	ErrAccountHasBalance       = errors.New("account balance must be zero")
+	ErrInvalidStatusTransition = errors.New("invalid account status transition")
+	ErrOverdraftLimitTooLow    = errors.New("overdraft limit is lower than the overdraft in use")
+
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+
+	ErrTransactionNotFound     = errors.New("transaction not found")
+	ErrInvalidReversal         = errors.New("transaction cannot be reversed")
+	ErrReversalExceedsOriginal = errors.New("reversal exceeds the original transaction amount")
+
+	ErrHoldNotFound       = errors.New("hold not found")
+	ErrHoldNotActive      = errors.New("hold is not active")
+	ErrHoldExpired        = errors.New("hold has expired")
+	ErrCaptureExceedsHold = errors.New("capture exceeds the held amount")
+
+	ErrLimitExceeded = errors.New("transfer limit exceeded")
+)
+
+// ValidationError reports an invalid value for a single field of an entity.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func NewValidationError(field, message string) *ValidationError {
+	return &ValidationError{
+		Field:   field,
+		Message: message,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// LimitExceededError reports the limit rule a transfer would breach.
+type LimitExceededError struct {
+	Rule    string
+	Message string
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("transfer limit %s exceeded: %s", e.Rule, e.Message)
+}
+
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "active"
+	HoldCaptured HoldStatus = "captured"
+	HoldVoided   HoldStatus = "voided"
+	HoldExpired  HoldStatus = "expired"
+)
+
+// Hold reserves part of an account's available balance until it is captured
+// into a transaction, voided or expires. While active, Amount is counted in
+// the account's HeldAmount.
+type Hold struct {
+	ID             string
+	AccountID      string
+	Amount         Money
+	CapturedAmount Money
+	Status         HoldStatus
+	TransactionID  string
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewHold places a hold of amount on account until expiresAt.
+func NewHold(account *Account, amount Money, expiresAt time.Time) (*Hold, error) {
+	if account == nil {
+		return nil, NewValidationError("account", "cannot be nil")
+	}
+	if !expiresAt.After(time.Now()) {
+		return nil, NewValidationError("expires_at", "must be in the future")
+	}
+	if !account.IsActive() {
+		return nil, fmt.Errorf("%w: account %s is %s", ErrAccountNotActive, account.ID, account.Status)
+	}
+	if err := account.Hold(amount); err != nil {
+		return nil, err
+	}
+	hold := &Hold{
+		ID:             uuid.New().String(),
+		AccountID:      account.ID,
+		Amount:         amount,
+		CapturedAmount: Zero(amount.Currency()),
+		Status:         HoldActive,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	return hold, nil
+}
+
+func (h *Hold) IsExpired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
+
+// Capture settles amount of the hold as a transaction from account to
+// accountTo. Whatever is not captured is released, so a hold is captured at
+// most once.
+func (h *Hold) Capture(account, accountTo *Account, amount Money, now time.Time) (*Transaction, error) {
+	if err := h.checkActive(account); err != nil {
+		return nil, err
+	}
+	if h.IsExpired(now) {
+		return nil, fmt.Errorf("%w: hold %s expired at %s", ErrHoldExpired, h.ID, h.ExpiresAt)
+	}
+	if !amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	cmp, err := amount.Cmp(h.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if cmp > 0 {
+		return nil, fmt.Errorf("%w: %s of %s", ErrCaptureExceedsHold, amount, h.Amount)
+	}
+
+	if err := account.Release(h.Amount); err != nil {
+		return nil, err
+	}
+	transaction, err := NewTransaction(account, accountTo, amount)
+	if err != nil {
+		account.HeldAmount, _ = account.HeldAmount.Add(h.Amount)
+		return nil, err
+	}
+
+	h.Status = HoldCaptured
+	h.CapturedAmount = amount
+	h.TransactionID = transaction.ID
+	h.UpdatedAt = now
+	return transaction, nil
+}
+
+// Void releases the whole hold without moving any money.
+func (h *Hold) Void(account *Account, now time.Time) error {
+	return h.release(account, HoldVoided, now)
+}
+
+// Expire releases a hold whose expiry has passed.
+func (h *Hold) Expire(account *Account, now time.Time) error {
+	if !h.IsExpired(now) {
+		return NewValidationError("expires_at", "has not passed yet")
+	}
+	return h.release(account, HoldExpired, now)
+}
+
+func (h *Hold) release(account *Account, status HoldStatus, now time.Time) error {
+	if err := h.checkActive(account); err != nil {
+		return err
+	}
+	if err := account.Release(h.Amount); err != nil {
+		return err
+	}
+	h.Status = status
+	h.UpdatedAt = now
+	return nil
+}
+
+func (h *Hold) checkActive(account *Account) error {
+	if h.Status != HoldActive {
+		return fmt.Errorf("%w: hold %s is %s", ErrHoldNotActive, h.ID, h.Status)
+	}
+	if account == nil || account.ID != h.AccountID {
+		return NewValidationError("account", "must be the account the hold was placed on")
+	}
+	return nil
+}
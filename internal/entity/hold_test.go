@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHold(t *testing.T) {
+	client, _ := NewClient("John", "j@j.com")
+
+	t.Run("should reserve the amount", func(t *testing.T) {
+		account := NewAccount(client)
+		account.Credit(NewMoney(100_00, DefaultCurrency))
+
+		hold, err := NewHold(account, NewMoney(30_00, DefaultCurrency), time.Now().Add(time.Hour))
+		assert.Nil(t, err)
+		assert.NotEmpty(t, hold.ID)
+		assert.Equal(t, account.ID, hold.AccountID)
+		assert.Equal(t, HoldActive, hold.Status)
+		assert.Equal(t, NewMoney(100_00, DefaultCurrency), account.Balance)
+		assert.Equal(t, NewMoney(30_00, DefaultCurrency), account.HeldAmount)
+
+		available, _ := account.AvailableBalance()
+		assert.Equal(t, NewMoney(70_00, DefaultCurrency), available)
+	})
+
+	t.Run("should not hold more than the available balance", func(t *testing.T) {
+		account := NewAccount(client)
+		account.Credit(NewMoney(100_00, DefaultCurrency))
+		NewHold(account, NewMoney(80_00, DefaultCurrency), time.Now().Add(time.Hour))
+
+		hold, err := NewHold(account, NewMoney(30_00, DefaultCurrency), time.Now().Add(time.Hour))
+		assert.Nil(t, hold)
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+		assert.Equal(t, NewMoney(80_00, DefaultCurrency), account.HeldAmount)
+	})
+
+	t.Run("should not hold on an inactive account", func(t *testing.T) {
+		account := NewAccount(client)
+		account.Credit(NewMoney(100_00, DefaultCurrency))
+		account.Freeze()
+
+		_, err := NewHold(account, NewMoney(30_00, DefaultCurrency), time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, ErrAccountNotActive)
+	})
+
+	t.Run("should require a future expiry", func(t *testing.T) {
+		account := NewAccount(client)
+		account.Credit(NewMoney(100_00, DefaultCurrency))
+
+		_, err := NewHold(account, NewMoney(30_00, DefaultCurrency), time.Now().Add(-time.Minute))
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "expires_at", validationErr.Field)
+	})
+}
+
+func TestHold_Capture(t *testing.T) {
+	client1, _ := NewClient("John", "j@j.com")
+	client2, _ := NewClient("Jane", "jane@j.com")
+
+	newHold := func() (*Hold, *Account, *Account) {
+		account := NewAccount(client1)
+		account.Credit(NewMoney(100_00, DefaultCurrency))
+		merchant := NewAccount(client2)
+		hold, _ := NewHold(account, NewMoney(60_00, DefaultCurrency), time.Now().Add(time.Hour))
+		return hold, account, merchant
+	}
+
+	t.Run("should capture part of the hold and release the rest", func(t *testing.T) {
+		hold, account, merchant := newHold()
+
+		transaction, err := hold.Capture(account, merchant, NewMoney(40_00, DefaultCurrency), time.Now())
+		assert.Nil(t, err)
+		assert.Equal(t, NewMoney(40_00, DefaultCurrency), transaction.Amount)
+		assert.Equal(t, HoldCaptured, hold.Status)
+		assert.Equal(t, transaction.ID, hold.TransactionID)
+		assert.Equal(t, NewMoney(40_00, DefaultCurrency), hold.CapturedAmount)
+		assert.Equal(t, NewMoney(60_00, DefaultCurrency), account.Balance)
+		assert.True(t, account.HeldAmount.IsZero())
+		assert.Equal(t, NewMoney(40_00, DefaultCurrency), merchant.Balance)
+
+		_, err = hold.Capture(account, merchant, NewMoney(10_00, DefaultCurrency), time.Now())
+		assert.ErrorIs(t, err, ErrHoldNotActive)
+	})
+
+	t.Run("should not capture into the holding account", func(t *testing.T) {
+		hold, account, _ := newHold()
+
+		_, err := hold.Capture(account, account, NewMoney(40_00, DefaultCurrency), time.Now())
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "account_to", validationErr.Field)
+		assert.Equal(t, HoldActive, hold.Status)
+		assert.Equal(t, NewMoney(60_00, DefaultCurrency), account.HeldAmount)
+	})
+
+	t.Run("should not capture more than held", func(t *testing.T) {
+		hold, account, merchant := newHold()
+
+		_, err := hold.Capture(account, merchant, NewMoney(60_01, DefaultCurrency), time.Now())
+		assert.ErrorIs(t, err, ErrCaptureExceedsHold)
+		assert.Equal(t, HoldActive, hold.Status)
+		assert.Equal(t, NewMoney(60_00, DefaultCurrency), account.HeldAmount)
+	})
+
+	t.Run("should keep the hold when the transaction fails", func(t *testing.T) {
+		hold, account, merchant := newHold()
+		merchant.Freeze()
+
+		_, err := hold.Capture(account, merchant, NewMoney(60_00, DefaultCurrency), time.Now())
+		assert.ErrorIs(t, err, ErrAccountNotActive)
+		assert.Equal(t, HoldActive, hold.Status)
+		assert.Equal(t, NewMoney(60_00, DefaultCurrency), account.HeldAmount)
+		assert.Equal(t, NewMoney(100_00, DefaultCurrency), account.Balance)
+	})
+
+	t.Run("should not capture an expired hold", func(t *testing.T) {
+		hold, account, merchant := newHold()
+
+		_, err := hold.Capture(account, merchant, NewMoney(60_00, DefaultCurrency), hold.ExpiresAt)
+		assert.ErrorIs(t, err, ErrHoldExpired)
+	})
+}
+
+func TestHold_VoidAndExpire(t *testing.T) {
+	client, _ := NewClient("John", "j@j.com")
+	account := NewAccount(client)
+	account.Credit(NewMoney(100_00, DefaultCurrency))
+
+	t.Run("should void the hold", func(t *testing.T) {
+		hold, _ := NewHold(account, NewMoney(60_00, DefaultCurrency), time.Now().Add(time.Hour))
+
+		assert.Nil(t, hold.Void(account, time.Now()))
+		assert.Equal(t, HoldVoided, hold.Status)
+		assert.True(t, account.HeldAmount.IsZero())
+
+		assert.ErrorIs(t, hold.Void(account, time.Now()), ErrHoldNotActive)
+	})
+
+	t.Run("should expire the hold only after its expiry", func(t *testing.T) {
+		hold, _ := NewHold(account, NewMoney(60_00, DefaultCurrency), time.Now().Add(time.Hour))
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, hold.Expire(account, time.Now()), &validationErr)
+		assert.Equal(t, HoldActive, hold.Status)
+
+		assert.Nil(t, hold.Expire(account, hold.ExpiresAt.Add(time.Second)))
+		assert.Equal(t, HoldExpired, hold.Status)
+		assert.True(t, account.HeldAmount.IsZero())
+	})
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// IdempotencyKey remembers the transaction created for a client-supplied
+// key, together with a hash of the request that created it, so a retried
+// request can be answered without moving money twice.
+type IdempotencyKey struct {
+	Key           string
+	RequestHash   string
+	TransactionID string
+	CreatedAt     time.Time
+}
+
+func NewIdempotencyKey(key, requestHash, transactionID string) (*IdempotencyKey, error) {
+	idempotencyKey := &IdempotencyKey{
+		Key:           key,
+		RequestHash:   requestHash,
+		TransactionID: transactionID,
+		CreatedAt:     time.Now(),
+	}
+	if err := idempotencyKey.Validate(); err != nil {
+		return nil, err
+	}
+	return idempotencyKey, nil
+}
+
+func (k *IdempotencyKey) Validate() error {
+	if k.Key == "" {
+		return NewValidationError("idempotency_key", "is required")
+	}
+	if len(k.Key) > 255 {
+		return NewValidationError("idempotency_key", "must be at most 255 characters")
+	}
+	if k.RequestHash == "" {
+		return NewValidationError("request_hash", "is required")
+	}
+	if k.TransactionID == "" {
+		return NewValidationError("transaction_id", "is required")
+	}
+	return nil
+}
+
+// Matches reports whether a request with the given hash is a replay of the
+// request that created the key.
+func (k *IdempotencyKey) Matches(requestHash string) bool {
+	return k.RequestHash == requestHash
+}
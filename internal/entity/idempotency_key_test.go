@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	key, err := NewIdempotencyKey("key-1", "hash", "transaction-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "key-1", key.Key)
+	assert.Equal(t, "transaction-1", key.TransactionID)
+	assert.NotEmpty(t, key.CreatedAt)
+	assert.True(t, key.Matches("hash"))
+	assert.False(t, key.Matches("other"))
+}
+
+func TestNewIdempotencyKeyWhenInvalid(t *testing.T) {
+	tests := []struct {
+		key           string
+		requestHash   string
+		transactionID string
+		field         string
+	}{
+		{"", "hash", "transaction-1", "idempotency_key"},
+		{strings.Repeat("k", 256), "hash", "transaction-1", "idempotency_key"},
+		{"key-1", "", "transaction-1", "request_hash"},
+		{"key-1", "hash", "", "transaction_id"},
+	}
+	for _, tt := range tests {
+		key, err := NewIdempotencyKey(tt.key, tt.requestHash, tt.transactionID)
+		assert.Nil(t, key)
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, tt.field, validationErr.Field)
+	}
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnbalancedJournal = errors.New("journal entries are not balanced")
+
+type EntryDirection string
+
+const (
+	EntryDebit  EntryDirection = "debit"
+	EntryCredit EntryDirection = "credit"
+)
+
+// LedgerEntry is one side of a double-entry posting. Every transaction
+// produces entries whose debits and credits sum to the same amount.
+type LedgerEntry struct {
+	ID            string
+	TransactionID string
+	AccountID     string
+	Direction     EntryDirection
+	Amount        Money
+	CreatedAt     time.Time
+}
+
+func NewLedgerEntry(transactionID, accountID string, direction EntryDirection, amount Money, createdAt time.Time) *LedgerEntry {
+	return &LedgerEntry{
+		ID:            uuid.New().String(),
+		TransactionID: transactionID,
+		AccountID:     accountID,
+		Direction:     direction,
+		Amount:        amount,
+		CreatedAt:     createdAt,
+	}
+}
+
+// SignedAmount returns the effect of the entry on the account balance:
+// credits increase it and debits decrease it.
+func (e *LedgerEntry) SignedAmount() Money {
+	if e.Direction == EntryDebit {
+		return e.Amount.Neg()
+	}
+	return e.Amount
+}
+
+// ValidateJournal checks that entries are well formed and that their debits
+// and credits balance.
+func ValidateJournal(entries []*LedgerEntry) error {
+	if len(entries) == 0 {
+		return fmt.Errorf("%w: no entries", ErrUnbalancedJournal)
+	}
+	totals := map[string]Money{}
+	for _, entry := range entries {
+		if entry.Direction != EntryDebit && entry.Direction != EntryCredit {
+			return NewValidationError("direction", fmt.Sprintf("%q is invalid", entry.Direction))
+		}
+		if !entry.Amount.IsPositive() {
+			return ErrInvalidAmount
+		}
+		currency := entry.Amount.Currency()
+		total, ok := totals[currency]
+		if !ok {
+			total = Zero(currency)
+		}
+		total, err := total.Add(entry.SignedAmount())
+		if err != nil {
+			return err
+		}
+		totals[currency] = total
+	}
+	for currency, total := range totals {
+		if !total.IsZero() {
+			return fmt.Errorf("%w: %s off by %s", ErrUnbalancedJournal, currency, total.Decimal())
+		}
+	}
+	return nil
+}
+
+// BalanceFromEntries derives an account balance from its ledger entries.
+func BalanceFromEntries(currency string, entries []*LedgerEntry) (Money, error) {
+	balance := Zero(currency)
+	for _, entry := range entries {
+		var err error
+		balance, err = balance.Add(entry.SignedAmount())
+		if err != nil {
+			return Money{}, err
+		}
+	}
+	return balance, nil
+}
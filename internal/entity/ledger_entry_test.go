@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction_LedgerEntries(t *testing.T) {
+	client1, _ := NewClient("John", "j@j.com")
+	account1 := NewAccount(client1)
+	client2, _ := NewClient("Jane", "jane@j.com")
+	account2 := NewAccount(client2)
+	account1.Credit(NewMoney(1000_00, DefaultCurrency))
+
+	transaction, err := NewTransaction(account1, account2, NewMoney(100_00, DefaultCurrency))
+	assert.Nil(t, err)
+
+	entries := transaction.LedgerEntries()
+	assert.Len(t, entries, 2)
+	assert.Nil(t, ValidateJournal(entries))
+
+	assert.Equal(t, account1.ID, entries[0].AccountID)
+	assert.Equal(t, EntryDebit, entries[0].Direction)
+	assert.Equal(t, NewMoney(-100_00, DefaultCurrency), entries[0].SignedAmount())
+	assert.Equal(t, account2.ID, entries[1].AccountID)
+	assert.Equal(t, EntryCredit, entries[1].Direction)
+	assert.Equal(t, NewMoney(100_00, DefaultCurrency), entries[1].SignedAmount())
+	for _, entry := range entries {
+		assert.NotEmpty(t, entry.ID)
+		assert.Equal(t, transaction.ID, entry.TransactionID)
+		assert.Equal(t, transaction.CreatedAt, entry.CreatedAt)
+	}
+}
+
+func TestValidateJournal(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should reject unbalanced entries", func(t *testing.T) {
+		entries := []*LedgerEntry{
+			NewLedgerEntry("t1", "a1", EntryDebit, NewMoney(100_00, DefaultCurrency), now),
+			NewLedgerEntry("t1", "a2", EntryCredit, NewMoney(90_00, DefaultCurrency), now),
+		}
+		assert.ErrorIs(t, ValidateJournal(entries), ErrUnbalancedJournal)
+	})
+
+	t.Run("should reject empty journal", func(t *testing.T) {
+		assert.ErrorIs(t, ValidateJournal(nil), ErrUnbalancedJournal)
+	})
+
+	t.Run("should reject non positive amounts", func(t *testing.T) {
+		entries := []*LedgerEntry{
+			NewLedgerEntry("t1", "a1", EntryDebit, Zero(DefaultCurrency), now),
+			NewLedgerEntry("t1", "a2", EntryCredit, Zero(DefaultCurrency), now),
+		}
+		assert.ErrorIs(t, ValidateJournal(entries), ErrInvalidAmount)
+	})
+
+	t.Run("should reject unknown direction", func(t *testing.T) {
+		entries := []*LedgerEntry{
+			NewLedgerEntry("t1", "a1", "sideways", NewMoney(100_00, DefaultCurrency), now),
+		}
+		var validationErr *ValidationError
+		assert.ErrorAs(t, ValidateJournal(entries), &validationErr)
+		assert.Equal(t, "direction", validationErr.Field)
+	})
+}
+
+func TestBalanceFromEntries(t *testing.T) {
+	now := time.Now()
+	entries := []*LedgerEntry{
+		NewLedgerEntry("t1", "a1", EntryCredit, NewMoney(100_00, DefaultCurrency), now),
+		NewLedgerEntry("t2", "a1", EntryDebit, NewMoney(30_50, DefaultCurrency), now),
+		NewLedgerEntry("t3", "a1", EntryCredit, NewMoney(25, DefaultCurrency), now),
+	}
+
+	balance, err := BalanceFromEntries(DefaultCurrency, entries)
+	assert.Nil(t, err)
+	assert.Equal(t, NewMoney(69_75, DefaultCurrency), balance)
+
+	_, err = BalanceFromEntries("USD", entries)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency used for accounts created without an
+// explicit one.
+const DefaultCurrency = "BRL"
+
+// minorUnitDigits is the number of decimal places kept by Money, so one unit
+// of a currency is stored as 100 minor units (cents).
+const minorUnitDigits = 2
+
+const minorUnitsPerUnit = 100
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrMoneyOverflow    = errors.New("money overflow")
+	ErrInvalidMoney     = errors.New("invalid money value")
+)
+
+// Money is an exact amount of a currency, stored as an integer number of
+// minor units. It is a value type: operations return new values.
+type Money struct {
+	minorUnits int64
+	currency   string
+}
+
+func NewMoney(minorUnits int64, currency string) Money {
+	return Money{
+		minorUnits: minorUnits,
+		currency:   strings.ToUpper(currency),
+	}
+}
+
+func Zero(currency string) Money {
+	return NewMoney(0, currency)
+}
+
+// ParseMoney parses a decimal string such as "10", "10.5" or "-0.07" into
+// Money of the given currency. Digits beyond the minor unit are only
+// accepted when they are zeros, so no value is ever rounded.
+func ParseMoney(value, currency string) (Money, error) {
+	if len(currency) != 3 {
+		return Money{}, fmt.Errorf("%w: currency %q", ErrInvalidMoney, currency)
+	}
+	s := strings.TrimSpace(value)
+	negative := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+	units, fraction, _ := strings.Cut(s, ".")
+	if units == "" && fraction == "" {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, value)
+	}
+	if units == "" {
+		units = "0"
+	}
+	if len(fraction) > minorUnitDigits {
+		if strings.Trim(fraction[minorUnitDigits:], "0") != "" {
+			return Money{}, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidMoney, value, minorUnitDigits)
+		}
+		fraction = fraction[:minorUnitDigits]
+	}
+	fraction += strings.Repeat("0", minorUnitDigits-len(fraction))
+	if !isDigits(units) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, value)
+	}
+	minorUnits, err := strconv.ParseInt(units+fraction, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrMoneyOverflow, value)
+	}
+	if negative {
+		minorUnits = -minorUnits
+	}
+	return NewMoney(minorUnits, currency), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) MinorUnits() int64 {
+	return m.minorUnits
+}
+
+func (m Money) Currency() string {
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.minorUnits == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.minorUnits > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.minorUnits < 0
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return Money{}, err
+	}
+	sum := m.minorUnits + other.minorUnits
+	if (other.minorUnits > 0 && sum < m.minorUnits) || (other.minorUnits < 0 && sum > m.minorUnits) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return NewMoney(sum, m.currency), nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if other.minorUnits == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	return m.Add(other.Neg())
+}
+
+func (m Money) Neg() Money {
+	return NewMoney(-m.minorUnits, m.currency)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or
+// greater than other.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.minorUnits < other.minorUnits:
+		return -1, nil
+	case m.minorUnits > other.minorUnits:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (m Money) checkCurrency(other Money) error {
+	if m.currency != other.currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	return nil
+}
+
+// Decimal formats the amount without the currency, e.g. "-10.05".
+func (m Money) Decimal() string {
+	units := m.minorUnits / minorUnitsPerUnit
+	fraction := m.minorUnits % minorUnitsPerUnit
+	sign := ""
+	if m.minorUnits < 0 {
+		sign = "-"
+		units, fraction = -units, -fraction
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, uint64(units), minorUnitDigits, uint64(fraction))
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.currency
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int64
+	}{
+		{"10", 10_00},
+		{"10.5", 10_50},
+		{"10.50", 10_50},
+		{"0.07", 7},
+		{".07", 7},
+		{"-3.07", -3_07},
+		{"+1.00", 1_00},
+		{"12.3400", 12_34},
+	}
+	for _, tt := range tests {
+		money, err := ParseMoney(tt.value, "brl")
+		assert.NoError(t, err, tt.value)
+		assert.Equal(t, NewMoney(tt.expected, "BRL"), money, tt.value)
+	}
+}
+
+func TestParseMoneyWhenValueIsInvalid(t *testing.T) {
+	for _, value := range []string{"", "-", ".", "abc", "1.2.3", "1.234", "1,00", "99999999999999999999"} {
+		_, err := ParseMoney(value, DefaultCurrency)
+		assert.Error(t, err, value)
+	}
+
+	_, err := ParseMoney("10.00", "REAL")
+	assert.ErrorIs(t, err, ErrInvalidMoney)
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	assert.Equal(t, "0.00", Zero(DefaultCurrency).Decimal())
+	assert.Equal(t, "10.05", NewMoney(10_05, DefaultCurrency).Decimal())
+	assert.Equal(t, "-0.07", NewMoney(-7, DefaultCurrency).Decimal())
+	assert.Equal(t, "-1234.50", NewMoney(-1234_50, DefaultCurrency).Decimal())
+	assert.Equal(t, "10.05 BRL", NewMoney(10_05, DefaultCurrency).String())
+}
+
+func TestMoney_AddAndSub(t *testing.T) {
+	total := Zero(DefaultCurrency)
+	tenCents, _ := ParseMoney("0.10", DefaultCurrency)
+	for i := 0; i < 1000; i++ {
+		var err error
+		total, err = total.Add(tenCents)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, NewMoney(100_00, DefaultCurrency), total)
+
+	for i := 0; i < 1000; i++ {
+		var err error
+		total, err = total.Sub(tenCents)
+		assert.NoError(t, err)
+	}
+	assert.True(t, total.IsZero())
+}
+
+func TestMoney_AddWithDifferentCurrencies(t *testing.T) {
+	_, err := NewMoney(1_00, "BRL").Add(NewMoney(1_00, "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = NewMoney(1_00, "BRL").Sub(NewMoney(1_00, "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestMoney_AddOverflow(t *testing.T) {
+	largest := NewMoney(1<<63-1, DefaultCurrency)
+	_, err := largest.Add(NewMoney(1, DefaultCurrency))
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+
+	smallest := NewMoney(-1<<63, DefaultCurrency)
+	_, err = smallest.Sub(NewMoney(1, DefaultCurrency))
+	assert.ErrorIs(t, err, ErrMoneyOverflow)
+}
+
+func TestMoney_Cmp(t *testing.T) {
+	one := NewMoney(1_00, DefaultCurrency)
+	two := NewMoney(2_00, DefaultCurrency)
+
+	cmp, err := one.Cmp(two)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	cmp, err = two.Cmp(one)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	cmp, err = one.Cmp(one)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	_, err = one.Cmp(NewMoney(1_00, "USD"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
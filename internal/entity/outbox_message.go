@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is an event waiting to be published. It is saved in the same
+// transaction as the change that produced the event, so the event is
+// published if and only if the change is committed. Messages can be
+// published more than once; consumers use ID to discard duplicates.
+type OutboxMessage struct {
+	ID        string
+	EventName string
+	// Key identifies the aggregate the event belongs to. Messages with the
+	// same key are published in the order they were saved.
+	Key       string
+	Payload   []byte
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+	SentAt    time.Time
+}
+
+func NewOutboxMessage(eventName, key string, payload []byte) (*OutboxMessage, error) {
+	message := &OutboxMessage{
+		ID:        uuid.New().String(),
+		EventName: eventName,
+		Key:       key,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}
+	if err := message.Validate(); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (m *OutboxMessage) Validate() error {
+	if m.EventName == "" {
+		return NewValidationError("event_name", "is required")
+	}
+	if len(m.Payload) == 0 {
+		return NewValidationError("payload", "is required")
+	}
+	return nil
+}
+
+func (m *OutboxMessage) IsSent() bool {
+	return !m.SentAt.IsZero()
+}
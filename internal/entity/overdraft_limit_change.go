@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OverdraftLimitChange is the audit record of a change to an account's
+// overdraft limit.
+type OverdraftLimitChange struct {
+	ID            string
+	AccountID     string
+	PreviousLimit Money
+	NewLimit      Money
+	ChangedBy     string
+	Reason        string
+	CreatedAt     time.Time
+}
+
+func NewOverdraftLimitChange(accountID string, previousLimit, newLimit Money, changedBy, reason string) (*OverdraftLimitChange, error) {
+	change := &OverdraftLimitChange{
+		ID:            uuid.New().String(),
+		AccountID:     accountID,
+		PreviousLimit: previousLimit,
+		NewLimit:      newLimit,
+		ChangedBy:     changedBy,
+		Reason:        reason,
+		CreatedAt:     time.Now(),
+	}
+	if err := change.Validate(); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (c *OverdraftLimitChange) Validate() error {
+	if c.AccountID == "" {
+		return NewValidationError("account_id", "is required")
+	}
+	if c.ChangedBy == "" {
+		return NewValidationError("changed_by", "is required")
+	}
+	return nil
+}
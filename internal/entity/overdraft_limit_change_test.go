@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOverdraftLimitChange(t *testing.T) {
+	change, err := NewOverdraftLimitChange("a1", Zero(DefaultCurrency), NewMoney(100_00, DefaultCurrency), "ops@example.com", "business account")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, change.ID)
+	assert.Equal(t, "a1", change.AccountID)
+	assert.Equal(t, NewMoney(100_00, DefaultCurrency), change.NewLimit)
+	assert.False(t, change.CreatedAt.IsZero())
+}
+
+func TestNewOverdraftLimitChangeWhenArgsAreInvalid(t *testing.T) {
+	var validationErr *ValidationError
+
+	_, err := NewOverdraftLimitChange("", Zero(DefaultCurrency), NewMoney(100_00, DefaultCurrency), "ops@example.com", "")
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "account_id", validationErr.Field)
+
+	_, err = NewOverdraftLimitChange("a1", Zero(DefaultCurrency), NewMoney(100_00, DefaultCurrency), "", "")
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "changed_by", validationErr.Field)
+}
@@ -0,0 +1,200 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/cron"
+	"github.com/google/uuid"
+)
+
+type ScheduledTransferStatus string
+
+const (
+	ScheduledTransferActive    ScheduledTransferStatus = "active"
+	ScheduledTransferPaused    ScheduledTransferStatus = "paused"
+	ScheduledTransferCompleted ScheduledTransferStatus = "completed"
+)
+
+// ScheduledTransfer is a transfer executed at NextRunAt, either once or, when
+// Recurrence holds a cron expression, at every activation of it.
+type ScheduledTransfer struct {
+	ID                  string
+	AccountIDFrom       string
+	AccountIDTo         string
+	Amount              Money
+	Recurrence          string
+	NextRunAt           time.Time
+	Status              ScheduledTransferStatus
+	ConsecutiveFailures int
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// NewScheduledTransfer schedules a transfer. A one-off transfer needs runAt;
+// a recurring one starts at runAt if given, or at the next activation of the
+// recurrence otherwise.
+func NewScheduledTransfer(accountIDFrom, accountIDTo string, amount Money, runAt time.Time, recurrence string) (*ScheduledTransfer, error) {
+	now := time.Now()
+	transfer := &ScheduledTransfer{
+		ID:            uuid.New().String(),
+		AccountIDFrom: accountIDFrom,
+		AccountIDTo:   accountIDTo,
+		Amount:        amount,
+		Recurrence:    recurrence,
+		NextRunAt:     runAt,
+		Status:        ScheduledTransferActive,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if recurrence != "" && runAt.IsZero() {
+		schedule, err := cron.Parse(recurrence)
+		if err != nil {
+			return nil, NewValidationError("recurrence", err.Error())
+		}
+		transfer.NextRunAt = schedule.Next(now)
+	}
+	if err := transfer.Validate(); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (s *ScheduledTransfer) Validate() error {
+	if s.AccountIDFrom == "" {
+		return NewValidationError("account_from", "is required")
+	}
+	if s.AccountIDTo == "" {
+		return NewValidationError("account_to", "is required")
+	}
+	if s.AccountIDFrom == s.AccountIDTo {
+		return NewValidationError("account_to", "must differ from account_from")
+	}
+	if !s.Amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	if s.Recurrence != "" {
+		if _, err := cron.Parse(s.Recurrence); err != nil {
+			return NewValidationError("recurrence", err.Error())
+		}
+	}
+	if s.NextRunAt.IsZero() {
+		return NewValidationError("run_at", "is required")
+	}
+	return nil
+}
+
+func (s *ScheduledTransfer) IsDue(now time.Time) bool {
+	return s.Status == ScheduledTransferActive && !now.Before(s.NextRunAt)
+}
+
+// RecordSuccess moves a recurring transfer to its next activation after now,
+// skipping any missed ones, and completes a one-off transfer.
+func (s *ScheduledTransfer) RecordSuccess(now time.Time) error {
+	s.ConsecutiveFailures = 0
+	s.UpdatedAt = now
+	if s.Recurrence == "" {
+		s.Status = ScheduledTransferCompleted
+		return nil
+	}
+	schedule, err := cron.Parse(s.Recurrence)
+	if err != nil {
+		return NewValidationError("recurrence", err.Error())
+	}
+	s.NextRunAt = schedule.Next(now)
+	if s.NextRunAt.IsZero() {
+		s.Status = ScheduledTransferCompleted
+	}
+	return nil
+}
+
+// RecordFailure handles a failed run. Insufficient funds are retried after
+// retryDelay until maxFailures consecutive runs failed; any other error, or
+// reaching maxFailures, pauses the transfer.
+func (s *ScheduledTransfer) RecordFailure(cause error, now time.Time, maxFailures int, retryDelay time.Duration) {
+	s.ConsecutiveFailures++
+	s.UpdatedAt = now
+	if !errors.Is(cause, ErrInsufficientFunds) || s.ConsecutiveFailures >= maxFailures {
+		s.Status = ScheduledTransferPaused
+		return
+	}
+	s.NextRunAt = now.Add(retryDelay)
+}
+
+// Pause stops an active transfer from running.
+func (s *ScheduledTransfer) Pause(now time.Time) error {
+	if s.Status != ScheduledTransferActive {
+		return fmt.Errorf("%w: scheduled transfer %s is %s", ErrInvalidStatusTransition, s.ID, s.Status)
+	}
+	s.Status = ScheduledTransferPaused
+	s.UpdatedAt = now
+	return nil
+}
+
+// Resume reactivates a paused transfer, running it at its next activation
+// after now, or right away for a one-off transfer.
+func (s *ScheduledTransfer) Resume(now time.Time) error {
+	if s.Status != ScheduledTransferPaused {
+		return fmt.Errorf("%w: scheduled transfer %s is %s", ErrInvalidStatusTransition, s.ID, s.Status)
+	}
+	s.Status = ScheduledTransferActive
+	s.ConsecutiveFailures = 0
+	s.UpdatedAt = now
+	if s.Recurrence == "" {
+		if s.NextRunAt.Before(now) {
+			s.NextRunAt = now
+		}
+		return nil
+	}
+	schedule, err := cron.Parse(s.Recurrence)
+	if err != nil {
+		return NewValidationError("recurrence", err.Error())
+	}
+	s.NextRunAt = schedule.Next(now)
+	return nil
+}
+
+// RunKey identifies the current occurrence of the transfer. It is used as the
+// idempotency key of the transaction, so an occurrence moves money at most
+// once even if recording its outcome fails.
+func (s *ScheduledTransfer) RunKey() string {
+	return fmt.Sprintf("scheduled-transfer:%s:%d", s.ID, s.NextRunAt.Unix())
+}
+
+type ScheduledTransferRunStatus string
+
+const (
+	ScheduledTransferRunSucceeded ScheduledTransferRunStatus = "succeeded"
+	ScheduledTransferRunFailed    ScheduledTransferRunStatus = "failed"
+)
+
+// ScheduledTransferRun records the outcome of one execution of a scheduled
+// transfer.
+type ScheduledTransferRun struct {
+	ID                  string
+	ScheduledTransferID string
+	Status              ScheduledTransferRunStatus
+	TransactionID       string
+	Error               string
+	ScheduledFor        time.Time
+	RanAt               time.Time
+}
+
+// NewScheduledTransferRun records a run of transfer; cause is nil when the
+// transaction transactionID was created.
+func NewScheduledTransferRun(transfer *ScheduledTransfer, transactionID string, cause error, ranAt time.Time) *ScheduledTransferRun {
+	run := &ScheduledTransferRun{
+		ID:                  uuid.New().String(),
+		ScheduledTransferID: transfer.ID,
+		Status:              ScheduledTransferRunSucceeded,
+		TransactionID:       transactionID,
+		ScheduledFor:        transfer.NextRunAt,
+		RanAt:               ranAt,
+	}
+	if cause != nil {
+		run.Status = ScheduledTransferRunFailed
+		run.Error = cause.Error()
+	}
+	return run
+}
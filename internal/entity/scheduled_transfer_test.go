@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScheduledTransfer(t *testing.T) {
+	amount := NewMoney(100_00, DefaultCurrency)
+
+	t.Run("should schedule a one-off transfer", func(t *testing.T) {
+		runAt := time.Now().Add(24 * time.Hour)
+
+		transfer, err := NewScheduledTransfer("a1", "a2", amount, runAt, "")
+		assert.Nil(t, err)
+		assert.NotEmpty(t, transfer.ID)
+		assert.Equal(t, runAt, transfer.NextRunAt)
+		assert.Equal(t, ScheduledTransferActive, transfer.Status)
+	})
+
+	t.Run("should start a recurring transfer at its next activation", func(t *testing.T) {
+		transfer, err := NewScheduledTransfer("a1", "a2", amount, time.Time{}, "0 9 5 * *")
+		assert.Nil(t, err)
+		assert.Equal(t, 5, transfer.NextRunAt.Day())
+		assert.Equal(t, 9, transfer.NextRunAt.Hour())
+		assert.True(t, transfer.NextRunAt.After(time.Now()))
+	})
+
+	t.Run("should reject invalid transfers", func(t *testing.T) {
+		tests := []struct {
+			from, to   string
+			runAt      time.Time
+			recurrence string
+			field      string
+		}{
+			{"", "a2", time.Now(), "", "account_from"},
+			{"a1", "", time.Now(), "", "account_to"},
+			{"a1", "a1", time.Now(), "", "account_to"},
+			{"a1", "a2", time.Time{}, "", "run_at"},
+			{"a1", "a2", time.Time{}, "every day", "recurrence"},
+		}
+		for _, tt := range tests {
+			_, err := NewScheduledTransfer(tt.from, tt.to, amount, tt.runAt, tt.recurrence)
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		}
+
+		_, err := NewScheduledTransfer("a1", "a2", NewMoney(0, DefaultCurrency), time.Now(), "")
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+	})
+}
+
+func TestScheduledTransfer_RecordOutcome(t *testing.T) {
+	amount := NewMoney(100_00, DefaultCurrency)
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("should complete a one-off transfer", func(t *testing.T) {
+		transfer, _ := NewScheduledTransfer("a1", "a2", amount, now, "")
+		assert.True(t, transfer.IsDue(now))
+
+		assert.Nil(t, transfer.RecordSuccess(now))
+		assert.Equal(t, ScheduledTransferCompleted, transfer.Status)
+		assert.False(t, transfer.IsDue(now))
+	})
+
+	t.Run("should move a recurring transfer to its next activation", func(t *testing.T) {
+		transfer, _ := NewScheduledTransfer("a1", "a2", amount, now, "0 9 10 * *")
+
+		assert.Nil(t, transfer.RecordSuccess(now.Add(time.Minute)))
+		assert.Equal(t, ScheduledTransferActive, transfer.Status)
+		assert.Equal(t, time.Date(2024, 2, 10, 9, 0, 0, 0, time.UTC), transfer.NextRunAt)
+	})
+
+	t.Run("should retry insufficient funds then pause", func(t *testing.T) {
+		transfer, _ := NewScheduledTransfer("a1", "a2", amount, now, "0 9 10 * *")
+
+		transfer.RecordFailure(ErrInsufficientFunds, now, 3, time.Hour)
+		assert.Equal(t, ScheduledTransferActive, transfer.Status)
+		assert.Equal(t, now.Add(time.Hour), transfer.NextRunAt)
+
+		transfer.RecordFailure(ErrInsufficientFunds, now.Add(time.Hour), 3, time.Hour)
+		assert.Equal(t, ScheduledTransferActive, transfer.Status)
+
+		transfer.RecordFailure(ErrInsufficientFunds, now.Add(2*time.Hour), 3, time.Hour)
+		assert.Equal(t, ScheduledTransferPaused, transfer.Status)
+		assert.Equal(t, 3, transfer.ConsecutiveFailures)
+		assert.False(t, transfer.IsDue(now.Add(24*time.Hour)))
+
+		assert.Nil(t, transfer.Resume(now.Add(24*time.Hour)))
+		assert.Equal(t, ScheduledTransferActive, transfer.Status)
+		assert.Equal(t, 0, transfer.ConsecutiveFailures)
+		assert.Equal(t, time.Date(2024, 2, 10, 9, 0, 0, 0, time.UTC), transfer.NextRunAt)
+	})
+
+	t.Run("should pause on other failures", func(t *testing.T) {
+		transfer, _ := NewScheduledTransfer("a1", "a2", amount, now, "")
+
+		transfer.RecordFailure(errors.New("account is not active"), now, 3, time.Hour)
+		assert.Equal(t, ScheduledTransferPaused, transfer.Status)
+
+		assert.ErrorIs(t, transfer.Pause(now), ErrInvalidStatusTransition)
+	})
+}
+
+func TestNewScheduledTransferRun(t *testing.T) {
+	now := time.Now()
+	transfer, _ := NewScheduledTransfer("a1", "a2", NewMoney(100_00, DefaultCurrency), now, "")
+
+	run := NewScheduledTransferRun(transfer, "t1", nil, now)
+	assert.Equal(t, ScheduledTransferRunSucceeded, run.Status)
+	assert.Equal(t, "t1", run.TransactionID)
+	assert.Equal(t, transfer.NextRunAt, run.ScheduledFor)
+
+	run = NewScheduledTransferRun(transfer, "", ErrInsufficientFunds, now)
+	assert.Equal(t, ScheduledTransferRunFailed, run.Status)
+	assert.Equal(t, "insufficient funds", run.Error)
+}
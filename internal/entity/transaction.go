@@ -1,23 +1,56 @@
 package entity
 
 import (
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// TransactionKind tells transfers between clients apart from money entering
+// or leaving the wallet through the treasury account.
+type TransactionKind string
+
+const (
+	TransactionTransfer   TransactionKind = "transfer"
+	TransactionDeposit    TransactionKind = "deposit"
+	TransactionWithdrawal TransactionKind = "withdrawal"
+)
+
 type Transaction struct {
 	ID          string
+	Kind        TransactionKind
 	AccountFrom *Account
 	AccountTo   *Account
-	Amount      float64
-	CreatedAt   time.Time
+	Amount      Money
+	// ReversalOf is the ID of the transaction this one reverses, if any.
+	ReversalOf string
+	// FeeOf is the ID of the transfer this transaction charges a fee for, if
+	// any.
+	FeeOf     string
+	CreatedAt time.Time
+}
+
+func NewTransaction(accountFrom, accountTo *Account, amount Money) (*Transaction, error) {
+	return newTransaction(TransactionTransfer, accountFrom, accountTo, amount)
+}
+
+// NewDeposit credits amount received from outside the wallet to account. The
+// treasury balance mirrors the money held by clients, so it is debited
+// without checking its funds and goes negative.
+func NewDeposit(treasury, account *Account, amount Money) (*Transaction, error) {
+	return newTransaction(TransactionDeposit, treasury, account, amount)
+}
+
+// NewWithdrawal debits amount paid out of the wallet from account.
+func NewWithdrawal(account, treasury *Account, amount Money) (*Transaction, error) {
+	return newTransaction(TransactionWithdrawal, account, treasury, amount)
 }
 
-func NewTransaction(accountFrom, accountTo *Account, amount float64) (*Transaction, error) {
+func newTransaction(kind TransactionKind, accountFrom, accountTo *Account, amount Money) (*Transaction, error) {
 	transaction := &Transaction{
 		ID:          uuid.New().String(),
+		Kind:        kind,
 		AccountFrom: accountFrom,
 		AccountTo:   accountTo,
 		Amount:      amount,
@@ -28,28 +61,142 @@ func NewTransaction(accountFrom, accountTo *Account, amount float64) (*Transacti
 		return nil, err
 	}
 
-	transaction.Commit()
+	if err := transaction.Commit(); err != nil {
+		return nil, err
+	}
 
 	return transaction, nil
 }
 
 func (t *Transaction) Validate() error {
 	if t.AccountFrom == nil {
-		return errors.New("account from cannot be nil")
+		return NewValidationError("account_from", "cannot be nil")
 	}
 	if t.AccountTo == nil {
-		return errors.New("account to cannot be nil")
+		return NewValidationError("account_to", "cannot be nil")
+	}
+	if t.AccountFrom.ID == t.AccountTo.ID {
+		return NewValidationError("account_to", "must differ from account_from")
+	}
+	if !t.Amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	for _, account := range []*Account{t.AccountFrom, t.AccountTo} {
+		if !account.IsActive() {
+			return fmt.Errorf("%w: account %s is %s", ErrAccountNotActive, account.ID, account.Status)
+		}
+	}
+	if t.AccountFrom.Balance.Currency() != t.Amount.Currency() || t.AccountTo.Balance.Currency() != t.Amount.Currency() {
+		return fmt.Errorf("%w: amount must match both accounts", ErrCurrencyMismatch)
+	}
+	if t.Kind == TransactionDeposit {
+		return nil
+	}
+	available, err := t.AccountFrom.AvailableBalance()
+	if err != nil {
+		return err
+	}
+	if cmp, _ := available.Cmp(t.Amount); cmp < 0 {
+		return fmt.Errorf("%w in account %s", ErrInsufficientFunds, t.AccountFrom.ID)
+	}
+	return nil
+}
+
+// NewReversal moves amount back from the recipient of original to its sender.
+// reversals are the reversals of original already made; together with the
+// new one they cannot exceed the original amount.
+func NewReversal(original *Transaction, accountFrom, accountTo *Account, amount Money, reversals []*Transaction) (*Transaction, error) {
+	if original.ReversalOf != "" {
+		return nil, fmt.Errorf("%w: %s is itself a reversal", ErrInvalidReversal, original.ID)
+	}
+	if accountFrom == nil || accountFrom.ID != original.AccountTo.ID {
+		return nil, NewValidationError("account_from", "must be the recipient of the original transaction")
+	}
+	if accountTo == nil || accountTo.ID != original.AccountFrom.ID {
+		return nil, NewValidationError("account_to", "must be the sender of the original transaction")
 	}
-	if t.Amount <= 0 {
-		return errors.New("amount must be greater than zero")
+	if !amount.IsPositive() {
+		return nil, ErrInvalidAmount
 	}
-	if t.AccountFrom.Balance < t.Amount {
-		return errors.New("insufficient funds in account from")
+
+	reversed := amount
+	for _, reversal := range reversals {
+		var err error
+		reversed, err = reversed.Add(reversal.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cmp, err := reversed.Cmp(original.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if cmp > 0 {
+		return nil, fmt.Errorf("%w: %s of %s would be reversed", ErrReversalExceedsOriginal, reversed, original.Amount)
+	}
+
+	// Reversing a deposit pays the money back out, and vice versa.
+	kind := original.Kind
+	switch original.Kind {
+	case TransactionDeposit:
+		kind = TransactionWithdrawal
+	case TransactionWithdrawal:
+		kind = TransactionDeposit
+	}
+
+	transaction, err := newTransaction(kind, accountFrom, accountTo, amount)
+	if err != nil {
+		return nil, err
+	}
+	transaction.ReversalOf = original.ID
+	return transaction, nil
+}
+
+// NewFee charges amount to the payer of transfer, crediting it to revenue.
+func NewFee(transfer *Transaction, revenue *Account, amount Money) (*Transaction, error) {
+	transaction, err := NewTransaction(transfer.AccountFrom, revenue, amount)
+	if err != nil {
+		return nil, err
+	}
+	transaction.FeeOf = transfer.ID
+	return transaction, nil
+}
+
+// RemainingAmount is how much of the transaction has not been reversed yet.
+func (t *Transaction) RemainingAmount(reversals []*Transaction) (Money, error) {
+	remaining := t.Amount
+	for _, reversal := range reversals {
+		var err error
+		remaining, err = remaining.Sub(reversal.Amount)
+		if err != nil {
+			return Money{}, err
+		}
+	}
+	return remaining, nil
+}
+
+// Commit moves Amount between the accounts. If crediting the destination
+// fails, the source account is credited back so both are left untouched.
+func (t *Transaction) Commit() error {
+	debit := t.AccountFrom.Debit
+	if t.Kind == TransactionDeposit {
+		debit = t.AccountFrom.subtract
+	}
+	if err := debit(t.Amount); err != nil {
+		return err
+	}
+	if err := t.AccountTo.Credit(t.Amount); err != nil {
+		t.AccountFrom.Balance, _ = t.AccountFrom.Balance.Add(t.Amount)
+		return err
 	}
 	return nil
 }
 
-func (t *Transaction) Commit() {
-	t.AccountFrom.Debit(t.Amount)
-	t.AccountTo.Credit(t.Amount)
+// LedgerEntries returns the balanced journal for the transaction: a debit on
+// the source account and a credit on the destination account.
+func (t *Transaction) LedgerEntries() []*LedgerEntry {
+	return []*LedgerEntry{
+		NewLedgerEntry(t.ID, t.AccountFrom.ID, EntryDebit, t.Amount, t.CreatedAt),
+		NewLedgerEntry(t.ID, t.AccountTo.ID, EntryCredit, t.Amount, t.CreatedAt),
+	}
 }
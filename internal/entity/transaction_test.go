@@ -12,56 +12,75 @@ func TestNewTransaction(t *testing.T) {
 	client2, _ := NewClient("Jane", "jane@j.com")
 	account2 := NewAccount(client2)
 
-	account1.Credit(1000)
-	account2.Credit(1000)
+	account1.Credit(NewMoney(1000_00, DefaultCurrency))
+	account2.Credit(NewMoney(1000_00, DefaultCurrency))
 
 	t.Run("should create a transaction", func(t *testing.T) {
-		transaction, err := NewTransaction(account1, account2, 100)
+		transaction, err := NewTransaction(account1, account2, NewMoney(100_00, DefaultCurrency))
 		assert.Nil(t, err)
 		assert.NotNil(t, transaction)
 		assert.Equal(t, account1, transaction.AccountFrom)
 		assert.Equal(t, account2, transaction.AccountTo)
-		assert.Equal(t, 100.0, transaction.Amount)
+		assert.Equal(t, NewMoney(100_00, DefaultCurrency), transaction.Amount)
 		assert.NotEmpty(t, transaction.ID)
 		assert.NotEmpty(t, transaction.CreatedAt)
-		assert.Equal(t, 900.0, account1.Balance)
-		assert.Equal(t, 1100.0, account2.Balance)
+		assert.Equal(t, NewMoney(900_00, DefaultCurrency), account1.Balance)
+		assert.Equal(t, NewMoney(1100_00, DefaultCurrency), account2.Balance)
 	})
 
 	t.Run("should return error when account from is nil", func(t *testing.T) {
-		transaction, err := NewTransaction(nil, account2, 100)
+		transaction, err := NewTransaction(nil, account2, NewMoney(100_00, DefaultCurrency))
 		assert.NotNil(t, err)
 		assert.Nil(t, transaction)
-		assert.Equal(t, "account from cannot be nil", err.Error())
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "account_from", validationErr.Field)
 	})
 
 	t.Run("should return error when account to is nil", func(t *testing.T) {
-		transaction, err := NewTransaction(account1, nil, 100)
+		transaction, err := NewTransaction(account1, nil, NewMoney(100_00, DefaultCurrency))
 		assert.NotNil(t, err)
 		assert.Nil(t, transaction)
-		assert.Equal(t, "account to cannot be nil", err.Error())
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "account_to", validationErr.Field)
+	})
+
+	t.Run("should return error when both accounts are the same", func(t *testing.T) {
+		for _, kind := range []TransactionKind{TransactionTransfer, TransactionDeposit, TransactionWithdrawal} {
+			transaction := &Transaction{
+				Kind:        kind,
+				AccountFrom: account1,
+				AccountTo:   account1,
+				Amount:      NewMoney(100_00, DefaultCurrency),
+			}
+			err := transaction.Validate()
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr, kind)
+			assert.Equal(t, "account_to", validationErr.Field, kind)
+		}
 	})
 
 	t.Run("should return error when amount is less than or equal to zero", func(t *testing.T) {
-		transaction, err := NewTransaction(account1, account2, 0)
+		transaction, err := NewTransaction(account1, account2, NewMoney(0, DefaultCurrency))
 		assert.NotNil(t, err)
 		assert.Nil(t, transaction)
-		assert.Equal(t, "amount must be greater than zero", err.Error())
+		assert.ErrorIs(t, err, ErrInvalidAmount)
 
-		transaction, err = NewTransaction(account1, account2, -10)
+		transaction, err = NewTransaction(account1, account2, NewMoney(-10_00, DefaultCurrency))
 		assert.NotNil(t, err)
 		assert.Nil(t, transaction)
-		assert.Equal(t, "amount must be greater than zero", err.Error())
+		assert.ErrorIs(t, err, ErrInvalidAmount)
 	})
 
 	t.Run("should return error when account from has insufficient funds", func(t *testing.T) {
 		accountWithLowBalance := NewAccount(client1)
-		accountWithLowBalance.Credit(50)
+		accountWithLowBalance.Credit(NewMoney(50_00, DefaultCurrency))
 
-		transaction, err := NewTransaction(accountWithLowBalance, account2, 100)
+		transaction, err := NewTransaction(accountWithLowBalance, account2, NewMoney(100_00, DefaultCurrency))
 		assert.NotNil(t, err)
 		assert.Nil(t, transaction)
-		assert.Equal(t, "insufficient funds in account from", err.Error())
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
 	})
 }
 
@@ -71,14 +90,14 @@ func TestTransaction_Validate(t *testing.T) {
 	client2, _ := NewClient("Jane", "jane@j.com")
 	account2 := NewAccount(client2)
 
-	account1.Credit(1000)
-	account2.Credit(1000)
+	account1.Credit(NewMoney(1000_00, DefaultCurrency))
+	account2.Credit(NewMoney(1000_00, DefaultCurrency))
 
 	t.Run("should return nil when transaction is valid", func(t *testing.T) {
 		transaction := &Transaction{
 			AccountFrom: account1,
 			AccountTo:   account2,
-			Amount:      100,
+			Amount:      NewMoney(100_00, DefaultCurrency),
 		}
 		err := transaction.Validate()
 		assert.Nil(t, err)
@@ -87,51 +106,119 @@ func TestTransaction_Validate(t *testing.T) {
 	t.Run("should return error when account from is nil", func(t *testing.T) {
 		transaction := &Transaction{
 			AccountTo: account2,
-			Amount:    100,
+			Amount:    NewMoney(100_00, DefaultCurrency),
 		}
 		err := transaction.Validate()
 		assert.NotNil(t, err)
-		assert.Equal(t, "account from cannot be nil", err.Error())
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "account_from", validationErr.Field)
 	})
 
 	t.Run("should return error when account to is nil", func(t *testing.T) {
 		transaction := &Transaction{
 			AccountFrom: account1,
-			Amount:      100,
+			Amount:      NewMoney(100_00, DefaultCurrency),
 		}
 		err := transaction.Validate()
 		assert.NotNil(t, err)
-		assert.Equal(t, "account to cannot be nil", err.Error())
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "account_to", validationErr.Field)
 	})
 
 	t.Run("should return error when amount is less than or equal to zero", func(t *testing.T) {
 		transaction := &Transaction{
 			AccountFrom: account1,
 			AccountTo:   account2,
-			Amount:      0,
+			Amount:      NewMoney(0, DefaultCurrency),
 		}
 		err := transaction.Validate()
 		assert.NotNil(t, err)
-		assert.Equal(t, "amount must be greater than zero", err.Error())
+		assert.ErrorIs(t, err, ErrInvalidAmount)
 
-		transaction.Amount = -10
+		transaction.Amount = NewMoney(-10_00, DefaultCurrency)
 		err = transaction.Validate()
 		assert.NotNil(t, err)
-		assert.Equal(t, "amount must be greater than zero", err.Error())
+		assert.ErrorIs(t, err, ErrInvalidAmount)
 	})
 
 	t.Run("should return error when account from has insufficient funds", func(t *testing.T) {
 		accountWithLowBalance := NewAccount(client1)
-		accountWithLowBalance.Credit(50)
+		accountWithLowBalance.Credit(NewMoney(50_00, DefaultCurrency))
 
 		transaction := &Transaction{
 			AccountFrom: accountWithLowBalance,
 			AccountTo:   account2,
-			Amount:      100,
+			Amount:      NewMoney(100_00, DefaultCurrency),
+		}
+		err := transaction.Validate()
+		assert.NotNil(t, err)
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+	})
+
+	t.Run("should allow overdraft up to the limit", func(t *testing.T) {
+		accountWithOverdraft := NewAccount(client1)
+		accountWithOverdraft.Credit(NewMoney(50_00, DefaultCurrency))
+		accountWithOverdraft.SetOverdraftLimit(NewMoney(50_00, DefaultCurrency))
+
+		transaction := &Transaction{
+			AccountFrom: accountWithOverdraft,
+			AccountTo:   account2,
+			Amount:      NewMoney(100_00, DefaultCurrency),
+		}
+		assert.Nil(t, transaction.Validate())
+
+		transaction.Amount = NewMoney(100_01, DefaultCurrency)
+		assert.ErrorIs(t, transaction.Validate(), ErrInsufficientFunds)
+	})
+
+	t.Run("should not spend held funds", func(t *testing.T) {
+		accountWithHold := NewAccount(client1)
+		accountWithHold.Credit(NewMoney(100_00, DefaultCurrency))
+		accountWithHold.Hold(NewMoney(60_00, DefaultCurrency))
+
+		transaction := &Transaction{
+			AccountFrom: accountWithHold,
+			AccountTo:   account2,
+			Amount:      NewMoney(50_00, DefaultCurrency),
+		}
+		assert.ErrorIs(t, transaction.Validate(), ErrInsufficientFunds)
+
+		transaction.Amount = NewMoney(40_00, DefaultCurrency)
+		assert.Nil(t, transaction.Validate())
+	})
+
+	t.Run("should return error when an account is not active", func(t *testing.T) {
+		frozenAccount := NewAccount(client2)
+		frozenAccount.Freeze()
+
+		transaction := &Transaction{
+			AccountFrom: account1,
+			AccountTo:   frozenAccount,
+			Amount:      NewMoney(100_00, DefaultCurrency),
+		}
+		err := transaction.Validate()
+		assert.ErrorIs(t, err, ErrAccountNotActive)
+
+		transaction = &Transaction{
+			AccountFrom: frozenAccount,
+			AccountTo:   account2,
+			Amount:      NewMoney(100_00, DefaultCurrency),
+		}
+		err = transaction.Validate()
+		assert.ErrorIs(t, err, ErrAccountNotActive)
+	})
+
+	t.Run("should return error when amount currency differs from accounts", func(t *testing.T) {
+		transaction := &Transaction{
+			AccountFrom: account1,
+			AccountTo:   account2,
+			Amount:      NewMoney(100_00, "USD"),
 		}
 		err := transaction.Validate()
 		assert.NotNil(t, err)
-		assert.Equal(t, "insufficient funds in account from", err.Error())
+		assert.ErrorIs(t, err, ErrCurrencyMismatch)
 	})
 }
 
@@ -141,16 +228,140 @@ func TestTransaction_Commit(t *testing.T) {
 	client2, _ := NewClient("Jane", "jane@j.com")
 	account2 := NewAccount(client2)
 
-	account1.Credit(1000)
-	account2.Credit(1000)
+	account1.Credit(NewMoney(1000_00, DefaultCurrency))
+	account2.Credit(NewMoney(1000_00, DefaultCurrency))
 
 	transaction := &Transaction{
 		AccountFrom: account1,
 		AccountTo:   account2,
-		Amount:      100,
+		Amount:      NewMoney(100_00, DefaultCurrency),
 	}
 
-	transaction.Commit()
-	assert.Equal(t, 900.0, account1.Balance)
-	assert.Equal(t, 1100.0, account2.Balance)
+	err := transaction.Commit()
+	assert.Nil(t, err)
+	assert.Equal(t, NewMoney(900_00, DefaultCurrency), account1.Balance)
+	assert.Equal(t, NewMoney(1100_00, DefaultCurrency), account2.Balance)
+}
+
+func TestNewReversal(t *testing.T) {
+	client1, _ := NewClient("John", "j@j.com")
+	client2, _ := NewClient("Jane", "jane@j.com")
+
+	newOriginal := func() (*Transaction, *Account, *Account) {
+		sender := NewAccount(client1)
+		sender.Credit(NewMoney(100_00, DefaultCurrency))
+		recipient := NewAccount(client2)
+		original, _ := NewTransaction(sender, recipient, NewMoney(100_00, DefaultCurrency))
+		return original, sender, recipient
+	}
+
+	t.Run("should reverse the full amount", func(t *testing.T) {
+		original, sender, recipient := newOriginal()
+
+		reversal, err := NewReversal(original, recipient, sender, NewMoney(100_00, DefaultCurrency), nil)
+		assert.Nil(t, err)
+		assert.Equal(t, original.ID, reversal.ReversalOf)
+		assert.Equal(t, NewMoney(100_00, DefaultCurrency), sender.Balance)
+		assert.True(t, recipient.Balance.IsZero())
+	})
+
+	t.Run("should reverse in parts up to the original amount", func(t *testing.T) {
+		original, sender, recipient := newOriginal()
+
+		first, err := NewReversal(original, recipient, sender, NewMoney(60_00, DefaultCurrency), nil)
+		assert.Nil(t, err)
+
+		remaining, err := original.RemainingAmount([]*Transaction{first})
+		assert.Nil(t, err)
+		assert.Equal(t, NewMoney(40_00, DefaultCurrency), remaining)
+
+		_, err = NewReversal(original, recipient, sender, NewMoney(40_01, DefaultCurrency), []*Transaction{first})
+		assert.ErrorIs(t, err, ErrReversalExceedsOriginal)
+		assert.Equal(t, NewMoney(40_00, DefaultCurrency), recipient.Balance)
+
+		_, err = NewReversal(original, recipient, sender, NewMoney(40_00, DefaultCurrency), []*Transaction{first})
+		assert.Nil(t, err)
+	})
+
+	t.Run("should respect the recipient available balance", func(t *testing.T) {
+		original, sender, recipient := newOriginal()
+		recipient.Debit(NewMoney(70_00, DefaultCurrency))
+
+		_, err := NewReversal(original, recipient, sender, NewMoney(50_00, DefaultCurrency), nil)
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+	})
+
+	t.Run("should not reverse a reversal", func(t *testing.T) {
+		original, sender, recipient := newOriginal()
+		reversal, _ := NewReversal(original, recipient, sender, NewMoney(10_00, DefaultCurrency), nil)
+
+		_, err := NewReversal(reversal, sender, recipient, NewMoney(10_00, DefaultCurrency), nil)
+		assert.ErrorIs(t, err, ErrInvalidReversal)
+	})
+
+	t.Run("should only move funds back between the original accounts", func(t *testing.T) {
+		original, sender, _ := newOriginal()
+		other := NewAccount(client2)
+		other.Credit(NewMoney(100_00, DefaultCurrency))
+
+		_, err := NewReversal(original, other, sender, NewMoney(10_00, DefaultCurrency), nil)
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "account_from", validationErr.Field)
+	})
+}
+
+func TestNewFee(t *testing.T) {
+	client1, _ := NewClient("John", "j@j.com")
+	client2, _ := NewClient("Jane", "jane@j.com")
+	payer := NewAccount(client1)
+	payer.Credit(NewMoney(100_00, DefaultCurrency))
+	payee := NewAccount(client2)
+	revenue := NewAccount(client2)
+
+	transfer, _ := NewTransaction(payer, payee, NewMoney(90_00, DefaultCurrency))
+
+	fee, err := NewFee(transfer, revenue, NewMoney(2_00, DefaultCurrency))
+	assert.Nil(t, err)
+	assert.Equal(t, transfer.ID, fee.FeeOf)
+	assert.Equal(t, payer, fee.AccountFrom)
+	assert.Equal(t, NewMoney(8_00, DefaultCurrency), payer.Balance)
+	assert.Equal(t, NewMoney(2_00, DefaultCurrency), revenue.Balance)
+
+	_, err = NewFee(transfer, revenue, NewMoney(8_01, DefaultCurrency))
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestNewDepositAndWithdrawal(t *testing.T) {
+	system, _ := NewClient("Wallet", "treasury@example.com")
+	client, _ := NewClient("John", "j@j.com")
+	treasury := NewAccount(system)
+	account := NewAccount(client)
+
+	deposit, err := NewDeposit(treasury, account, NewMoney(100_00, DefaultCurrency))
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionDeposit, deposit.Kind)
+	assert.Equal(t, NewMoney(-100_00, DefaultCurrency), treasury.Balance)
+	assert.Equal(t, NewMoney(100_00, DefaultCurrency), account.Balance)
+
+	withdrawal, err := NewWithdrawal(account, treasury, NewMoney(40_00, DefaultCurrency))
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionWithdrawal, withdrawal.Kind)
+	assert.Equal(t, NewMoney(-60_00, DefaultCurrency), treasury.Balance)
+	assert.Equal(t, NewMoney(60_00, DefaultCurrency), account.Balance)
+
+	_, err = NewWithdrawal(account, treasury, NewMoney(60_01, DefaultCurrency))
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = NewDeposit(treasury, treasury, NewMoney(1_00, DefaultCurrency))
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+
+	reversal, err := NewReversal(deposit, account, treasury, NewMoney(10_00, DefaultCurrency), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionWithdrawal, reversal.Kind)
+
+	reversal, err = NewReversal(withdrawal, treasury, account, NewMoney(10_00, DefaultCurrency), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, TransactionDeposit, reversal.Kind)
 }
@@ -0,0 +1,108 @@
+// Package events carries the domain events emitted by the use cases and
+// dispatches them to in-process handlers.
+package events
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var (
+	ErrHandlerAlreadyRegistered = errors.New("handler already registered")
+	ErrHandlerNotComparable     = errors.New("handler must be a non-nil comparable value, such as a pointer")
+)
+
+// Event is something that happened in the domain. Events are plain structs so
+// they can be serialized as they are. EventKey identifies the aggregate the
+// event is about, such as an account.
+type Event interface {
+	EventName() string
+	EventKey() string
+	OccurredAt() time.Time
+}
+
+// Handler reacts to an event. Handlers are compared by identity, so they must
+// be comparable: Register rejects funcs, slices and maps, and structs holding
+// them, with ErrHandlerNotComparable.
+type Handler interface {
+	Handle(event Event)
+}
+
+// Dispatcher is what the use cases depend on to emit events.
+type Dispatcher interface {
+	Dispatch(events ...Event)
+}
+
+// EventDispatcher calls the handlers registered for an event synchronously,
+// in the order they were registered.
+type EventDispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewEventDispatcher() *EventDispatcher {
+	return &EventDispatcher{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+func (d *EventDispatcher) Register(eventName string, handler Handler) error {
+	if t := reflect.TypeOf(handler); t == nil || !t.Comparable() {
+		return ErrHandlerNotComparable
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, h := range d.handlers[eventName] {
+		if h == handler {
+			return ErrHandlerAlreadyRegistered
+		}
+	}
+	d.handlers[eventName] = append(d.handlers[eventName], handler)
+	return nil
+}
+
+func (d *EventDispatcher) Dispatch(events ...Event) {
+	for _, event := range events {
+		d.mu.RLock()
+		handlers := d.handlers[event.EventName()]
+		d.mu.RUnlock()
+		for _, handler := range handlers {
+			handler.Handle(event)
+		}
+	}
+}
+
+// Remove unregisters handler, keeping the order of the others. Only
+// comparable handlers are ever registered, so comparing them with handler
+// cannot panic.
+func (d *EventDispatcher) Remove(eventName string, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	handlers := d.handlers[eventName]
+	for i, h := range handlers {
+		if h == handler {
+			d.handlers[eventName] = append(handlers[:i:i], handlers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (d *EventDispatcher) Has(eventName string, handler Handler) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, h := range d.handlers[eventName] {
+		if h == handler {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *EventDispatcher) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers = make(map[string][]Handler)
+}
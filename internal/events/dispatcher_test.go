@@ -0,0 +1,112 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TestEvent struct {
+	Name string
+}
+
+func (e TestEvent) EventName() string     { return e.Name }
+func (e TestEvent) EventKey() string      { return "" }
+func (e TestEvent) OccurredAt() time.Time { return time.Time{} }
+
+type TestHandler struct {
+	ID    string
+	Calls *[]string
+}
+
+func (h *TestHandler) Handle(event Event) {
+	*h.Calls = append(*h.Calls, h.ID+":"+event.EventName())
+}
+
+type EventDispatcherTestSuite struct {
+	suite.Suite
+	calls      []string
+	handler    *TestHandler
+	handler2   *TestHandler
+	handler3   *TestHandler
+	dispatcher *EventDispatcher
+}
+
+func (s *EventDispatcherTestSuite) SetupTest() {
+	s.calls = nil
+	s.handler = &TestHandler{ID: "1", Calls: &s.calls}
+	s.handler2 = &TestHandler{ID: "2", Calls: &s.calls}
+	s.handler3 = &TestHandler{ID: "3", Calls: &s.calls}
+	s.dispatcher = NewEventDispatcher()
+}
+
+func TestEventDispatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(EventDispatcherTestSuite))
+}
+
+func (s *EventDispatcherTestSuite) TestRegister() {
+	s.Nil(s.dispatcher.Register("a", s.handler))
+	s.Nil(s.dispatcher.Register("a", s.handler2))
+	s.Nil(s.dispatcher.Register("b", s.handler))
+
+	s.True(s.dispatcher.Has("a", s.handler))
+	s.True(s.dispatcher.Has("a", s.handler2))
+	s.True(s.dispatcher.Has("b", s.handler))
+	s.False(s.dispatcher.Has("b", s.handler2))
+}
+
+func (s *EventDispatcherTestSuite) TestRegisterTwice() {
+	s.Nil(s.dispatcher.Register("a", s.handler))
+	s.ErrorIs(s.dispatcher.Register("a", s.handler), ErrHandlerAlreadyRegistered)
+}
+
+// FuncHandler is not comparable.
+type FuncHandler func(event Event)
+
+func (f FuncHandler) Handle(event Event) { f(event) }
+
+func (s *EventDispatcherTestSuite) TestRegisterNotComparable() {
+	handler := FuncHandler(func(Event) {})
+	s.ErrorIs(s.dispatcher.Register("a", handler), ErrHandlerNotComparable)
+	s.ErrorIs(s.dispatcher.Register("a", nil), ErrHandlerNotComparable)
+
+	s.Nil(s.dispatcher.Register("a", s.handler))
+	s.False(s.dispatcher.Has("a", handler))
+	s.dispatcher.Remove("a", handler)
+	s.True(s.dispatcher.Has("a", s.handler))
+}
+
+func (s *EventDispatcherTestSuite) TestDispatchInRegistrationOrder() {
+	s.dispatcher.Register("a", s.handler2)
+	s.dispatcher.Register("a", s.handler)
+	s.dispatcher.Register("b", s.handler3)
+
+	s.dispatcher.Dispatch(TestEvent{Name: "a"}, TestEvent{Name: "b"}, TestEvent{Name: "c"})
+
+	s.Equal([]string{"2:a", "1:a", "3:b"}, s.calls)
+}
+
+func (s *EventDispatcherTestSuite) TestRemove() {
+	s.dispatcher.Register("a", s.handler)
+	s.dispatcher.Register("a", s.handler2)
+	s.dispatcher.Register("a", s.handler3)
+
+	s.dispatcher.Remove("a", s.handler2)
+	s.dispatcher.Remove("a", s.handler2)
+	s.dispatcher.Remove("unknown", s.handler)
+
+	s.False(s.dispatcher.Has("a", s.handler2))
+	s.dispatcher.Dispatch(TestEvent{Name: "a"})
+	s.Equal([]string{"1:a", "3:a"}, s.calls)
+}
+
+func (s *EventDispatcherTestSuite) TestClear() {
+	s.dispatcher.Register("a", s.handler)
+	s.dispatcher.Register("b", s.handler2)
+
+	s.dispatcher.Clear()
+
+	s.False(s.dispatcher.Has("a", s.handler))
+	s.False(s.dispatcher.Has("b", s.handler2))
+}
@@ -0,0 +1,116 @@
+package events
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+const (
+	ClientCreatedName      = "ClientCreated"
+	AccountCreatedName     = "AccountCreated"
+	TransactionCreatedName = "TransactionCreated"
+	BalanceUpdatedName     = "BalanceUpdated"
+)
+
+// Amounts are carried as decimal strings with their currency so events
+// serialize without losing precision.
+
+type ClientCreated struct {
+	ClientID  string    `json:"client_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Tier      string    `json:"tier"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewClientCreated(client *entity.Client) ClientCreated {
+	return ClientCreated{
+		ClientID:  client.ID,
+		Name:      client.Name,
+		Email:     client.Email,
+		Tier:      string(client.Tier),
+		CreatedAt: client.CreatedAt,
+	}
+}
+
+func (e ClientCreated) EventName() string     { return ClientCreatedName }
+func (e ClientCreated) EventKey() string      { return e.ClientID }
+func (e ClientCreated) OccurredAt() time.Time { return e.CreatedAt }
+
+type AccountCreated struct {
+	AccountID string    `json:"account_id"`
+	ClientID  string    `json:"client_id"`
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewAccountCreated(account *entity.Account) AccountCreated {
+	return AccountCreated{
+		AccountID: account.ID,
+		ClientID:  account.Client.ID,
+		Currency:  account.Balance.Currency(),
+		CreatedAt: account.CreatedAt,
+	}
+}
+
+func (e AccountCreated) EventName() string     { return AccountCreatedName }
+func (e AccountCreated) EventKey() string      { return e.AccountID }
+func (e AccountCreated) OccurredAt() time.Time { return e.CreatedAt }
+
+type TransactionCreated struct {
+	TransactionID string    `json:"transaction_id"`
+	Kind          string    `json:"kind"`
+	AccountIDFrom string    `json:"account_id_from"`
+	AccountIDTo   string    `json:"account_id_to"`
+	Amount        string    `json:"amount"`
+	Currency      string    `json:"currency"`
+	ReversalOf    string    `json:"reversal_of,omitempty"`
+	FeeOf         string    `json:"fee_of,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func NewTransactionCreated(transaction *entity.Transaction) TransactionCreated {
+	return TransactionCreated{
+		TransactionID: transaction.ID,
+		Kind:          string(transaction.Kind),
+		AccountIDFrom: transaction.AccountFrom.ID,
+		AccountIDTo:   transaction.AccountTo.ID,
+		Amount:        transaction.Amount.Decimal(),
+		Currency:      transaction.Amount.Currency(),
+		ReversalOf:    transaction.ReversalOf,
+		FeeOf:         transaction.FeeOf,
+		CreatedAt:     transaction.CreatedAt,
+	}
+}
+
+func (e TransactionCreated) EventName() string     { return TransactionCreatedName }
+func (e TransactionCreated) EventKey() string      { return e.AccountIDFrom }
+func (e TransactionCreated) OccurredAt() time.Time { return e.CreatedAt }
+
+// BalanceUpdated reports the balance of an account after a change. Version is
+// the account version the balance was written with, so consumers can discard
+// events older than what they have already applied.
+type BalanceUpdated struct {
+	AccountID  string    `json:"account_id"`
+	Balance    string    `json:"balance"`
+	HeldAmount string    `json:"held_amount"`
+	Currency   string    `json:"currency"`
+	Version    int       `json:"version"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func NewBalanceUpdated(account *entity.Account) BalanceUpdated {
+	return BalanceUpdated{
+		AccountID:  account.ID,
+		Balance:    account.Balance.Decimal(),
+		HeldAmount: account.HeldAmount.Decimal(),
+		Currency:   account.Balance.Currency(),
+		Version:    account.Version,
+		UpdatedAt:  account.UpdatedAt,
+	}
+}
+
+func (e BalanceUpdated) EventName() string     { return BalanceUpdatedName }
+func (e BalanceUpdated) EventKey() string      { return e.AccountID }
+func (e BalanceUpdated) OccurredAt() time.Time { return e.UpdatedAt }
@@ -0,0 +1,143 @@
+// Package fee computes the fee charged on a transfer. Policies compose: a
+// Tiered or ByClientTier policy delegates to other policies, and Capped
+// bounds the result of any policy.
+package fee
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+var ErrInvalidPolicy = errors.New("invalid fee policy")
+
+// Policy returns the fee charged to client for transferring amount. The fee
+// is in the currency of amount and never negative.
+type Policy interface {
+	Fee(amount entity.Money, client *entity.Client) (entity.Money, error)
+}
+
+// None charges nothing.
+type None struct{}
+
+func (None) Fee(amount entity.Money, client *entity.Client) (entity.Money, error) {
+	return entity.Zero(amount.Currency()), nil
+}
+
+// Flat charges the same fee on every transfer.
+type Flat struct {
+	Amount entity.Money
+}
+
+func (p Flat) Fee(amount entity.Money, client *entity.Client) (entity.Money, error) {
+	if p.Amount.IsNegative() {
+		return entity.Money{}, fmt.Errorf("%w: flat fee %s is negative", ErrInvalidPolicy, p.Amount)
+	}
+	if p.Amount.Currency() != amount.Currency() {
+		return entity.Money{}, fmt.Errorf("%w: flat fee in %s for a transfer in %s", entity.ErrCurrencyMismatch, p.Amount.Currency(), amount.Currency())
+	}
+	return p.Amount, nil
+}
+
+// Percentage charges a share of the amount expressed in basis points
+// (1 bp = 0.01%), rounded half up to the nearest minor unit.
+type Percentage struct {
+	BasisPoints int64
+}
+
+func (p Percentage) Fee(amount entity.Money, client *entity.Client) (entity.Money, error) {
+	if p.BasisPoints < 0 {
+		return entity.Money{}, fmt.Errorf("%w: %d basis points", ErrInvalidPolicy, p.BasisPoints)
+	}
+	product := new(big.Int).Mul(big.NewInt(amount.MinorUnits()), big.NewInt(p.BasisPoints))
+	product.Add(product, big.NewInt(5_000))
+	fee := product.Quo(product, big.NewInt(10_000))
+	if !fee.IsInt64() {
+		return entity.Money{}, entity.ErrMoneyOverflow
+	}
+	return entity.NewMoney(fee.Int64(), amount.Currency()), nil
+}
+
+// Tier applies Policy to amounts up to and including UpTo. A zero UpTo
+// matches any amount.
+type Tier struct {
+	UpTo   entity.Money
+	Policy Policy
+}
+
+// Tiered applies the policy of the first tier the amount falls into. Tiers
+// must be ordered by UpTo, with an unbounded tier last.
+type Tiered struct {
+	Tiers []Tier
+}
+
+func (p Tiered) Fee(amount entity.Money, client *entity.Client) (entity.Money, error) {
+	for _, tier := range p.Tiers {
+		if tier.UpTo.IsZero() {
+			return tier.Policy.Fee(amount, client)
+		}
+		cmp, err := amount.Cmp(tier.UpTo)
+		if err != nil {
+			return entity.Money{}, err
+		}
+		if cmp <= 0 {
+			return tier.Policy.Fee(amount, client)
+		}
+	}
+	return entity.Money{}, fmt.Errorf("%w: no tier for %s", ErrInvalidPolicy, amount)
+}
+
+// Capped bounds the fee of Policy between Min and Max. A zero Max means no
+// upper bound.
+type Capped struct {
+	Policy Policy
+	Min    entity.Money
+	Max    entity.Money
+}
+
+func (p Capped) Fee(amount entity.Money, client *entity.Client) (entity.Money, error) {
+	fee, err := p.Policy.Fee(amount, client)
+	if err != nil {
+		return entity.Money{}, err
+	}
+	if !p.Min.IsZero() {
+		cmp, err := fee.Cmp(p.Min)
+		if err != nil {
+			return entity.Money{}, err
+		}
+		if cmp < 0 {
+			fee = p.Min
+		}
+	}
+	if !p.Max.IsZero() {
+		cmp, err := fee.Cmp(p.Max)
+		if err != nil {
+			return entity.Money{}, err
+		}
+		if cmp > 0 {
+			fee = p.Max
+		}
+	}
+	return fee, nil
+}
+
+// ByClientTier applies the policy configured for the client's tier, or
+// Default for tiers without one.
+type ByClientTier struct {
+	Tiers   map[entity.ClientTier]Policy
+	Default Policy
+}
+
+func (p ByClientTier) Fee(amount entity.Money, client *entity.Client) (entity.Money, error) {
+	if client != nil {
+		if policy, ok := p.Tiers[client.Tier]; ok {
+			return policy.Fee(amount, client)
+		}
+	}
+	if p.Default == nil {
+		return None{}.Fee(amount, client)
+	}
+	return p.Default.Fee(amount, client)
+}
@@ -0,0 +1,93 @@
+package fee
+
+import (
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func brl(minorUnits int64) entity.Money {
+	return entity.NewMoney(minorUnits, entity.DefaultCurrency)
+}
+
+func TestFlat(t *testing.T) {
+	fee, err := Flat{Amount: brl(2_50)}.Fee(brl(100_00), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, brl(2_50), fee)
+
+	_, err = Flat{Amount: entity.NewMoney(1_00, "USD")}.Fee(brl(100_00), nil)
+	assert.ErrorIs(t, err, entity.ErrCurrencyMismatch)
+
+	_, err = Flat{Amount: brl(-1)}.Fee(brl(100_00), nil)
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+}
+
+func TestPercentage(t *testing.T) {
+	tests := []struct {
+		basisPoints int64
+		amount      int64
+		expected    int64
+	}{
+		{150, 100_00, 1_50},
+		{150, 33, 0},
+		{150, 34, 1},
+		{1, 1_000_00, 10},
+		{0, 100_00, 0},
+	}
+	for _, tt := range tests {
+		fee, err := Percentage{BasisPoints: tt.basisPoints}.Fee(brl(tt.amount), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, brl(tt.expected), fee, "%d bp of %d", tt.basisPoints, tt.amount)
+	}
+
+	_, err := Percentage{BasisPoints: -1}.Fee(brl(100_00), nil)
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+}
+
+func TestTiered(t *testing.T) {
+	policy := Tiered{Tiers: []Tier{
+		{UpTo: brl(100_00), Policy: Flat{Amount: brl(1_00)}},
+		{UpTo: brl(1000_00), Policy: Percentage{BasisPoints: 100}},
+		{Policy: Flat{Amount: brl(5_00)}},
+	}}
+
+	fee, _ := policy.Fee(brl(100_00), nil)
+	assert.Equal(t, brl(1_00), fee)
+	fee, _ = policy.Fee(brl(300_00), nil)
+	assert.Equal(t, brl(3_00), fee)
+	fee, _ = policy.Fee(brl(5000_00), nil)
+	assert.Equal(t, brl(5_00), fee)
+
+	_, err := Tiered{Tiers: []Tier{{UpTo: brl(10_00), Policy: None{}}}}.Fee(brl(20_00), nil)
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+}
+
+func TestCapped(t *testing.T) {
+	policy := Capped{Policy: Percentage{BasisPoints: 200}, Min: brl(1_00), Max: brl(10_00)}
+
+	fee, _ := policy.Fee(brl(10_00), nil)
+	assert.Equal(t, brl(1_00), fee)
+	fee, _ = policy.Fee(brl(200_00), nil)
+	assert.Equal(t, brl(4_00), fee)
+	fee, _ = policy.Fee(brl(10000_00), nil)
+	assert.Equal(t, brl(10_00), fee)
+}
+
+func TestByClientTier(t *testing.T) {
+	policy := ByClientTier{
+		Tiers:   map[entity.ClientTier]Policy{"premium": None{}},
+		Default: Flat{Amount: brl(2_00)},
+	}
+	client, _ := entity.NewClient("John", "j@j.com")
+
+	fee, _ := policy.Fee(brl(100_00), client)
+	assert.Equal(t, brl(2_00), fee)
+
+	client.SetTier("premium")
+	fee, _ = policy.Fee(brl(100_00), client)
+	assert.True(t, fee.IsZero())
+
+	fee, _ = ByClientTier{}.Fee(brl(100_00), client)
+	assert.True(t, fee.IsZero())
+}
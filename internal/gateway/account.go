@@ -4,7 +4,17 @@ import (
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
 )
 
+// AccountRepository is the name an AccountGateway is registered under in a
+// unit of work.
+const AccountRepository = "AccountDB"
+
+// AccountGateway persists accounts. FindByIDForUpdate also locks the account
+// until the unit of work it runs in ends, where the store supports it.
 type AccountGateway interface {
 	Save(account *entity.Account) error
 	FindByID(id string) (*entity.Account, error)
+	FindByIDForUpdate(id string) (*entity.Account, error)
+	UpdateBalance(account *entity.Account) error
+	UpdateStatus(account *entity.Account) error
+	UpdateOverdraftLimit(account *entity.Account) error
 }
@@ -0,0 +1,21 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+const BalanceRepository = "BalanceDB"
+
+// BalanceGateway persists the balance projection. Writes only take effect
+// when they are newer than the stored state, so applying an event twice or
+// out of order leaves the projection unchanged. ApplyTransaction creates a
+// zero balance in currency at entity.UnprojectedVersion for an account it has
+// not seen yet; its amounts only become meaningful once ApplyBalance stores a
+// version.
+type BalanceGateway interface {
+	ApplyBalance(balance *entity.AccountBalance) error
+	ApplyTransaction(accountID, currency, transactionID string, at time.Time) error
+	FindByAccountID(accountID string) (*entity.AccountBalance, error)
+}
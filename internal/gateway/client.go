@@ -2,7 +2,12 @@ package gateway
 
 import "github.com/AntonioSabino/fc-ms-wallet/internal/entity"
 
+const ClientRepository = "ClientDB"
+
+// ClientGateway persists clients. GetForUpdate also locks the client until
+// the unit of work it runs in ends, where the store supports it.
 type ClientGateway interface {
 	Get(id string) (*entity.Client, error)
+	GetForUpdate(id string) (*entity.Client, error)
 	Save(client *entity.Client) error
 }
@@ -0,0 +1,40 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+)
+
+// NotFoundError reports that no entity of the given kind has the given ID.
+type NotFoundError struct {
+	Entity string
+	ID     string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Entity, e.ID)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return ErrNotFound
+}
+
+// ConflictError reports that an entity changed between being read and being
+// written, so the write was rejected and the operation can be retried.
+type ConflictError struct {
+	Entity string
+	ID     string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %s was modified concurrently", e.Entity, e.ID)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
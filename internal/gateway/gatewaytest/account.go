@@ -0,0 +1,136 @@
+package gatewaytest
+
+import (
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type accountSuite struct {
+	contract
+}
+
+func (s *accountSuite) SetupTest() {
+	s.contract.SetupTest()
+	s.require(s.gateways.Clients, s.gateways.Accounts)
+}
+
+func (s *accountSuite) TestSaveAndFindByID() {
+	account := s.newAccount(100_00)
+
+	retrievedAccount, err := s.gateways.Accounts.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(account.ID, retrievedAccount.ID)
+	s.Equal(brl(100_00), retrievedAccount.Balance)
+	s.True(retrievedAccount.HeldAmount.IsZero())
+	s.True(retrievedAccount.OverdraftLimit.IsZero())
+	s.Equal(entity.AccountActive, retrievedAccount.Status)
+	s.Equal(0, retrievedAccount.Version)
+	s.sameTime(account.CreatedAt, retrievedAccount.CreatedAt)
+	s.Equal(account.Client.ID, retrievedAccount.Client.ID)
+	s.Equal(account.Client.Name, retrievedAccount.Client.Name)
+	s.Equal(account.Client.Email, retrievedAccount.Client.Email)
+}
+
+func (s *accountSuite) TestFindByIDKeepsExactBalances() {
+	account := s.newAccount(0)
+	s.Nil(account.SetOverdraftLimit(brl(500_01)))
+	s.Nil(s.gateways.Accounts.UpdateOverdraftLimit(account))
+	account.Balance = brl(-499_99)
+	account.HeldAmount = brl(1234567_89)
+	s.Nil(s.gateways.Accounts.UpdateBalance(account))
+
+	retrievedAccount, err := s.gateways.Accounts.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(brl(-499_99), retrievedAccount.Balance)
+	s.Equal(brl(1234567_89), retrievedAccount.HeldAmount)
+	s.Equal(brl(500_01), retrievedAccount.OverdraftLimit)
+}
+
+func (s *accountSuite) TestFindByIDNotFound() {
+	account, err := s.gateways.Accounts.FindByID("unknown")
+	s.Nil(account)
+	s.notFound(err, "account", "unknown")
+}
+
+func (s *accountSuite) TestFindByIDForUpdate() {
+	account := s.newAccount(100_00)
+
+	retrievedAccount, err := s.gateways.Accounts.FindByIDForUpdate(account.ID)
+	s.Nil(err)
+	s.Equal(account.ID, retrievedAccount.ID)
+	s.Equal(brl(100_00), retrievedAccount.Balance)
+	s.Equal(0, retrievedAccount.Version)
+	s.Equal(account.Client.ID, retrievedAccount.Client.ID)
+
+	retrievedAccount, err = s.gateways.Accounts.FindByIDForUpdate("unknown")
+	s.Nil(retrievedAccount)
+	s.notFound(err, "account", "unknown")
+}
+
+func (s *accountSuite) TestUpdateBalance() {
+	account := s.newAccount(0)
+	s.Nil(account.Credit(brl(150_25)))
+	s.Nil(account.Hold(brl(50_00)))
+	s.Nil(s.gateways.Accounts.UpdateBalance(account))
+	s.Equal(1, account.Version)
+
+	retrievedAccount, err := s.gateways.Accounts.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(brl(150_25), retrievedAccount.Balance)
+	s.Equal(brl(50_00), retrievedAccount.HeldAmount)
+	s.Equal(1, retrievedAccount.Version)
+}
+
+func (s *accountSuite) TestUpdateBalanceWithStaleVersion() {
+	account := s.newAccount(100_00)
+	first, err := s.gateways.Accounts.FindByID(account.ID)
+	s.Nil(err)
+	second, err := s.gateways.Accounts.FindByID(account.ID)
+	s.Nil(err)
+
+	s.Nil(first.Debit(brl(80_00)))
+	s.Nil(s.gateways.Accounts.UpdateBalance(first))
+
+	s.Nil(second.Debit(brl(80_00)))
+	err = s.gateways.Accounts.UpdateBalance(second)
+	s.ErrorIs(err, gateway.ErrConflict)
+	var conflict *gateway.ConflictError
+	s.ErrorAs(err, &conflict)
+	s.Equal(account.ID, conflict.ID)
+	s.Equal(0, second.Version)
+
+	retrievedAccount, err := s.gateways.Accounts.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(brl(20_00), retrievedAccount.Balance)
+	s.Equal(1, retrievedAccount.Version)
+}
+
+func (s *accountSuite) TestUpdateStatus() {
+	account := s.newAccount(0)
+	stale := *account
+
+	s.Nil(account.Freeze())
+	s.Nil(s.gateways.Accounts.UpdateStatus(account))
+	s.Equal(1, account.Version)
+
+	retrievedAccount, err := s.gateways.Accounts.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(entity.AccountFrozen, retrievedAccount.Status)
+
+	s.Nil(stale.Close())
+	s.ErrorIs(s.gateways.Accounts.UpdateStatus(&stale), gateway.ErrConflict)
+}
+
+func (s *accountSuite) TestUpdateOverdraftLimit() {
+	account := s.newAccount(0)
+	s.Nil(account.SetOverdraftLimit(brl(250_00)))
+	s.Nil(s.gateways.Accounts.UpdateOverdraftLimit(account))
+
+	retrievedAccount, err := s.gateways.Accounts.FindByID(account.ID)
+	s.Nil(err)
+	s.Equal(brl(250_00), retrievedAccount.OverdraftLimit)
+	s.Equal(1, retrievedAccount.Version)
+
+	retrievedAccount.Version = 0
+	s.ErrorIs(s.gateways.Accounts.UpdateOverdraftLimit(retrievedAccount), gateway.ErrConflict)
+}
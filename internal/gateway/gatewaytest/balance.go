@@ -0,0 +1,72 @@
+package gatewaytest
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+type balanceSuite struct {
+	contract
+}
+
+func (s *balanceSuite) SetupTest() {
+	s.contract.SetupTest()
+	s.require(s.gateways.Balances)
+}
+
+func (s *balanceSuite) TestApplyBalanceKeepsNewestVersion() {
+	now := time.Now()
+	newer := &entity.AccountBalance{AccountID: "account-1", Balance: brl(60_00), HeldAmount: brl(10_00), Version: 2, UpdatedAt: now}
+	older := &entity.AccountBalance{AccountID: "account-1", Balance: brl(100_00), HeldAmount: brl(0), Version: 1, UpdatedAt: now.Add(-time.Minute)}
+	s.Nil(s.gateways.Balances.ApplyBalance(newer))
+	s.Nil(s.gateways.Balances.ApplyBalance(older))
+	s.Nil(s.gateways.Balances.ApplyBalance(newer))
+
+	balance, err := s.gateways.Balances.FindByAccountID("account-1")
+	s.Nil(err)
+	s.Equal("account-1", balance.AccountID)
+	s.Equal(brl(60_00), balance.Balance)
+	s.Equal(brl(10_00), balance.HeldAmount)
+	s.Equal(2, balance.Version)
+	s.sameTime(now, balance.UpdatedAt)
+	s.Empty(balance.LastTransactionID)
+	s.True(balance.LastTransactionAt.IsZero())
+
+	newest := &entity.AccountBalance{AccountID: "account-1", Balance: brl(-5_00), HeldAmount: brl(0), Version: 3, UpdatedAt: now.Add(time.Minute)}
+	s.Nil(s.gateways.Balances.ApplyBalance(newest))
+
+	balance, err = s.gateways.Balances.FindByAccountID("account-1")
+	s.Nil(err)
+	s.Equal(brl(-5_00), balance.Balance)
+	s.Equal(3, balance.Version)
+}
+
+func (s *balanceSuite) TestApplyTransactionKeepsLatest() {
+	now := time.Now()
+	s.Nil(s.gateways.Balances.ApplyTransaction("account-1", "USD", "transaction-2", now))
+	s.Nil(s.gateways.Balances.ApplyTransaction("account-1", "USD", "transaction-1", now.Add(-time.Minute)))
+
+	balance, err := s.gateways.Balances.FindByAccountID("account-1")
+	s.Nil(err)
+	s.True(balance.Balance.IsZero())
+	s.Equal("USD", balance.Balance.Currency())
+	s.Equal(entity.UnprojectedVersion, balance.Version)
+	s.Equal("transaction-2", balance.LastTransactionID)
+	s.sameTime(now, balance.LastTransactionAt)
+
+	s.Nil(s.gateways.Balances.ApplyBalance(&entity.AccountBalance{AccountID: "account-1", Balance: entity.NewMoney(7_00, "USD"), HeldAmount: entity.Zero("USD"), Version: 1, UpdatedAt: now}))
+	s.Nil(s.gateways.Balances.ApplyTransaction("account-1", "USD", "transaction-3", now.Add(time.Minute)))
+
+	balance, err = s.gateways.Balances.FindByAccountID("account-1")
+	s.Nil(err)
+	s.Equal(entity.NewMoney(7_00, "USD"), balance.Balance)
+	s.Equal(1, balance.Version)
+	s.Equal("transaction-3", balance.LastTransactionID)
+}
+
+func (s *balanceSuite) TestFindByAccountIDNotFound() {
+	balance, err := s.gateways.Balances.FindByAccountID("unknown")
+	s.Nil(balance)
+	s.notFound(err, "balance", "unknown")
+}
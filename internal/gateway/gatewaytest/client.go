@@ -0,0 +1,34 @@
+package gatewaytest
+
+import (
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+type clientSuite struct {
+	contract
+}
+
+func (s *clientSuite) SetupTest() {
+	s.contract.SetupTest()
+	s.require(s.gateways.Clients)
+}
+
+func (s *clientSuite) TestSaveAndGet() {
+	client, _ := entity.NewClient("Jane Doe", "jane.doe@example.com")
+	s.Nil(client.SetTier("premium"))
+	s.Nil(s.gateways.Clients.Save(client))
+
+	retrievedClient, err := s.gateways.Clients.Get(client.ID)
+	s.Nil(err)
+	s.Equal(client.ID, retrievedClient.ID)
+	s.Equal("Jane Doe", retrievedClient.Name)
+	s.Equal("jane.doe@example.com", retrievedClient.Email)
+	s.Equal(entity.ClientTier("premium"), retrievedClient.Tier)
+	s.sameTime(client.CreatedAt, retrievedClient.CreatedAt)
+}
+
+func (s *clientSuite) TestGetNotFound() {
+	client, err := s.gateways.Clients.Get("unknown")
+	s.Nil(client)
+	s.notFound(err, "client", "unknown")
+}
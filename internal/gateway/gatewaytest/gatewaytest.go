@@ -0,0 +1,100 @@
+// Package gatewaytest holds the contract every implementation of the gateway
+// interfaces must honour, as test suites that run the same scenarios against
+// any backend.
+package gatewaytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/stretchr/testify/suite"
+)
+
+// Gateways are the implementations under test, sharing one store.
+type Gateways struct {
+	Clients               gateway.ClientGateway
+	Accounts              gateway.AccountGateway
+	Transactions          gateway.TransactionGateway
+	Ledger                gateway.LedgerGateway
+	IdempotencyKeys       gateway.IdempotencyKeyGateway
+	OverdraftLimitChanges gateway.OverdraftLimitChangeGateway
+	Holds                 gateway.HoldGateway
+	ScheduledTransfers    gateway.ScheduledTransferGateway
+	Outbox                gateway.OutboxGateway
+	Balances              gateway.BalanceGateway
+}
+
+// Factory returns gateways backed by a new, empty store. Gateways left nil
+// are not tested, nor are the scenarios that need them to set up their data.
+type Factory func(t *testing.T) Gateways
+
+// Run runs every contract against the gateways built by factory, one store
+// per scenario.
+func Run(t *testing.T, factory Factory) {
+	t.Run("ClientGateway", func(t *testing.T) { suite.Run(t, &clientSuite{contract: contract{factory: factory}}) })
+	t.Run("AccountGateway", func(t *testing.T) { suite.Run(t, &accountSuite{contract: contract{factory: factory}}) })
+	t.Run("TransactionGateway", func(t *testing.T) { suite.Run(t, &transactionSuite{contract: contract{factory: factory}}) })
+	t.Run("LedgerGateway", func(t *testing.T) { suite.Run(t, &ledgerSuite{contract: contract{factory: factory}}) })
+	t.Run("IdempotencyKeyGateway", func(t *testing.T) { suite.Run(t, &idempotencyKeySuite{contract: contract{factory: factory}}) })
+	t.Run("OverdraftLimitChangeGateway", func(t *testing.T) {
+		suite.Run(t, &overdraftLimitChangeSuite{contract: contract{factory: factory}})
+	})
+	t.Run("HoldGateway", func(t *testing.T) { suite.Run(t, &holdSuite{contract: contract{factory: factory}}) })
+	t.Run("ScheduledTransferGateway", func(t *testing.T) { suite.Run(t, &scheduledTransferSuite{contract: contract{factory: factory}}) })
+	t.Run("OutboxGateway", func(t *testing.T) { suite.Run(t, &outboxSuite{contract: contract{factory: factory}}) })
+	t.Run("BalanceGateway", func(t *testing.T) { suite.Run(t, &balanceSuite{contract: contract{factory: factory}}) })
+}
+
+type contract struct {
+	suite.Suite
+	factory  Factory
+	gateways Gateways
+}
+
+func (c *contract) SetupTest() {
+	c.gateways = c.factory(c.T())
+}
+
+// require skips the scenario unless every gateway given is implemented.
+func (c *contract) require(gateways ...any) {
+	for _, gateway := range gateways {
+		if gateway == nil {
+			c.T().Skip("gateway not implemented")
+		}
+	}
+}
+
+// newAccount saves a new client and an account of theirs holding balance
+// minor units of the default currency.
+func (c *contract) newAccount(balance int64) *entity.Account {
+	client, err := entity.NewClient("John Doe", "john@example.com")
+	c.Require().Nil(err)
+	c.Require().Nil(c.gateways.Clients.Save(client))
+	account := entity.NewAccount(client)
+	if balance != 0 {
+		c.Require().Nil(account.Credit(brl(balance)))
+	}
+	c.Require().Nil(c.gateways.Accounts.Save(account))
+	return account
+}
+
+// sameTime checks that a time read back is the one written, allowing for
+// stores that keep microseconds only.
+func (c *contract) sameTime(expected, actual time.Time) {
+	c.WithinDuration(expected, actual, time.Microsecond)
+}
+
+func (c *contract) notFound(err error, entity, id string) {
+	c.ErrorIs(err, gateway.ErrNotFound)
+	var notFound *gateway.NotFoundError
+	if c.ErrorAs(err, &notFound) {
+		c.Equal(entity, notFound.Entity)
+		c.Equal(id, notFound.ID)
+	}
+}
+
+func brl(minorUnits int64) entity.Money {
+	return entity.NewMoney(minorUnits, entity.DefaultCurrency)
+}
@@ -0,0 +1,98 @@
+package gatewaytest
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type holdSuite struct {
+	contract
+	account *entity.Account
+}
+
+func (s *holdSuite) SetupTest() {
+	s.contract.SetupTest()
+	s.require(s.gateways.Clients, s.gateways.Accounts, s.gateways.Holds)
+	s.account = s.newAccount(1000_00)
+}
+
+func (s *holdSuite) TestSaveAndFindByID() {
+	hold, err := entity.NewHold(s.account, brl(100_00), time.Now().Add(time.Hour))
+	s.Nil(err)
+	s.Nil(s.gateways.Holds.Save(hold))
+
+	retrievedHold, err := s.gateways.Holds.FindByID(hold.ID)
+	s.Nil(err)
+	s.Equal(hold.ID, retrievedHold.ID)
+	s.Equal(s.account.ID, retrievedHold.AccountID)
+	s.Equal(brl(100_00), retrievedHold.Amount)
+	s.True(retrievedHold.CapturedAmount.IsZero())
+	s.Equal(entity.DefaultCurrency, retrievedHold.CapturedAmount.Currency())
+	s.Equal(entity.HoldActive, retrievedHold.Status)
+	s.Empty(retrievedHold.TransactionID)
+	s.sameTime(hold.ExpiresAt, retrievedHold.ExpiresAt)
+	s.sameTime(hold.CreatedAt, retrievedHold.CreatedAt)
+	s.sameTime(hold.UpdatedAt, retrievedHold.UpdatedAt)
+}
+
+func (s *holdSuite) TestFindByIDNotFound() {
+	hold, err := s.gateways.Holds.FindByID("unknown")
+	s.Nil(hold)
+	s.notFound(err, "hold", "unknown")
+}
+
+func (s *holdSuite) TestUpdateOnlyActiveHolds() {
+	hold, _ := entity.NewHold(s.account, brl(100_00), time.Now().Add(time.Hour))
+	s.Nil(s.gateways.Holds.Save(hold))
+	stale := *hold
+
+	merchant := s.newAccount(0)
+	transaction, err := hold.Capture(s.account, merchant, brl(40_00), time.Now())
+	s.Nil(err)
+	s.Nil(s.gateways.Holds.Update(hold))
+
+	retrievedHold, err := s.gateways.Holds.FindByID(hold.ID)
+	s.Nil(err)
+	s.Equal(entity.HoldCaptured, retrievedHold.Status)
+	s.Equal(brl(40_00), retrievedHold.CapturedAmount)
+	s.Equal(transaction.ID, retrievedHold.TransactionID)
+	s.sameTime(hold.UpdatedAt, retrievedHold.UpdatedAt)
+
+	stale.Status = entity.HoldVoided
+	stale.UpdatedAt = time.Now()
+	err = s.gateways.Holds.Update(&stale)
+	s.ErrorIs(err, gateway.ErrConflict)
+	var conflict *gateway.ConflictError
+	s.ErrorAs(err, &conflict)
+	s.Equal(hold.ID, conflict.ID)
+
+	retrievedHold, err = s.gateways.Holds.FindByID(hold.ID)
+	s.Nil(err)
+	s.Equal(entity.HoldCaptured, retrievedHold.Status)
+}
+
+func (s *holdSuite) TestFindExpired() {
+	now := time.Now()
+	later, _ := entity.NewHold(s.account, brl(10_00), now.Add(time.Hour))
+	expiring, _ := entity.NewHold(s.account, brl(10_00), now.Add(2*time.Minute))
+	earliest, _ := entity.NewHold(s.account, brl(10_00), now.Add(time.Minute))
+	voided, _ := entity.NewHold(s.account, brl(10_00), now.Add(time.Minute))
+	s.Nil(voided.Void(s.account, now))
+	for _, hold := range []*entity.Hold{later, expiring, earliest, voided} {
+		s.Nil(s.gateways.Holds.Save(hold))
+	}
+
+	holds, err := s.gateways.Holds.FindExpired(now)
+	s.Nil(err)
+	s.Empty(holds)
+
+	holds, err = s.gateways.Holds.FindExpired(now.Add(2 * time.Minute))
+	s.Nil(err)
+	s.Len(holds, 2)
+	if len(holds) == 2 {
+		s.Equal(earliest.ID, holds[0].ID)
+		s.Equal(expiring.ID, holds[1].ID)
+	}
+}
@@ -0,0 +1,49 @@
+package gatewaytest
+
+import (
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type idempotencyKeySuite struct {
+	contract
+}
+
+func (s *idempotencyKeySuite) SetupTest() {
+	s.contract.SetupTest()
+	s.require(s.gateways.IdempotencyKeys)
+}
+
+func (s *idempotencyKeySuite) TestSaveAndFindByKey() {
+	key, _ := entity.NewIdempotencyKey("key-1", "hash-1", "transaction-1")
+	s.Nil(s.gateways.IdempotencyKeys.Save(key))
+
+	retrievedKey, err := s.gateways.IdempotencyKeys.FindByKey("key-1")
+	s.Nil(err)
+	s.Equal("key-1", retrievedKey.Key)
+	s.Equal("hash-1", retrievedKey.RequestHash)
+	s.Equal("transaction-1", retrievedKey.TransactionID)
+	s.sameTime(key.CreatedAt, retrievedKey.CreatedAt)
+}
+
+func (s *idempotencyKeySuite) TestSaveTakenKey() {
+	key, _ := entity.NewIdempotencyKey("key-1", "hash-1", "transaction-1")
+	s.Nil(s.gateways.IdempotencyKeys.Save(key))
+
+	duplicate, _ := entity.NewIdempotencyKey("key-1", "hash-2", "transaction-2")
+	err := s.gateways.IdempotencyKeys.Save(duplicate)
+	s.ErrorIs(err, gateway.ErrConflict)
+	var conflict *gateway.ConflictError
+	s.ErrorAs(err, &conflict)
+	s.Equal("key-1", conflict.ID)
+
+	retrievedKey, err := s.gateways.IdempotencyKeys.FindByKey("key-1")
+	s.Nil(err)
+	s.Equal("transaction-1", retrievedKey.TransactionID)
+}
+
+func (s *idempotencyKeySuite) TestFindByKeyNotFound() {
+	key, err := s.gateways.IdempotencyKeys.FindByKey("unknown")
+	s.Nil(key)
+	s.notFound(err, "idempotency key", "unknown")
+}
@@ -0,0 +1,70 @@
+package gatewaytest
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+type ledgerSuite struct {
+	contract
+	accountFrom *entity.Account
+	accountTo   *entity.Account
+}
+
+func (s *ledgerSuite) SetupTest() {
+	s.contract.SetupTest()
+	s.require(s.gateways.Clients, s.gateways.Accounts, s.gateways.Transactions, s.gateways.Ledger)
+	s.accountFrom = s.newAccount(100_00)
+	s.accountTo = s.newAccount(0)
+}
+
+// newJournal saves a transfer of amount and returns its unsaved entries.
+func (s *ledgerSuite) newJournal(amount int64, createdAt time.Time) []*entity.LedgerEntry {
+	transaction, err := entity.NewTransaction(s.accountFrom, s.accountTo, brl(amount))
+	s.Require().Nil(err)
+	s.Require().Nil(s.gateways.Transactions.Save(transaction))
+	return []*entity.LedgerEntry{
+		entity.NewLedgerEntry(transaction.ID, s.accountFrom.ID, entity.EntryDebit, brl(amount), createdAt),
+		entity.NewLedgerEntry(transaction.ID, s.accountTo.ID, entity.EntryCredit, brl(amount), createdAt),
+	}
+}
+
+func (s *ledgerSuite) TestSaveAndFindByAccountID() {
+	now := time.Now()
+	second := s.newJournal(20_00, now)
+	first := s.newJournal(10_00, now.Add(-time.Minute))
+	s.Nil(s.gateways.Ledger.Save(second))
+	s.Nil(s.gateways.Ledger.Save(first))
+
+	entries, err := s.gateways.Ledger.FindByAccountID(s.accountTo.ID)
+	s.Nil(err)
+	s.Len(entries, 2)
+	if len(entries) == 2 {
+		s.Equal(first[1].ID, entries[0].ID)
+		s.Equal(first[1].TransactionID, entries[0].TransactionID)
+		s.Equal(s.accountTo.ID, entries[0].AccountID)
+		s.Equal(entity.EntryCredit, entries[0].Direction)
+		s.Equal(brl(10_00), entries[0].Amount)
+		s.sameTime(first[1].CreatedAt, entries[0].CreatedAt)
+		s.Equal(second[1].ID, entries[1].ID)
+	}
+
+	entries, err = s.gateways.Ledger.FindByAccountID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Len(entries, 2)
+	for _, entry := range entries {
+		s.Equal(entity.EntryDebit, entry.Direction)
+	}
+}
+
+func (s *ledgerSuite) TestSaveRejectsUnbalancedJournal() {
+	entries := s.newJournal(10_00, time.Now())
+	entries[1].Amount = brl(9_99)
+
+	s.ErrorIs(s.gateways.Ledger.Save(entries), entity.ErrUnbalancedJournal)
+
+	saved, err := s.gateways.Ledger.FindByAccountID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Empty(saved)
+}
@@ -0,0 +1,88 @@
+package gatewaytest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+type outboxSuite struct {
+	contract
+}
+
+func (s *outboxSuite) SetupTest() {
+	s.contract.SetupTest()
+	s.require(s.gateways.Outbox)
+}
+
+func (s *outboxSuite) newMessages(n int) []*entity.OutboxMessage {
+	messages := make([]*entity.OutboxMessage, n)
+	for i := range messages {
+		message, err := entity.NewOutboxMessage("BalanceUpdated", "account-1", []byte(fmt.Sprintf(`{"version":%d}`, i)))
+		s.Require().Nil(err)
+		messages[i] = message
+	}
+	return messages
+}
+
+func (s *outboxSuite) TestFindUnsentInSaveOrder() {
+	messages := s.newMessages(3)
+	// Later messages may carry earlier timestamps; the save order wins.
+	messages[2].CreatedAt = messages[0].CreatedAt.Add(-time.Hour)
+	s.Nil(s.gateways.Outbox.Save(messages[:2]))
+	s.Nil(s.gateways.Outbox.Save(messages[2:]))
+
+	unsent, err := s.gateways.Outbox.FindUnsent(10)
+	s.Nil(err)
+	s.Len(unsent, 3)
+	for i, message := range unsent {
+		s.Equal(messages[i].ID, message.ID)
+		s.Equal("BalanceUpdated", message.EventName)
+		s.Equal("account-1", message.Key)
+		s.Equal(messages[i].Payload, message.Payload)
+		s.Equal(0, message.Attempts)
+		s.Empty(message.LastError)
+		s.sameTime(messages[i].CreatedAt, message.CreatedAt)
+		s.False(message.IsSent())
+	}
+
+	unsent, err = s.gateways.Outbox.FindUnsent(2)
+	s.Nil(err)
+	s.Len(unsent, 2)
+}
+
+func (s *outboxSuite) TestMarkSent() {
+	messages := s.newMessages(3)
+	s.Nil(s.gateways.Outbox.Save(messages))
+
+	s.Nil(s.gateways.Outbox.MarkSent(messages[0].ID, time.Now()))
+
+	unsent, err := s.gateways.Outbox.FindUnsent(10)
+	s.Nil(err)
+	s.Len(unsent, 2)
+	if len(unsent) == 2 {
+		s.Equal(messages[1].ID, unsent[0].ID)
+		s.Equal(messages[2].ID, unsent[1].ID)
+	}
+
+	s.notFound(s.gateways.Outbox.MarkSent("unknown", time.Now()), "outbox message", "unknown")
+}
+
+func (s *outboxSuite) TestRecordFailure() {
+	messages := s.newMessages(1)
+	s.Nil(s.gateways.Outbox.Save(messages))
+
+	s.Nil(s.gateways.Outbox.RecordFailure(messages[0].ID, "broker unavailable"))
+	s.Nil(s.gateways.Outbox.RecordFailure(messages[0].ID, "timeout"))
+
+	unsent, err := s.gateways.Outbox.FindUnsent(10)
+	s.Nil(err)
+	s.Len(unsent, 1)
+	if len(unsent) == 1 {
+		s.Equal(2, unsent[0].Attempts)
+		s.Equal("timeout", unsent[0].LastError)
+	}
+
+	s.notFound(s.gateways.Outbox.RecordFailure("unknown", "timeout"), "outbox message", "unknown")
+}
@@ -0,0 +1,45 @@
+package gatewaytest
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+type overdraftLimitChangeSuite struct {
+	contract
+}
+
+func (s *overdraftLimitChangeSuite) SetupTest() {
+	s.contract.SetupTest()
+	s.require(s.gateways.Clients, s.gateways.Accounts, s.gateways.OverdraftLimitChanges)
+}
+
+func (s *overdraftLimitChangeSuite) TestSaveAndFindByAccountID() {
+	account := s.newAccount(0)
+	other := s.newAccount(0)
+	now := time.Now()
+	raised, _ := entity.NewOverdraftLimitChange(account.ID, brl(0), brl(500_00), "admin", "good standing")
+	raised.CreatedAt = now.Add(-time.Hour)
+	lowered, _ := entity.NewOverdraftLimitChange(account.ID, brl(500_00), brl(100_50), "risk", "")
+	lowered.CreatedAt = now
+	unrelated, _ := entity.NewOverdraftLimitChange(other.ID, brl(0), brl(10_00), "admin", "")
+	for _, change := range []*entity.OverdraftLimitChange{lowered, raised, unrelated} {
+		s.Nil(s.gateways.OverdraftLimitChanges.Save(change))
+	}
+
+	changes, err := s.gateways.OverdraftLimitChanges.FindByAccountID(account.ID)
+	s.Nil(err)
+	s.Len(changes, 2)
+	if len(changes) == 2 {
+		s.Equal(raised.ID, changes[0].ID)
+		s.Equal(account.ID, changes[0].AccountID)
+		s.True(changes[0].PreviousLimit.IsZero())
+		s.Equal(brl(500_00), changes[0].NewLimit)
+		s.Equal("admin", changes[0].ChangedBy)
+		s.Equal("good standing", changes[0].Reason)
+		s.sameTime(raised.CreatedAt, changes[0].CreatedAt)
+		s.Equal(lowered.ID, changes[1].ID)
+		s.Equal(brl(100_50), changes[1].NewLimit)
+	}
+}
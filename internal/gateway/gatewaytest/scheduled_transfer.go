@@ -0,0 +1,164 @@
+package gatewaytest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type scheduledTransferSuite struct {
+	contract
+	accountFrom *entity.Account
+	accountTo   *entity.Account
+}
+
+func (s *scheduledTransferSuite) SetupTest() {
+	s.contract.SetupTest()
+	s.require(s.gateways.Clients, s.gateways.Accounts, s.gateways.ScheduledTransfers)
+	s.accountFrom = s.newAccount(100_00)
+	s.accountTo = s.newAccount(0)
+}
+
+func (s *scheduledTransferSuite) newTransfer(runAt time.Time, recurrence string) *entity.ScheduledTransfer {
+	transfer, err := entity.NewScheduledTransfer(s.accountFrom.ID, s.accountTo.ID, brl(25_50), runAt, recurrence)
+	s.Require().Nil(err)
+	s.Require().Nil(s.gateways.ScheduledTransfers.Save(transfer))
+	return transfer
+}
+
+func (s *scheduledTransferSuite) TestSaveAndFindByID() {
+	runAt := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+	transfer := s.newTransfer(runAt, "0 9 5 * *")
+
+	retrievedTransfer, err := s.gateways.ScheduledTransfers.FindByID(transfer.ID)
+	s.Nil(err)
+	s.Equal(transfer.ID, retrievedTransfer.ID)
+	s.Equal(s.accountFrom.ID, retrievedTransfer.AccountIDFrom)
+	s.Equal(s.accountTo.ID, retrievedTransfer.AccountIDTo)
+	s.Equal(brl(25_50), retrievedTransfer.Amount)
+	s.Equal("0 9 5 * *", retrievedTransfer.Recurrence)
+	s.True(runAt.Equal(retrievedTransfer.NextRunAt))
+	s.Equal(entity.ScheduledTransferActive, retrievedTransfer.Status)
+	s.Equal(0, retrievedTransfer.ConsecutiveFailures)
+	s.sameTime(transfer.CreatedAt, retrievedTransfer.CreatedAt)
+	s.sameTime(transfer.UpdatedAt, retrievedTransfer.UpdatedAt)
+}
+
+func (s *scheduledTransferSuite) TestFindByIDNotFound() {
+	transfer, err := s.gateways.ScheduledTransfers.FindByID("unknown")
+	s.Nil(transfer)
+	s.notFound(err, "scheduled transfer", "unknown")
+}
+
+func (s *scheduledTransferSuite) TestUpdate() {
+	now := time.Now()
+	transfer := s.newTransfer(now, "")
+	transfer.RecordFailure(entity.ErrInsufficientFunds, now, 3, time.Hour)
+	s.Nil(s.gateways.ScheduledTransfers.Update(transfer))
+
+	retrievedTransfer, err := s.gateways.ScheduledTransfers.FindByID(transfer.ID)
+	s.Nil(err)
+	s.Equal(1, retrievedTransfer.ConsecutiveFailures)
+	s.sameTime(now.Add(time.Hour), retrievedTransfer.NextRunAt)
+	s.Equal(entity.ScheduledTransferActive, retrievedTransfer.Status)
+
+	s.Nil(transfer.Pause(now))
+	s.Nil(s.gateways.ScheduledTransfers.Update(transfer))
+	s.Nil(s.gateways.ScheduledTransfers.Update(transfer))
+
+	retrievedTransfer, err = s.gateways.ScheduledTransfers.FindByID(transfer.ID)
+	s.Nil(err)
+	s.Equal(entity.ScheduledTransferPaused, retrievedTransfer.Status)
+
+	unknown := *transfer
+	unknown.ID = "unknown"
+	s.notFound(s.gateways.ScheduledTransfers.Update(&unknown), "scheduled transfer", "unknown")
+}
+
+func (s *scheduledTransferSuite) TestFindDue() {
+	now := time.Now()
+	later := s.newTransfer(now.Add(2*time.Minute), "")
+	first := s.newTransfer(now.Add(time.Minute), "")
+	s.newTransfer(now.Add(time.Hour), "")
+	paused := s.newTransfer(now.Add(time.Minute), "")
+	s.Nil(paused.Pause(now))
+	s.Nil(s.gateways.ScheduledTransfers.Update(paused))
+
+	transfers, err := s.gateways.ScheduledTransfers.FindDue(now, 10)
+	s.Nil(err)
+	s.Empty(transfers)
+
+	transfers, err = s.gateways.ScheduledTransfers.FindDue(now.Add(2*time.Minute), 10)
+	s.Nil(err)
+	s.Len(transfers, 2)
+	if len(transfers) == 2 {
+		s.Equal(first.ID, transfers[0].ID)
+		s.Equal(later.ID, transfers[1].ID)
+	}
+
+	transfers, err = s.gateways.ScheduledTransfers.FindDue(now.Add(2*time.Minute), 1)
+	s.Nil(err)
+	s.Len(transfers, 1)
+}
+
+func (s *scheduledTransferSuite) TestClaim() {
+	now := time.Now()
+	transfer := s.newTransfer(now.Add(-time.Minute), "")
+	notDue := s.newTransfer(now.Add(time.Hour), "")
+
+	s.Nil(s.gateways.ScheduledTransfers.Claim(transfer.ID, now, now.Add(time.Minute)))
+	s.ErrorIs(s.gateways.ScheduledTransfers.Claim(transfer.ID, now, now.Add(time.Minute)), gateway.ErrConflict)
+	s.ErrorIs(s.gateways.ScheduledTransfers.Claim(notDue.ID, now, now.Add(time.Minute)), gateway.ErrConflict)
+	s.ErrorIs(s.gateways.ScheduledTransfers.Claim("unknown", now, now.Add(time.Minute)), gateway.ErrConflict)
+
+	transfers, err := s.gateways.ScheduledTransfers.FindDue(now, 10)
+	s.Nil(err)
+	s.Empty(transfers)
+
+	// An expired claim can be taken over.
+	later := now.Add(2 * time.Minute)
+	transfers, err = s.gateways.ScheduledTransfers.FindDue(later, 10)
+	s.Nil(err)
+	s.Len(transfers, 1)
+	s.Nil(s.gateways.ScheduledTransfers.Claim(transfer.ID, later, later.Add(time.Minute)))
+
+	// Update releases the claim.
+	s.Nil(s.gateways.ScheduledTransfers.Update(transfer))
+	transfers, err = s.gateways.ScheduledTransfers.FindDue(later, 10)
+	s.Nil(err)
+	s.Len(transfers, 1)
+
+	s.Nil(transfer.Pause(later))
+	s.Nil(s.gateways.ScheduledTransfers.Update(transfer))
+	s.ErrorIs(s.gateways.ScheduledTransfers.Claim(transfer.ID, later, later.Add(time.Minute)), gateway.ErrConflict)
+}
+
+func (s *scheduledTransferSuite) TestSaveRunAndFindRuns() {
+	now := time.Now()
+	transfer := s.newTransfer(now, "")
+	other := s.newTransfer(now, "")
+	failed := entity.NewScheduledTransferRun(transfer, "", errors.New("boom"), now.Add(-time.Minute))
+	succeeded := entity.NewScheduledTransferRun(transfer, "transaction-1", nil, now)
+	for _, run := range []*entity.ScheduledTransferRun{succeeded, failed, entity.NewScheduledTransferRun(other, "", nil, now)} {
+		s.Nil(s.gateways.ScheduledTransfers.SaveRun(run))
+	}
+
+	runs, err := s.gateways.ScheduledTransfers.FindRuns(transfer.ID)
+	s.Nil(err)
+	s.Len(runs, 2)
+	if len(runs) == 2 {
+		s.Equal(failed.ID, runs[0].ID)
+		s.Equal(transfer.ID, runs[0].ScheduledTransferID)
+		s.Equal(entity.ScheduledTransferRunFailed, runs[0].Status)
+		s.Empty(runs[0].TransactionID)
+		s.Equal("boom", runs[0].Error)
+		s.sameTime(transfer.NextRunAt, runs[0].ScheduledFor)
+		s.sameTime(failed.RanAt, runs[0].RanAt)
+		s.Equal(succeeded.ID, runs[1].ID)
+		s.Equal(entity.ScheduledTransferRunSucceeded, runs[1].Status)
+		s.Equal("transaction-1", runs[1].TransactionID)
+		s.Empty(runs[1].Error)
+	}
+}
@@ -0,0 +1,172 @@
+package gatewaytest
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+type transactionSuite struct {
+	contract
+	accountFrom *entity.Account
+	accountTo   *entity.Account
+}
+
+func (s *transactionSuite) SetupTest() {
+	s.contract.SetupTest()
+	s.require(s.gateways.Clients, s.gateways.Accounts, s.gateways.Transactions)
+	s.accountFrom = s.newAccount(1000_00)
+	s.accountTo = s.newAccount(0)
+}
+
+// newTransfer saves a transfer of amount from accountFrom to accountTo made
+// at createdAt.
+func (s *transactionSuite) newTransfer(amount int64, createdAt time.Time) *entity.Transaction {
+	transaction, err := entity.NewTransaction(s.accountFrom, s.accountTo, brl(amount))
+	s.Require().Nil(err)
+	transaction.CreatedAt = createdAt
+	s.Require().Nil(s.gateways.Transactions.Save(transaction))
+	return transaction
+}
+
+func (s *transactionSuite) TestSaveAndFindByID() {
+	transaction := s.newTransfer(40_50, time.Now())
+
+	retrievedTransaction, err := s.gateways.Transactions.FindByID(transaction.ID)
+	s.Nil(err)
+	s.Equal(transaction.ID, retrievedTransaction.ID)
+	s.Equal(entity.TransactionTransfer, retrievedTransaction.Kind)
+	s.Equal(s.accountFrom.ID, retrievedTransaction.AccountFrom.ID)
+	s.Equal(s.accountTo.ID, retrievedTransaction.AccountTo.ID)
+	s.Equal(brl(40_50), retrievedTransaction.Amount)
+	s.Empty(retrievedTransaction.ReversalOf)
+	s.Empty(retrievedTransaction.FeeOf)
+	s.sameTime(transaction.CreatedAt, retrievedTransaction.CreatedAt)
+}
+
+func (s *transactionSuite) TestFindByIDNotFound() {
+	transaction, err := s.gateways.Transactions.FindByID("unknown")
+	s.Nil(transaction)
+	s.notFound(err, "transaction", "unknown")
+}
+
+func (s *transactionSuite) TestFindByIDForUpdate() {
+	transaction := s.newTransfer(40_50, time.Now())
+
+	retrievedTransaction, err := s.gateways.Transactions.FindByIDForUpdate(transaction.ID)
+	s.Nil(err)
+	s.Equal(transaction.ID, retrievedTransaction.ID)
+	s.Equal(s.accountFrom.ID, retrievedTransaction.AccountFrom.ID)
+	s.Equal(brl(40_50), retrievedTransaction.Amount)
+
+	retrievedTransaction, err = s.gateways.Transactions.FindByIDForUpdate("unknown")
+	s.Nil(retrievedTransaction)
+	s.notFound(err, "transaction", "unknown")
+}
+
+func (s *transactionSuite) TestFindByAccountID() {
+	now := time.Now()
+	second := s.newTransfer(20_00, now)
+	first := s.newTransfer(10_00, now.Add(-time.Minute))
+	back, err := entity.NewTransaction(s.accountTo, s.accountFrom, brl(5_00))
+	s.Nil(err)
+	back.CreatedAt = now.Add(time.Minute)
+	s.Nil(s.gateways.Transactions.Save(back))
+	other := s.newAccount(0)
+
+	for _, accountID := range []string{s.accountFrom.ID, s.accountTo.ID} {
+		transactions, err := s.gateways.Transactions.FindByAccountID(accountID)
+		s.Nil(err)
+		s.Len(transactions, 3)
+		if len(transactions) == 3 {
+			s.Equal(first.ID, transactions[0].ID)
+			s.Equal(second.ID, transactions[1].ID)
+			s.Equal(back.ID, transactions[2].ID)
+		}
+	}
+
+	transactions, err := s.gateways.Transactions.FindByAccountID(other.ID)
+	s.Nil(err)
+	s.Empty(transactions)
+}
+
+func (s *transactionSuite) TestFindReversalsAndFees() {
+	now := time.Now()
+	original := s.newTransfer(100_00, now.Add(-time.Hour))
+	revenue := s.newAccount(0)
+	fee, err := entity.NewFee(original, revenue, brl(2_00))
+	s.Nil(err)
+	s.Nil(s.gateways.Transactions.Save(fee))
+
+	first, err := entity.NewReversal(original, s.accountTo, s.accountFrom, brl(30_00), nil)
+	s.Nil(err)
+	first.CreatedAt = now.Add(-time.Minute)
+	s.Nil(s.gateways.Transactions.Save(first))
+	second, err := entity.NewReversal(original, s.accountTo, s.accountFrom, brl(70_00), []*entity.Transaction{first})
+	s.Nil(err)
+	second.CreatedAt = now
+	s.Nil(s.gateways.Transactions.Save(second))
+
+	reversals, err := s.gateways.Transactions.FindReversals(original.ID)
+	s.Nil(err)
+	s.Len(reversals, 2)
+	if len(reversals) == 2 {
+		s.Equal(first.ID, reversals[0].ID)
+		s.Equal(original.ID, reversals[0].ReversalOf)
+		s.Equal(second.ID, reversals[1].ID)
+	}
+
+	fees, err := s.gateways.Transactions.FindFees(original.ID)
+	s.Nil(err)
+	s.Len(fees, 1)
+	if len(fees) == 1 {
+		s.Equal(fee.ID, fees[0].ID)
+		s.Equal(original.ID, fees[0].FeeOf)
+		s.Equal(revenue.ID, fees[0].AccountTo.ID)
+	}
+
+	reversals, err = s.gateways.Transactions.FindReversals(second.ID)
+	s.Nil(err)
+	s.Empty(reversals)
+}
+
+func (s *transactionSuite) TestOutgoingVolume() {
+	now := time.Now()
+	since := now.Add(-24 * time.Hour)
+	s.newTransfer(500_00, since.Add(-time.Minute))
+	counted := s.newTransfer(10_10, since.Add(time.Minute))
+	s.newTransfer(20_20, now)
+	revenue := s.newAccount(0)
+	fee, err := entity.NewFee(counted, revenue, brl(1_00))
+	s.Nil(err)
+	s.Nil(s.gateways.Transactions.Save(fee))
+	reversal, err := entity.NewReversal(counted, s.accountTo, s.accountFrom, brl(10_10), nil)
+	s.Nil(err)
+	s.Nil(s.gateways.Transactions.Save(reversal))
+	withdrawal, err := entity.NewWithdrawal(s.accountFrom, revenue, brl(3_00))
+	s.Nil(err)
+	s.Nil(s.gateways.Transactions.Save(withdrawal))
+
+	sibling := entity.NewAccount(s.accountFrom.Client)
+	s.Nil(sibling.Credit(brl(100_00)))
+	s.Nil(s.gateways.Accounts.Save(sibling))
+	transaction, err := entity.NewTransaction(sibling, s.accountTo, brl(5_05))
+	s.Nil(err)
+	s.Nil(s.gateways.Transactions.Save(transaction))
+
+	volume, err := s.gateways.Transactions.OutgoingVolumeByAccount(s.accountFrom.ID, entity.DefaultCurrency, since)
+	s.Nil(err)
+	s.Equal(brl(30_30), volume.Total)
+	s.Equal(2, volume.Count)
+
+	volume, err = s.gateways.Transactions.OutgoingVolumeByClient(s.accountFrom.Client.ID, entity.DefaultCurrency, since)
+	s.Nil(err)
+	s.Equal(brl(35_35), volume.Total)
+	s.Equal(3, volume.Count)
+
+	volume, err = s.gateways.Transactions.OutgoingVolumeByAccount(s.accountTo.ID, entity.DefaultCurrency, since)
+	s.Nil(err)
+	s.True(volume.Total.IsZero())
+	s.Equal(entity.DefaultCurrency, volume.Total.Currency())
+	s.Equal(0, volume.Count)
+}
@@ -0,0 +1,19 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+const HoldRepository = "HoldDB"
+
+// HoldGateway persists holds. Update only applies to a hold that is still
+// active in storage and returns a *ConflictError otherwise, so a hold cannot
+// be settled twice.
+type HoldGateway interface {
+	Save(hold *entity.Hold) error
+	FindByID(id string) (*entity.Hold, error)
+	Update(hold *entity.Hold) error
+	FindExpired(now time.Time) ([]*entity.Hold, error)
+}
@@ -0,0 +1,12 @@
+package gateway
+
+import "github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+
+const IdempotencyKeyRepository = "IdempotencyKeyDB"
+
+// IdempotencyKeyGateway stores idempotency keys. Save returns a
+// *ConflictError when the key is already taken.
+type IdempotencyKeyGateway interface {
+	Save(key *entity.IdempotencyKey) error
+	FindByKey(key string) (*entity.IdempotencyKey, error)
+}
@@ -0,0 +1,10 @@
+package gateway
+
+import "github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+
+const LedgerRepository = "LedgerDB"
+
+type LedgerGateway interface {
+	Save(entries []*entity.LedgerEntry) error
+	FindByAccountID(accountID string) ([]*entity.LedgerEntry, error)
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// accountRow is a stored account. Its client is kept apart and joined back when
+// the account is read.
+type accountRow struct {
+	entity.Account
+	clientID string
+}
+
+type AccountGateway struct {
+	Store *Store
+}
+
+func NewAccountGateway(store *Store) *AccountGateway {
+	return &AccountGateway{
+		Store: store,
+	}
+}
+
+// FindByID loads an account with its client.
+func (a *AccountGateway) FindByID(id string) (*entity.Account, error) {
+	a.Store.mu.Lock()
+	defer a.Store.mu.Unlock()
+	stored, ok := a.Store.data.accounts[id]
+	if !ok {
+		return nil, &gateway.NotFoundError{Entity: "account", ID: id}
+	}
+	client := a.Store.data.clients[stored.clientID]
+	found := stored.Account
+	found.Client = &client
+	return &found, nil
+}
+
+// FindByIDForUpdate loads an account like FindByID. Units of work on a store
+// already run one at a time, so there is nothing left to lock.
+func (a *AccountGateway) FindByIDForUpdate(id string) (*entity.Account, error) {
+	return a.FindByID(id)
+}
+
+// Save stores a new account of a client already saved.
+func (a *AccountGateway) Save(account *entity.Account) error {
+	defer a.Store.write()()
+	if _, ok := a.Store.data.accounts[account.ID]; ok {
+		return duplicate("account", account.ID)
+	}
+	if _, ok := a.Store.data.clients[account.Client.ID]; !ok {
+		return &gateway.NotFoundError{Entity: "client", ID: account.Client.ID}
+	}
+	a.Store.data.accounts[account.ID] = newAccountRow(account)
+	return nil
+}
+
+// UpdateBalance writes the account balance and held amount only if the stored
+// version still matches account.Version, returning a *gateway.ConflictError
+// otherwise.
+func (a *AccountGateway) UpdateBalance(account *entity.Account) error {
+	return a.update(account, func(stored *entity.Account) {
+		stored.Balance = account.Balance
+		stored.HeldAmount = account.HeldAmount
+	})
+}
+
+// UpdateStatus writes the account status with the same version check as
+// UpdateBalance.
+func (a *AccountGateway) UpdateStatus(account *entity.Account) error {
+	return a.update(account, func(stored *entity.Account) {
+		stored.Status = account.Status
+	})
+}
+
+// UpdateOverdraftLimit writes the account overdraft limit with the same
+// version check as UpdateBalance.
+func (a *AccountGateway) UpdateOverdraftLimit(account *entity.Account) error {
+	return a.update(account, func(stored *entity.Account) {
+		stored.OverdraftLimit = account.OverdraftLimit
+	})
+}
+
+func (a *AccountGateway) update(account *entity.Account, set func(stored *entity.Account)) error {
+	defer a.Store.write()()
+	stored, ok := a.Store.data.accounts[account.ID]
+	if !ok || stored.Version != account.Version {
+		return &gateway.ConflictError{Entity: "account", ID: account.ID}
+	}
+	set(&stored.Account)
+	stored.Version++
+	a.Store.data.accounts[account.ID] = stored
+	account.Version++
+	return nil
+}
+
+func newAccountRow(a *entity.Account) accountRow {
+	stored := accountRow{Account: *a, clientID: a.Client.ID}
+	stored.Client = nil
+	return stored
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type BalanceGateway struct {
+	Store *Store
+}
+
+func NewBalanceGateway(store *Store) *BalanceGateway {
+	return &BalanceGateway{
+		Store: store,
+	}
+}
+
+// ApplyBalance stores the balance unless the stored one has the same or a
+// later version.
+func (b *BalanceGateway) ApplyBalance(balance *entity.AccountBalance) error {
+	defer b.Store.write()()
+	stored, ok := b.Store.data.balances[balance.AccountID]
+	if !ok {
+		b.Store.data.balances[balance.AccountID] = entity.AccountBalance{
+			AccountID:  balance.AccountID,
+			Balance:    balance.Balance,
+			HeldAmount: balance.HeldAmount,
+			Version:    balance.Version,
+			UpdatedAt:  balance.UpdatedAt,
+		}
+		return nil
+	}
+	if stored.Version >= balance.Version {
+		return nil
+	}
+	stored.Balance = balance.Balance
+	stored.HeldAmount = balance.HeldAmount
+	stored.Version = balance.Version
+	stored.UpdatedAt = balance.UpdatedAt
+	b.Store.data.balances[balance.AccountID] = stored
+	return nil
+}
+
+// ApplyTransaction records the transaction as the last one of the account
+// unless a later one is already recorded.
+func (b *BalanceGateway) ApplyTransaction(accountID, currency, transactionID string, at time.Time) error {
+	defer b.Store.write()()
+	stored, ok := b.Store.data.balances[accountID]
+	if !ok {
+		stored = entity.AccountBalance{
+			AccountID:  accountID,
+			Balance:    entity.Zero(currency),
+			HeldAmount: entity.Zero(currency),
+			Version:    entity.UnprojectedVersion,
+			UpdatedAt:  at,
+		}
+	} else if !stored.LastTransactionAt.IsZero() && !stored.LastTransactionAt.Before(at) {
+		return nil
+	}
+	stored.LastTransactionID = transactionID
+	stored.LastTransactionAt = at
+	b.Store.data.balances[accountID] = stored
+	return nil
+}
+
+func (b *BalanceGateway) FindByAccountID(accountID string) (*entity.AccountBalance, error) {
+	b.Store.mu.Lock()
+	defer b.Store.mu.Unlock()
+	balance, ok := b.Store.data.balances[accountID]
+	if !ok {
+		return nil, &gateway.NotFoundError{Entity: "balance", ID: accountID}
+	}
+	return &balance, nil
+}
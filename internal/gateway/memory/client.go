@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type ClientGateway struct {
+	Store *Store
+}
+
+func NewClientGateway(store *Store) *ClientGateway {
+	return &ClientGateway{
+		Store: store,
+	}
+}
+
+// Get returns the client without its accounts, like the SQL gateway.
+func (c *ClientGateway) Get(id string) (*entity.Client, error) {
+	c.Store.mu.Lock()
+	defer c.Store.mu.Unlock()
+	client, ok := c.Store.data.clients[id]
+	if !ok {
+		return nil, &gateway.NotFoundError{Entity: "client", ID: id}
+	}
+	return &client, nil
+}
+
+// GetForUpdate returns the client like Get. Units of work on a store already
+// run one at a time, so there is nothing left to lock.
+func (c *ClientGateway) GetForUpdate(id string) (*entity.Client, error) {
+	return c.Get(id)
+}
+
+func (c *ClientGateway) Save(client *entity.Client) error {
+	defer c.Store.write()()
+	if _, ok := c.Store.data.clients[client.ID]; ok {
+		return duplicate("client", client.ID)
+	}
+	stored := *client
+	stored.Accounts = nil
+	c.Store.data.clients[client.ID] = stored
+	return nil
+}
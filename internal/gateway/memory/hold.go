@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"slices"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type HoldGateway struct {
+	Store *Store
+}
+
+func NewHoldGateway(store *Store) *HoldGateway {
+	return &HoldGateway{
+		Store: store,
+	}
+}
+
+func (h *HoldGateway) Save(hold *entity.Hold) error {
+	defer h.Store.write()()
+	if _, ok := h.Store.data.holds[hold.ID]; ok {
+		return duplicate("hold", hold.ID)
+	}
+	h.Store.data.holds[hold.ID] = *hold
+	return nil
+}
+
+func (h *HoldGateway) FindByID(id string) (*entity.Hold, error) {
+	h.Store.mu.Lock()
+	defer h.Store.mu.Unlock()
+	hold, ok := h.Store.data.holds[id]
+	if !ok {
+		return nil, &gateway.NotFoundError{Entity: "hold", ID: id}
+	}
+	return &hold, nil
+}
+
+// Update writes the settlement of a hold only if it is still active in the
+// store, returning a *gateway.ConflictError otherwise.
+func (h *HoldGateway) Update(hold *entity.Hold) error {
+	defer h.Store.write()()
+	stored, ok := h.Store.data.holds[hold.ID]
+	if !ok || stored.Status != entity.HoldActive {
+		return &gateway.ConflictError{Entity: "hold", ID: hold.ID}
+	}
+	stored.Status = hold.Status
+	stored.CapturedAmount = hold.CapturedAmount
+	stored.TransactionID = hold.TransactionID
+	stored.UpdatedAt = hold.UpdatedAt
+	h.Store.data.holds[hold.ID] = stored
+	return nil
+}
+
+// FindExpired returns the active holds whose expiry is not after now, oldest
+// expiry first.
+func (h *HoldGateway) FindExpired(now time.Time) ([]*entity.Hold, error) {
+	h.Store.mu.Lock()
+	defer h.Store.mu.Unlock()
+	holds := []*entity.Hold{}
+	for _, hold := range h.Store.data.holds {
+		if hold.Status == entity.HoldActive && !hold.ExpiresAt.After(now) {
+			holds = append(holds, &hold)
+		}
+	}
+	slices.SortFunc(holds, func(a, b *entity.Hold) int {
+		return chronological(a.ExpiresAt, a.ID, b.ExpiresAt, b.ID)
+	})
+	return holds, nil
+}
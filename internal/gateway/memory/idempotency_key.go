@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type IdempotencyKeyGateway struct {
+	Store *Store
+}
+
+func NewIdempotencyKeyGateway(store *Store) *IdempotencyKeyGateway {
+	return &IdempotencyKeyGateway{
+		Store: store,
+	}
+}
+
+// Save reports a key that is already stored as a *gateway.ConflictError.
+func (i *IdempotencyKeyGateway) Save(key *entity.IdempotencyKey) error {
+	defer i.Store.write()()
+	if _, ok := i.Store.data.idempotencyKeys[key.Key]; ok {
+		return &gateway.ConflictError{Entity: "idempotency key", ID: key.Key}
+	}
+	i.Store.data.idempotencyKeys[key.Key] = *key
+	return nil
+}
+
+func (i *IdempotencyKeyGateway) FindByKey(key string) (*entity.IdempotencyKey, error) {
+	i.Store.mu.Lock()
+	defer i.Store.mu.Unlock()
+	idempotencyKey, ok := i.Store.data.idempotencyKeys[key]
+	if !ok {
+		return nil, &gateway.NotFoundError{Entity: "idempotency key", ID: key}
+	}
+	return &idempotencyKey, nil
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"slices"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+type LedgerGateway struct {
+	Store *Store
+}
+
+func NewLedgerGateway(store *Store) *LedgerGateway {
+	return &LedgerGateway{
+		Store: store,
+	}
+}
+
+// Save stores a balanced journal. Unbalanced entries, or entries whose IDs
+// are taken, are rejected before anything is written.
+func (l *LedgerGateway) Save(entries []*entity.LedgerEntry) error {
+	if err := entity.ValidateJournal(entries); err != nil {
+		return err
+	}
+	defer l.Store.write()()
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if _, ok := l.Store.data.ledgerEntries[entry.ID]; ok || seen[entry.ID] {
+			return duplicate("ledger entry", entry.ID)
+		}
+		seen[entry.ID] = true
+	}
+	for _, entry := range entries {
+		l.Store.data.ledgerEntries[entry.ID] = *entry
+	}
+	return nil
+}
+
+// FindByAccountID returns the entries of an account, oldest first.
+func (l *LedgerGateway) FindByAccountID(accountID string) ([]*entity.LedgerEntry, error) {
+	l.Store.mu.Lock()
+	defer l.Store.mu.Unlock()
+	entries := []*entity.LedgerEntry{}
+	for _, entry := range l.Store.data.ledgerEntries {
+		if entry.AccountID == accountID {
+			entries = append(entries, &entry)
+		}
+	}
+	slices.SortFunc(entries, func(a, b *entity.LedgerEntry) int {
+		return chronological(a.CreatedAt, a.ID, b.CreatedAt, b.ID)
+	})
+	return entries, nil
+}
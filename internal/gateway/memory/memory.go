@@ -0,0 +1,211 @@
+// Package memory implements every gateway in process memory, for running the
+// wallet without a database and for use-case tests. The gateways of a Store
+// share its data and behave like the SQL ones: they report missing entities
+// with *gateway.NotFoundError, reject duplicate IDs, and hand out copies, so
+// changing an entity has no effect until it is written back.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+)
+
+// ErrDuplicateKey is returned when saving an entity whose ID is already
+// taken.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// Store holds the data of the gateways. It is safe for concurrent use.
+type Store struct {
+	*state
+	// inWork is set on the store handed to the gateways of a unit of work,
+	// which already holds work.
+	inWork bool
+}
+
+type state struct {
+	mu   sync.Mutex
+	data data
+	// work serializes units of work, and writes made outside of one, as a
+	// database serializes writers.
+	work sync.Mutex
+	// waiting, when set, is called by a write made outside a unit of work
+	// that is about to wait for the running one, so tests can tell it is
+	// blocked.
+	waiting func()
+}
+
+type data struct {
+	clients               map[string]entity.Client
+	accounts              map[string]accountRow
+	transactions          map[string]transactionRow
+	ledgerEntries         map[string]entity.LedgerEntry
+	idempotencyKeys       map[string]entity.IdempotencyKey
+	overdraftLimitChanges map[string]entity.OverdraftLimitChange
+	holds                 map[string]entity.Hold
+	scheduledTransfers    map[string]scheduledTransferRow
+	scheduledTransferRuns map[string]entity.ScheduledTransferRun
+	outbox                map[string]outboxRow
+	outboxSequence        int
+	balances              map[string]entity.AccountBalance
+}
+
+func NewStore() *Store {
+	return &Store{state: &state{
+		data: data{
+			clients:               make(map[string]entity.Client),
+			accounts:              make(map[string]accountRow),
+			transactions:          make(map[string]transactionRow),
+			ledgerEntries:         make(map[string]entity.LedgerEntry),
+			idempotencyKeys:       make(map[string]entity.IdempotencyKey),
+			overdraftLimitChanges: make(map[string]entity.OverdraftLimitChange),
+			holds:                 make(map[string]entity.Hold),
+			scheduledTransfers:    make(map[string]scheduledTransferRow),
+			scheduledTransferRuns: make(map[string]entity.ScheduledTransferRun),
+			outbox:                make(map[string]outboxRow),
+			balances:              make(map[string]entity.AccountBalance),
+		},
+	}}
+}
+
+// write locks the store for a write and returns the function unlocking it.
+// Writes made outside a unit of work wait for the running one to end, so
+// rolling it back cannot undo them.
+func (s *Store) write() (unlock func()) {
+	if !s.inWork && !s.work.TryLock() {
+		if s.waiting != nil {
+			s.waiting()
+		}
+		s.work.Lock()
+	}
+	s.mu.Lock()
+	return func() {
+		s.mu.Unlock()
+		if !s.inWork {
+			s.work.Unlock()
+		}
+	}
+}
+
+// snapshot copies the data. Stored values are never modified in place, so
+// copying the maps is enough.
+func (s *Store) snapshot() data {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := s.data
+	snapshot.clients = maps.Clone(s.data.clients)
+	snapshot.accounts = maps.Clone(s.data.accounts)
+	snapshot.transactions = maps.Clone(s.data.transactions)
+	snapshot.ledgerEntries = maps.Clone(s.data.ledgerEntries)
+	snapshot.idempotencyKeys = maps.Clone(s.data.idempotencyKeys)
+	snapshot.overdraftLimitChanges = maps.Clone(s.data.overdraftLimitChanges)
+	snapshot.holds = maps.Clone(s.data.holds)
+	snapshot.scheduledTransfers = maps.Clone(s.data.scheduledTransfers)
+	snapshot.scheduledTransferRuns = maps.Clone(s.data.scheduledTransferRuns)
+	snapshot.outbox = maps.Clone(s.data.outbox)
+	snapshot.balances = maps.Clone(s.data.balances)
+	return snapshot
+}
+
+func (s *Store) restore(snapshot data) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = snapshot
+}
+
+// repository returns the gateway registered under name in a unit of work.
+func (s *Store) repository(name string) (interface{}, error) {
+	switch name {
+	case gateway.ClientRepository:
+		return NewClientGateway(s), nil
+	case gateway.AccountRepository:
+		return NewAccountGateway(s), nil
+	case gateway.TransactionRepository:
+		return NewTransactionGateway(s), nil
+	case gateway.LedgerRepository:
+		return NewLedgerGateway(s), nil
+	case gateway.IdempotencyKeyRepository:
+		return NewIdempotencyKeyGateway(s), nil
+	case gateway.OverdraftLimitChangeRepository:
+		return NewOverdraftLimitChangeGateway(s), nil
+	case gateway.HoldRepository:
+		return NewHoldGateway(s), nil
+	case gateway.ScheduledTransferRepository:
+		return NewScheduledTransferGateway(s), nil
+	case gateway.OutboxRepository:
+		return NewOutboxGateway(s), nil
+	case gateway.BalanceRepository:
+		return NewBalanceGateway(s), nil
+	}
+	return nil, fmt.Errorf("%w: %s", uow.ErrRepositoryNotFound, name)
+}
+
+// Uow is a uow.UnitOfWork over a Store, with every gateway registered under
+// its usual name. Units of work run one at a time; when one fails, the store
+// is put back as it was when the unit of work started. Gateways used outside
+// a unit of work see its changes before it ends, and their writes wait for it
+// to end.
+type Uow struct {
+	store *Store
+}
+
+func NewUow(store *Store) *Uow {
+	return &Uow{store: store}
+}
+
+func (u *Uow) GetRepository(ctx context.Context, name string) (interface{}, error) {
+	return nil, uow.ErrNoTransaction
+}
+
+func (u *Uow) Do(ctx context.Context, fn func(uow uow.UnitOfWork) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	u.store.work.Lock()
+	defer u.store.work.Unlock()
+
+	snapshot := u.store.snapshot()
+	defer func() {
+		if p := recover(); p != nil {
+			u.store.restore(snapshot)
+			panic(p)
+		}
+	}()
+
+	if err := fn(&txUow{store: &Store{state: u.store.state, inWork: true}}); err != nil {
+		u.store.restore(snapshot)
+		return err
+	}
+	return nil
+}
+
+// txUow is the UnitOfWork handed to the function given to Uow.Do.
+type txUow struct {
+	store *Store
+}
+
+func (u *txUow) GetRepository(ctx context.Context, name string) (interface{}, error) {
+	return u.store.repository(name)
+}
+
+func (u *txUow) Do(ctx context.Context, fn func(uow uow.UnitOfWork) error) error {
+	return fn(u)
+}
+
+func duplicate(entity, id string) error {
+	return fmt.Errorf("%w: %s %s", ErrDuplicateKey, entity, id)
+}
+
+// chronological orders by time, then by ID, as the SQL gateways do.
+func chronological(a time.Time, aID string, b time.Time, bID string) int {
+	return cmp.Or(a.Compare(b), strings.Compare(aID, bID))
+}
@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/gatewaytest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestGateways(t *testing.T) {
+	gatewaytest.Run(t, func(t *testing.T) gatewaytest.Gateways {
+		store := NewStore()
+		return gatewaytest.Gateways{
+			Clients:               NewClientGateway(store),
+			Accounts:              NewAccountGateway(store),
+			Transactions:          NewTransactionGateway(store),
+			Ledger:                NewLedgerGateway(store),
+			IdempotencyKeys:       NewIdempotencyKeyGateway(store),
+			OverdraftLimitChanges: NewOverdraftLimitChangeGateway(store),
+			Holds:                 NewHoldGateway(store),
+			ScheduledTransfers:    NewScheduledTransferGateway(store),
+			Outbox:                NewOutboxGateway(store),
+			Balances:              NewBalanceGateway(store),
+		}
+	})
+}
+
+type StoreTestSuite struct {
+	suite.Suite
+	store    *Store
+	clients  *ClientGateway
+	accounts *AccountGateway
+	client   *entity.Client
+	account  *entity.Account
+}
+
+func (s *StoreTestSuite) SetupTest() {
+	s.store = NewStore()
+	s.clients = NewClientGateway(s.store)
+	s.accounts = NewAccountGateway(s.store)
+	s.client, _ = entity.NewClient("John Doe", "john@example.com")
+	s.Nil(s.clients.Save(s.client))
+	s.account = entity.NewAccount(s.client)
+	s.Nil(s.accounts.Save(s.account))
+}
+
+func TestStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(StoreTestSuite))
+}
+
+func (s *StoreTestSuite) TestSaveRejectsDuplicateIDs() {
+	s.ErrorIs(s.clients.Save(s.client), ErrDuplicateKey)
+	s.ErrorIs(s.accounts.Save(s.account), ErrDuplicateKey)
+}
+
+func (s *StoreTestSuite) TestSaveAccountNeedsClient() {
+	other, _ := entity.NewClient("Jane Doe", "jane@example.com")
+	err := s.accounts.Save(entity.NewAccount(other))
+	s.ErrorIs(err, gateway.ErrNotFound)
+}
+
+func (s *StoreTestSuite) TestReturnsCopies() {
+	s.Nil(s.account.Credit(entity.NewMoney(10_00, entity.DefaultCurrency)))
+	found, err := s.accounts.FindByID(s.account.ID)
+	s.Nil(err)
+	s.True(found.Balance.IsZero())
+
+	found.Client.Name = "Changed"
+	s.Nil(found.Credit(entity.NewMoney(20_00, entity.DefaultCurrency)))
+	again, err := s.accounts.FindByID(s.account.ID)
+	s.Nil(err)
+	s.True(again.Balance.IsZero())
+	s.Equal("John Doe", again.Client.Name)
+}
+
+func (s *StoreTestSuite) TestUpdateChecksVersion() {
+	first, _ := s.accounts.FindByID(s.account.ID)
+	second, _ := s.accounts.FindByID(s.account.ID)
+	s.Nil(first.Credit(entity.NewMoney(10_00, entity.DefaultCurrency)))
+	s.Nil(s.accounts.UpdateBalance(first))
+	s.Equal(1, first.Version)
+
+	s.Nil(second.Credit(entity.NewMoney(20_00, entity.DefaultCurrency)))
+	s.ErrorIs(s.accounts.UpdateBalance(second), gateway.ErrConflict)
+
+	found, _ := s.accounts.FindByID(s.account.ID)
+	s.Equal(entity.NewMoney(10_00, entity.DefaultCurrency), found.Balance)
+}
+
+func (s *StoreTestSuite) TestUowCommits() {
+	ctx := context.Background()
+	err := NewUow(s.store).Do(ctx, func(u uow.UnitOfWork) error {
+		accounts, err := uow.GetRepository[gateway.AccountGateway](ctx, u, gateway.AccountRepository)
+		if err != nil {
+			return err
+		}
+		account, err := accounts.FindByID(s.account.ID)
+		if err != nil {
+			return err
+		}
+		if err := account.Credit(entity.NewMoney(10_00, entity.DefaultCurrency)); err != nil {
+			return err
+		}
+		return accounts.UpdateBalance(account)
+	})
+	s.Nil(err)
+
+	found, _ := s.accounts.FindByID(s.account.ID)
+	s.Equal(entity.NewMoney(10_00, entity.DefaultCurrency), found.Balance)
+}
+
+func (s *StoreTestSuite) TestUowRollsBackOnError() {
+	ctx := context.Background()
+	failure := errors.New("failure")
+	other, _ := entity.NewClient("Jane Doe", "jane@example.com")
+	err := NewUow(s.store).Do(ctx, func(u uow.UnitOfWork) error {
+		clients, err := uow.GetRepository[gateway.ClientGateway](ctx, u, gateway.ClientRepository)
+		if err != nil {
+			return err
+		}
+		if err := clients.Save(other); err != nil {
+			return err
+		}
+		return failure
+	})
+	s.ErrorIs(err, failure)
+
+	_, err = s.clients.Get(other.ID)
+	s.ErrorIs(err, gateway.ErrNotFound)
+}
+
+func (s *StoreTestSuite) TestUowRollsBackOnPanic() {
+	ctx := context.Background()
+	other, _ := entity.NewClient("Jane Doe", "jane@example.com")
+	s.Panics(func() {
+		NewUow(s.store).Do(ctx, func(u uow.UnitOfWork) error {
+			clients, _ := uow.GetRepository[gateway.ClientGateway](ctx, u, gateway.ClientRepository)
+			clients.Save(other)
+			panic("boom")
+		})
+	})
+
+	_, err := s.clients.Get(other.ID)
+	s.ErrorIs(err, gateway.ErrNotFound)
+}
+
+func (s *StoreTestSuite) TestUowRollbackKeepsWritesMadeOutsideIt() {
+	ctx := context.Background()
+	failure := errors.New("failure")
+	other, _ := entity.NewClient("Jane Doe", "jane@example.com")
+	saved := make(chan error)
+	waiting := make(chan struct{})
+	s.store.waiting = func() { close(waiting) }
+	err := NewUow(s.store).Do(ctx, func(u uow.UnitOfWork) error {
+		go func() { saved <- s.clients.Save(other) }()
+		// The save is blocked on the unit of work, so it lands after the
+		// rollback.
+		<-waiting
+		return failure
+	})
+	s.ErrorIs(err, failure)
+	s.Nil(<-saved)
+
+	_, err = s.clients.Get(other.ID)
+	s.Nil(err)
+}
+
+func (s *StoreTestSuite) TestUowRepositories() {
+	ctx := context.Background()
+	u := NewUow(s.store)
+	_, err := u.GetRepository(ctx, gateway.AccountRepository)
+	s.ErrorIs(err, uow.ErrNoTransaction)
+
+	err = u.Do(ctx, func(u uow.UnitOfWork) error {
+		for _, name := range []string{
+			gateway.ClientRepository, gateway.AccountRepository, gateway.TransactionRepository, gateway.LedgerRepository,
+			gateway.IdempotencyKeyRepository, gateway.OverdraftLimitChangeRepository, gateway.HoldRepository,
+			gateway.ScheduledTransferRepository, gateway.OutboxRepository, gateway.BalanceRepository,
+		} {
+			if _, err := u.GetRepository(ctx, name); err != nil {
+				return err
+			}
+		}
+		_, err := u.GetRepository(ctx, "UnknownDB")
+		return err
+	})
+	s.ErrorIs(err, uow.ErrRepositoryNotFound)
+}
@@ -0,0 +1,193 @@
+// Package memorytest provides data and failing repositories over a memory
+// store for use-case tests.
+package memorytest
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+)
+
+var clients atomic.Int64
+
+// NewStore returns a store holding two accounts, each of its own client,
+// with balances of first and second minor units of the default currency.
+func NewStore(t testing.TB, first, second int64) (store *memory.Store, firstAccount, secondAccount *entity.Account) {
+	t.Helper()
+	store = memory.NewStore()
+	return store, NewAccount(t, store, "John Doe", first), NewAccount(t, store, "Jane Doe", second)
+}
+
+// NewClient saves a new client named name, with an email address of their
+// own.
+func NewClient(t testing.TB, store *memory.Store, name string) *entity.Client {
+	t.Helper()
+	client, err := entity.NewClient(name, fmt.Sprintf("client-%d@example.com", clients.Add(1)))
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	if err := memory.NewClientGateway(store).Save(client); err != nil {
+		t.Fatalf("saving client: %v", err)
+	}
+	return client
+}
+
+// NewAccount saves a new client named name and an account of theirs holding
+// balance minor units of the default currency.
+func NewAccount(t testing.TB, store *memory.Store, name string, balance int64) *entity.Account {
+	t.Helper()
+	account := entity.NewAccount(NewClient(t, store, name))
+	if balance != 0 {
+		if err := account.Credit(entity.NewMoney(balance, entity.DefaultCurrency)); err != nil {
+			t.Fatalf("crediting account: %v", err)
+		}
+	}
+	if err := memory.NewAccountGateway(store).Save(account); err != nil {
+		t.Fatalf("saving account: %v", err)
+	}
+	return account
+}
+
+// FindAccount reads back the account with the given ID.
+func FindAccount(t testing.TB, store *memory.Store, id string) *entity.Account {
+	t.Helper()
+	account, err := memory.NewAccountGateway(store).FindByID(id)
+	if err != nil {
+		t.Fatalf("finding account %s: %v", id, err)
+	}
+	return account
+}
+
+// Transfer saves a transfer of amount minor units from one account to
+// another, along with the balances it leaves them with.
+func Transfer(t testing.TB, store *memory.Store, from, to *entity.Account, amount int64) *entity.Transaction {
+	t.Helper()
+	transaction, err := entity.NewTransaction(from, to, entity.NewMoney(amount, entity.DefaultCurrency))
+	if err != nil {
+		t.Fatalf("creating transfer: %v", err)
+	}
+	Post(t, store, transaction)
+	return transaction
+}
+
+// Post saves transaction and the balances it left its accounts with.
+func Post(t testing.TB, store *memory.Store, transaction *entity.Transaction) {
+	t.Helper()
+	for _, account := range []*entity.Account{transaction.AccountFrom, transaction.AccountTo} {
+		if err := memory.NewAccountGateway(store).UpdateBalance(account); err != nil {
+			t.Fatalf("saving account: %v", err)
+		}
+	}
+	if err := memory.NewTransactionGateway(store).Save(transaction); err != nil {
+		t.Fatalf("saving transaction: %v", err)
+	}
+}
+
+// PlaceHold holds amount minor units on the account with the given ID until
+// expiresAt.
+func PlaceHold(t testing.TB, store *memory.Store, accountID string, amount int64, expiresAt time.Time) *entity.Hold {
+	t.Helper()
+	account := FindAccount(t, store, accountID)
+	hold, err := entity.NewHold(account, entity.NewMoney(amount, entity.DefaultCurrency), expiresAt)
+	if err != nil {
+		t.Fatalf("placing hold: %v", err)
+	}
+	if err := memory.NewAccountGateway(store).UpdateBalance(account); err != nil {
+		t.Fatalf("saving account: %v", err)
+	}
+	if err := memory.NewHoldGateway(store).Save(hold); err != nil {
+		t.Fatalf("saving hold: %v", err)
+	}
+	return hold
+}
+
+// Journal saves the ledger entries of a transfer of amount minor units from
+// the account with ID from to the one with ID to.
+func Journal(t testing.TB, store *memory.Store, transactionID, from, to string, amount int64, at time.Time) {
+	t.Helper()
+	err := memory.NewLedgerGateway(store).Save([]*entity.LedgerEntry{
+		entity.NewLedgerEntry(transactionID, from, entity.EntryDebit, entity.NewMoney(amount, entity.DefaultCurrency), at),
+		entity.NewLedgerEntry(transactionID, to, entity.EntryCredit, entity.NewMoney(amount, entity.DefaultCurrency), at),
+	})
+	if err != nil {
+		t.Fatalf("saving ledger entries: %v", err)
+	}
+}
+
+// Wrap returns a unit of work handing out the repositories of u passed
+// through wrap, so that a test can make them fail.
+func Wrap(u uow.UnitOfWork, wrap func(name string, repository interface{}) interface{}) uow.UnitOfWork {
+	return &wrappingUow{UnitOfWork: u, wrap: wrap}
+}
+
+type wrappingUow struct {
+	uow.UnitOfWork
+	wrap func(name string, repository interface{}) interface{}
+}
+
+func (u *wrappingUow) GetRepository(ctx context.Context, name string) (interface{}, error) {
+	repository, err := u.UnitOfWork.GetRepository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return u.wrap(name, repository), nil
+}
+
+func (u *wrappingUow) Do(ctx context.Context, fn func(uow uow.UnitOfWork) error) error {
+	return u.UnitOfWork.Do(ctx, func(tx uow.UnitOfWork) error {
+		return fn(&wrappingUow{UnitOfWork: tx, wrap: u.wrap})
+	})
+}
+
+// WithConflicts returns u whose account repository fails the first
+// *conflicts account updates as if the account had been modified
+// concurrently.
+func WithConflicts(u uow.UnitOfWork, conflicts *int) uow.UnitOfWork {
+	return Wrap(u, func(name string, repository interface{}) interface{} {
+		if name == gateway.AccountRepository {
+			return &conflictingAccounts{AccountGateway: repository.(gateway.AccountGateway), conflicts: conflicts}
+		}
+		return repository
+	})
+}
+
+type conflictingAccounts struct {
+	gateway.AccountGateway
+	conflicts *int
+}
+
+func (a *conflictingAccounts) conflict(account *entity.Account) error {
+	if *a.conflicts > 0 {
+		*a.conflicts--
+		return &gateway.ConflictError{Entity: "account", ID: account.ID}
+	}
+	return nil
+}
+
+func (a *conflictingAccounts) UpdateBalance(account *entity.Account) error {
+	if err := a.conflict(account); err != nil {
+		return err
+	}
+	return a.AccountGateway.UpdateBalance(account)
+}
+
+func (a *conflictingAccounts) UpdateStatus(account *entity.Account) error {
+	if err := a.conflict(account); err != nil {
+		return err
+	}
+	return a.AccountGateway.UpdateStatus(account)
+}
+
+func (a *conflictingAccounts) UpdateOverdraftLimit(account *entity.Account) error {
+	if err := a.conflict(account); err != nil {
+		return err
+	}
+	return a.AccountGateway.UpdateOverdraftLimit(account)
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"bytes"
+	"slices"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// outboxRow is a stored message with the sequence number giving the order it
+// was saved in.
+type outboxRow struct {
+	entity.OutboxMessage
+	sequence int
+}
+
+type OutboxGateway struct {
+	Store *Store
+}
+
+func NewOutboxGateway(store *Store) *OutboxGateway {
+	return &OutboxGateway{
+		Store: store,
+	}
+}
+
+// Save stores messages in order. Messages whose IDs are taken are rejected
+// before anything is written.
+func (o *OutboxGateway) Save(messages []*entity.OutboxMessage) error {
+	defer o.Store.write()()
+	seen := make(map[string]bool, len(messages))
+	for _, message := range messages {
+		if _, ok := o.Store.data.outbox[message.ID]; ok || seen[message.ID] {
+			return duplicate("outbox message", message.ID)
+		}
+		seen[message.ID] = true
+	}
+	for _, message := range messages {
+		o.Store.data.outboxSequence++
+		stored := outboxRow{OutboxMessage: *message, sequence: o.Store.data.outboxSequence}
+		stored.Payload = bytes.Clone(message.Payload)
+		o.Store.data.outbox[message.ID] = stored
+	}
+	return nil
+}
+
+func (o *OutboxGateway) FindUnsent(limit int) ([]*entity.OutboxMessage, error) {
+	o.Store.mu.Lock()
+	defer o.Store.mu.Unlock()
+	unsent := []outboxRow{}
+	for _, stored := range o.Store.data.outbox {
+		if stored.SentAt.IsZero() {
+			unsent = append(unsent, stored)
+		}
+	}
+	slices.SortFunc(unsent, func(a, b outboxRow) int {
+		return a.sequence - b.sequence
+	})
+	if limit >= 0 && len(unsent) > limit {
+		unsent = unsent[:limit]
+	}
+	messages := make([]*entity.OutboxMessage, 0, len(unsent))
+	for _, stored := range unsent {
+		message := stored.OutboxMessage
+		message.Payload = bytes.Clone(stored.Payload)
+		messages = append(messages, &message)
+	}
+	return messages, nil
+}
+
+func (o *OutboxGateway) MarkSent(id string, sentAt time.Time) error {
+	return o.update(id, func(stored *entity.OutboxMessage) {
+		stored.SentAt = sentAt
+	})
+}
+
+func (o *OutboxGateway) RecordFailure(id string, cause string) error {
+	return o.update(id, func(stored *entity.OutboxMessage) {
+		stored.Attempts++
+		stored.LastError = cause
+	})
+}
+
+func (o *OutboxGateway) update(id string, set func(stored *entity.OutboxMessage)) error {
+	defer o.Store.write()()
+	stored, ok := o.Store.data.outbox[id]
+	if !ok {
+		return &gateway.NotFoundError{Entity: "outbox message", ID: id}
+	}
+	set(&stored.OutboxMessage)
+	o.Store.data.outbox[id] = stored
+	return nil
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"slices"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+type OverdraftLimitChangeGateway struct {
+	Store *Store
+}
+
+func NewOverdraftLimitChangeGateway(store *Store) *OverdraftLimitChangeGateway {
+	return &OverdraftLimitChangeGateway{
+		Store: store,
+	}
+}
+
+func (o *OverdraftLimitChangeGateway) Save(change *entity.OverdraftLimitChange) error {
+	defer o.Store.write()()
+	if _, ok := o.Store.data.overdraftLimitChanges[change.ID]; ok {
+		return duplicate("overdraft limit change", change.ID)
+	}
+	o.Store.data.overdraftLimitChanges[change.ID] = *change
+	return nil
+}
+
+// FindByAccountID returns the changes made to an account, oldest first.
+func (o *OverdraftLimitChangeGateway) FindByAccountID(accountID string) ([]*entity.OverdraftLimitChange, error) {
+	o.Store.mu.Lock()
+	defer o.Store.mu.Unlock()
+	changes := []*entity.OverdraftLimitChange{}
+	for _, change := range o.Store.data.overdraftLimitChanges {
+		if change.AccountID == accountID {
+			changes = append(changes, &change)
+		}
+	}
+	slices.SortFunc(changes, func(a, b *entity.OverdraftLimitChange) int {
+		return chronological(a.CreatedAt, a.ID, b.CreatedAt, b.ID)
+	})
+	return changes, nil
+}
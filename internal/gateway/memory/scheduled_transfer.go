@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"slices"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// scheduledTransferRow is a stored transfer with the time its claim expires.
+type scheduledTransferRow struct {
+	entity.ScheduledTransfer
+	claimedUntil time.Time
+}
+
+func (r scheduledTransferRow) isClaimed(now time.Time) bool {
+	return r.claimedUntil.After(now)
+}
+
+type ScheduledTransferGateway struct {
+	Store *Store
+}
+
+func NewScheduledTransferGateway(store *Store) *ScheduledTransferGateway {
+	return &ScheduledTransferGateway{
+		Store: store,
+	}
+}
+
+func (s *ScheduledTransferGateway) Save(transfer *entity.ScheduledTransfer) error {
+	defer s.Store.write()()
+	if _, ok := s.Store.data.scheduledTransfers[transfer.ID]; ok {
+		return duplicate("scheduled transfer", transfer.ID)
+	}
+	s.Store.data.scheduledTransfers[transfer.ID] = scheduledTransferRow{ScheduledTransfer: *transfer}
+	return nil
+}
+
+func (s *ScheduledTransferGateway) FindByID(id string) (*entity.ScheduledTransfer, error) {
+	s.Store.mu.Lock()
+	defer s.Store.mu.Unlock()
+	stored, ok := s.Store.data.scheduledTransfers[id]
+	if !ok {
+		return nil, &gateway.NotFoundError{Entity: "scheduled transfer", ID: id}
+	}
+	transfer := stored.ScheduledTransfer
+	return &transfer, nil
+}
+
+func (s *ScheduledTransferGateway) Update(transfer *entity.ScheduledTransfer) error {
+	defer s.Store.write()()
+	stored, ok := s.Store.data.scheduledTransfers[transfer.ID]
+	if !ok {
+		return &gateway.NotFoundError{Entity: "scheduled transfer", ID: transfer.ID}
+	}
+	stored.NextRunAt = transfer.NextRunAt
+	stored.Status = transfer.Status
+	stored.ConsecutiveFailures = transfer.ConsecutiveFailures
+	stored.UpdatedAt = transfer.UpdatedAt
+	stored.claimedUntil = time.Time{}
+	s.Store.data.scheduledTransfers[transfer.ID] = stored
+	return nil
+}
+
+func (s *ScheduledTransferGateway) FindDue(now time.Time, limit int) ([]*entity.ScheduledTransfer, error) {
+	s.Store.mu.Lock()
+	defer s.Store.mu.Unlock()
+	transfers := []*entity.ScheduledTransfer{}
+	for _, stored := range s.Store.data.scheduledTransfers {
+		if stored.IsDue(now) && !stored.isClaimed(now) {
+			transfer := stored.ScheduledTransfer
+			transfers = append(transfers, &transfer)
+		}
+	}
+	slices.SortFunc(transfers, func(a, b *entity.ScheduledTransfer) int {
+		return chronological(a.NextRunAt, a.ID, b.NextRunAt, b.ID)
+	})
+	if limit >= 0 && len(transfers) > limit {
+		transfers = transfers[:limit]
+	}
+	return transfers, nil
+}
+
+func (s *ScheduledTransferGateway) Claim(id string, now, until time.Time) error {
+	defer s.Store.write()()
+	stored, ok := s.Store.data.scheduledTransfers[id]
+	if !ok || !stored.IsDue(now) || stored.isClaimed(now) {
+		return &gateway.ConflictError{Entity: "scheduled transfer", ID: id}
+	}
+	stored.claimedUntil = until
+	s.Store.data.scheduledTransfers[id] = stored
+	return nil
+}
+
+func (s *ScheduledTransferGateway) SaveRun(run *entity.ScheduledTransferRun) error {
+	defer s.Store.write()()
+	if _, ok := s.Store.data.scheduledTransferRuns[run.ID]; ok {
+		return duplicate("scheduled transfer run", run.ID)
+	}
+	s.Store.data.scheduledTransferRuns[run.ID] = *run
+	return nil
+}
+
+// FindRuns returns the runs of a transfer, oldest first.
+func (s *ScheduledTransferGateway) FindRuns(transferID string) ([]*entity.ScheduledTransferRun, error) {
+	s.Store.mu.Lock()
+	defer s.Store.mu.Unlock()
+	runs := []*entity.ScheduledTransferRun{}
+	for _, run := range s.Store.data.scheduledTransferRuns {
+		if run.ScheduledTransferID == transferID {
+			runs = append(runs, &run)
+		}
+	}
+	slices.SortFunc(runs, func(a, b *entity.ScheduledTransferRun) int {
+		return chronological(a.RanAt, a.ID, b.RanAt, b.ID)
+	})
+	return runs, nil
+}
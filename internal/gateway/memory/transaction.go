@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"slices"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// transactionRow is a stored transaction, keeping only the IDs of its
+// accounts.
+type transactionRow struct {
+	entity.Transaction
+	accountIDFrom string
+	accountIDTo   string
+}
+
+// TransactionGateway stores transactions. Like the SQL gateway, the
+// transactions it returns only carry the IDs of their accounts.
+type TransactionGateway struct {
+	Store *Store
+}
+
+func NewTransactionGateway(store *Store) *TransactionGateway {
+	return &TransactionGateway{
+		Store: store,
+	}
+}
+
+func (t *TransactionGateway) Save(transaction *entity.Transaction) error {
+	defer t.Store.write()()
+	if _, ok := t.Store.data.transactions[transaction.ID]; ok {
+		return duplicate("transaction", transaction.ID)
+	}
+	stored := transactionRow{Transaction: *transaction, accountIDFrom: transaction.AccountFrom.ID, accountIDTo: transaction.AccountTo.ID}
+	stored.AccountFrom = nil
+	stored.AccountTo = nil
+	t.Store.data.transactions[transaction.ID] = stored
+	return nil
+}
+
+func (t *TransactionGateway) FindByID(id string) (*entity.Transaction, error) {
+	t.Store.mu.Lock()
+	defer t.Store.mu.Unlock()
+	stored, ok := t.Store.data.transactions[id]
+	if !ok {
+		return nil, &gateway.NotFoundError{Entity: "transaction", ID: id}
+	}
+	return stored.load(), nil
+}
+
+// FindByIDForUpdate loads a transaction like FindByID. Units of work on a
+// store already run one at a time, so there is nothing left to lock.
+func (t *TransactionGateway) FindByIDForUpdate(id string) (*entity.Transaction, error) {
+	return t.FindByID(id)
+}
+
+// FindReversals returns the transactions reversing the given one, oldest
+// first.
+func (t *TransactionGateway) FindReversals(transactionID string) ([]*entity.Transaction, error) {
+	return t.findAll(func(stored transactionRow) bool {
+		return stored.ReversalOf == transactionID
+	}), nil
+}
+
+// FindFees returns the fees charged for the given transaction.
+func (t *TransactionGateway) FindFees(transactionID string) ([]*entity.Transaction, error) {
+	return t.findAll(func(stored transactionRow) bool {
+		return stored.FeeOf == transactionID
+	}), nil
+}
+
+// FindByAccountID returns the transactions sent or received by an account,
+// oldest first.
+func (t *TransactionGateway) FindByAccountID(accountID string) ([]*entity.Transaction, error) {
+	return t.findAll(func(stored transactionRow) bool {
+		return stored.accountIDFrom == accountID || stored.accountIDTo == accountID
+	}), nil
+}
+
+// OutgoingVolumeByAccount aggregates the transfers sent from an account.
+func (t *TransactionGateway) OutgoingVolumeByAccount(accountID, currency string, since time.Time) (gateway.Volume, error) {
+	t.Store.mu.Lock()
+	defer t.Store.mu.Unlock()
+	return t.outgoingVolume(currency, since, func(stored transactionRow) bool {
+		return stored.accountIDFrom == accountID
+	})
+}
+
+// OutgoingVolumeByClient aggregates the transfers sent from every account of
+// a client.
+func (t *TransactionGateway) OutgoingVolumeByClient(clientID, currency string, since time.Time) (gateway.Volume, error) {
+	t.Store.mu.Lock()
+	defer t.Store.mu.Unlock()
+	return t.outgoingVolume(currency, since, func(stored transactionRow) bool {
+		account, ok := t.Store.data.accounts[stored.accountIDFrom]
+		return ok && account.clientID == clientID
+	})
+}
+
+// outgoingVolume sums the transactions matching sentFrom. The store must be
+// locked.
+func (t *TransactionGateway) outgoingVolume(currency string, since time.Time, sentFrom func(stored transactionRow) bool) (gateway.Volume, error) {
+	volume := gateway.Volume{Total: entity.Zero(currency)}
+	for _, stored := range t.Store.data.transactions {
+		if !sentFrom(stored) || stored.Amount.Currency() != volume.Total.Currency() || stored.CreatedAt.Before(since) ||
+			stored.Kind != entity.TransactionTransfer || stored.ReversalOf != "" || stored.FeeOf != "" {
+			continue
+		}
+		total, err := volume.Total.Add(stored.Amount)
+		if err != nil {
+			return gateway.Volume{}, err
+		}
+		volume.Total = total
+		volume.Count++
+	}
+	return volume, nil
+}
+
+func (t *TransactionGateway) findAll(match func(stored transactionRow) bool) []*entity.Transaction {
+	t.Store.mu.Lock()
+	defer t.Store.mu.Unlock()
+	transactions := []*entity.Transaction{}
+	for _, stored := range t.Store.data.transactions {
+		if match(stored) {
+			transactions = append(transactions, stored.load())
+		}
+	}
+	slices.SortFunc(transactions, func(a, b *entity.Transaction) int {
+		return chronological(a.CreatedAt, a.ID, b.CreatedAt, b.ID)
+	})
+	return transactions
+}
+
+func (t transactionRow) load() *entity.Transaction {
+	found := t.Transaction
+	found.AccountFrom = &entity.Account{ID: t.accountIDFrom}
+	found.AccountTo = &entity.Account{ID: t.accountIDTo}
+	return &found
+}
@@ -0,0 +1,18 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+const OutboxRepository = "OutboxDB"
+
+// OutboxGateway persists outbox messages. FindUnsent returns messages in the
+// order they were saved.
+type OutboxGateway interface {
+	Save(messages []*entity.OutboxMessage) error
+	FindUnsent(limit int) ([]*entity.OutboxMessage, error)
+	MarkSent(id string, sentAt time.Time) error
+	RecordFailure(id string, cause string) error
+}
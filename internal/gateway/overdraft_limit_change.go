@@ -0,0 +1,10 @@
+package gateway
+
+import "github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+
+const OverdraftLimitChangeRepository = "OverdraftLimitChangeDB"
+
+type OverdraftLimitChangeGateway interface {
+	Save(change *entity.OverdraftLimitChange) error
+	FindByAccountID(accountID string) ([]*entity.OverdraftLimitChange, error)
+}
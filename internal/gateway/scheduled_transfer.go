@@ -0,0 +1,28 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+const ScheduledTransferRepository = "ScheduledTransferDB"
+
+// ScheduledTransferGateway persists scheduled transfers. A runner claims a due
+// transfer before executing it, so that concurrent runners leave it alone
+// until the claim expires or Update releases it.
+type ScheduledTransferGateway interface {
+	Save(transfer *entity.ScheduledTransfer) error
+	FindByID(id string) (*entity.ScheduledTransfer, error)
+	// Update stores the transfer and releases its claim.
+	Update(transfer *entity.ScheduledTransfer) error
+	// FindDue returns up to limit active transfers due at now and not
+	// claimed, earliest first.
+	FindDue(now time.Time, limit int) ([]*entity.ScheduledTransfer, error)
+	// Claim reserves the transfer id until until. It returns a
+	// *ConflictError when the transfer is no longer active and due at now,
+	// or is claimed by someone else.
+	Claim(id string, now, until time.Time) error
+	SaveRun(run *entity.ScheduledTransferRun) error
+	FindRuns(transferID string) ([]*entity.ScheduledTransferRun, error)
+}
@@ -1,7 +1,31 @@
 package gateway
 
-import "github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+import (
+	"time"
 
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+)
+
+const TransactionRepository = "TransactionDB"
+
+// TransactionGateway persists transactions. Transactions it returns only carry
+// the IDs of their accounts; load the accounts through AccountGateway.
+// FindByIDForUpdate also locks the transaction until the unit of work it runs
+// in ends, where the store supports it.
 type TransactionGateway interface {
 	Save(transaction *entity.Transaction) error
+	FindByID(id string) (*entity.Transaction, error)
+	FindByIDForUpdate(id string) (*entity.Transaction, error)
+	FindReversals(transactionID string) ([]*entity.Transaction, error)
+	FindFees(transactionID string) ([]*entity.Transaction, error)
+	FindByAccountID(accountID string) ([]*entity.Transaction, error)
+	OutgoingVolumeByAccount(accountID, currency string, since time.Time) (Volume, error)
+	OutgoingVolumeByClient(clientID, currency string, since time.Time) (Volume, error)
+}
+
+// Volume aggregates the transfers sent in one currency since a point in
+// time. Deposits, withdrawals, reversals and fees are not counted.
+type Volume struct {
+	Total entity.Money
+	Count int
 }
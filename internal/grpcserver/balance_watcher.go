@@ -0,0 +1,99 @@
+package grpcserver
+
+import (
+	"sync"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+)
+
+// watchBuffer is how many balance updates a watcher can fall behind before
+// its oldest pending update is dropped.
+const watchBuffer = 16
+
+// BalanceWatcher fans BalanceUpdated events out to the WatchBalance streams
+// of the same account. Register it on the dispatcher the use cases send their
+// events to.
+type BalanceWatcher struct {
+	mu       sync.Mutex
+	watchers map[string]map[chan events.BalanceUpdated]struct{}
+	closed   bool
+}
+
+func NewBalanceWatcher() *BalanceWatcher {
+	return &BalanceWatcher{
+		watchers: make(map[string]map[chan events.BalanceUpdated]struct{}),
+	}
+}
+
+// Watch returns a channel receiving the balance updates of accountID and a
+// function to stop watching. The channel is closed once stop is called or
+// the watcher is closed.
+func (w *BalanceWatcher) Watch(accountID string) (<-chan events.BalanceUpdated, func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	updates := make(chan events.BalanceUpdated, watchBuffer)
+	if w.closed {
+		close(updates)
+		return updates, func() {}
+	}
+	if w.watchers[accountID] == nil {
+		w.watchers[accountID] = make(map[chan events.BalanceUpdated]struct{})
+	}
+	w.watchers[accountID][updates] = struct{}{}
+
+	return updates, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if _, ok := w.watchers[accountID][updates]; !ok {
+			return
+		}
+		delete(w.watchers[accountID], updates)
+		if len(w.watchers[accountID]) == 0 {
+			delete(w.watchers, accountID)
+		}
+		close(updates)
+	}
+}
+
+// Handle delivers BalanceUpdated events to the watchers of their account
+// without blocking. A watcher whose buffer is full loses its oldest pending
+// update, so it always ends up with the latest balance.
+func (w *BalanceWatcher) Handle(event events.Event) {
+	update, ok := event.(events.BalanceUpdated)
+	if !ok {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for updates := range w.watchers[update.AccountID] {
+		select {
+		case updates <- update:
+			continue
+		default:
+		}
+		select {
+		case <-updates:
+		default:
+		}
+		select {
+		case updates <- update:
+		default:
+		}
+	}
+}
+
+// Close ends every watch, so streams waiting for updates return and the
+// server can stop gracefully.
+func (w *BalanceWatcher) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	for accountID, watchers := range w.watchers {
+		for updates := range watchers {
+			close(updates)
+		}
+		delete(w.watchers, accountID)
+	}
+}
@@ -0,0 +1,59 @@
+package grpcserver
+
+import (
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceWatcherDeliversToAccountWatchers(t *testing.T) {
+	watcher := NewBalanceWatcher()
+	updates, stop := watcher.Watch("account-1")
+	other, stopOther := watcher.Watch("account-2")
+	defer stopOther()
+
+	watcher.Handle(events.BalanceUpdated{AccountID: "account-1", Version: 1})
+	watcher.Handle(events.AccountCreated{AccountID: "account-1"})
+
+	assert.Equal(t, 1, (<-updates).Version)
+	assert.Empty(t, other)
+
+	stop()
+	stop()
+	_, ok := <-updates
+	assert.False(t, ok)
+	watcher.Handle(events.BalanceUpdated{AccountID: "account-1", Version: 2})
+}
+
+func TestBalanceWatcherKeepsLatestWhenFull(t *testing.T) {
+	watcher := NewBalanceWatcher()
+	updates, stop := watcher.Watch("account-1")
+	defer stop()
+
+	for version := 1; version <= watchBuffer+5; version++ {
+		watcher.Handle(events.BalanceUpdated{AccountID: "account-1", Version: version})
+	}
+
+	assert.Len(t, updates, watchBuffer)
+	assert.Equal(t, 6, (<-updates).Version)
+	var last events.BalanceUpdated
+	for len(updates) > 0 {
+		last = <-updates
+	}
+	assert.Equal(t, watchBuffer+5, last.Version)
+}
+
+func TestBalanceWatcherClose(t *testing.T) {
+	watcher := NewBalanceWatcher()
+	updates, stop := watcher.Watch("account-1")
+
+	watcher.Close()
+	_, ok := <-updates
+	assert.False(t, ok)
+	stop()
+
+	late, _ := watcher.Watch("account-1")
+	_, ok = <-late
+	assert.False(t, ok)
+}
@@ -0,0 +1,49 @@
+package grpcserver
+
+import (
+	"errors"
+	"log"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus converts err to a gRPC status error with the code matching it.
+// Unexpected errors are logged and reported without their details.
+func toStatus(err error) error {
+	code := codeFor(err)
+	if code == codes.Internal {
+		log.Printf("internal error: %v", err)
+		return status.Error(code, "internal error")
+	}
+	return status.Error(code, err.Error())
+}
+
+func codeFor(err error) codes.Code {
+	var validationErr *entity.ValidationError
+	switch {
+	case errors.As(err, &validationErr),
+		errors.Is(err, entity.ErrInvalidMoney),
+		errors.Is(err, entity.ErrInvalidAmount):
+		return codes.InvalidArgument
+	case errors.Is(err, entity.ErrClientNotFound),
+		errors.Is(err, entity.ErrAccountNotFound),
+		errors.Is(err, entity.ErrTransactionNotFound),
+		errors.Is(err, gateway.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, entity.ErrIdempotencyKeyReused):
+		return codes.AlreadyExists
+	case errors.Is(err, gateway.ErrConflict):
+		return codes.Aborted
+	case errors.Is(err, entity.ErrLimitExceeded):
+		return codes.ResourceExhausted
+	case errors.Is(err, entity.ErrInsufficientFunds),
+		errors.Is(err, entity.ErrAccountNotActive),
+		errors.Is(err, entity.ErrCurrencyMismatch),
+		errors.Is(err, entity.ErrMoneyOverflow):
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
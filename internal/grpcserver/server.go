@@ -0,0 +1,298 @@
+// Package grpcserver implements the WalletService gRPC API on top of the
+// wallet use cases.
+package grpcserver
+
+import (
+	"context"
+
+	walletv1 "github.com/AntonioSabino/fc-ms-wallet/api/wallet/v1"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	createaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_account"
+	createclient "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_client"
+	createtransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_transaction"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/usecase/deposit"
+	getaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_account"
+	getbalance "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_balance"
+	listtransactions "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/list_transactions"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/usecase/withdraw"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WalletServer struct {
+	walletv1.UnimplementedWalletServiceServer
+
+	CreateClientUseCase      *createclient.CreateClientUseCase
+	CreateAccountUseCase     *createaccount.CreateAccountUseCase
+	CreateTransactionUseCase *createtransaction.CreateTransactionUseCase
+	DepositUseCase           *deposit.DepositUseCase
+	WithdrawUseCase          *withdraw.WithdrawUseCase
+	GetAccountUseCase        *getaccount.GetAccountUseCase
+	GetBalanceUseCase        *getbalance.GetBalanceUseCase
+	ListTransactionsUseCase  *listtransactions.ListTransactionsUseCase
+	// Balances feeds WatchBalance; it only sees the balance changes of the
+	// use cases whose Dispatcher it is registered on.
+	Balances *BalanceWatcher
+}
+
+func NewWalletServer(
+	createClientUseCase *createclient.CreateClientUseCase,
+	createAccountUseCase *createaccount.CreateAccountUseCase,
+	createTransactionUseCase *createtransaction.CreateTransactionUseCase,
+	depositUseCase *deposit.DepositUseCase,
+	withdrawUseCase *withdraw.WithdrawUseCase,
+	getAccountUseCase *getaccount.GetAccountUseCase,
+	getBalanceUseCase *getbalance.GetBalanceUseCase,
+	listTransactionsUseCase *listtransactions.ListTransactionsUseCase,
+	balances *BalanceWatcher,
+) *WalletServer {
+	return &WalletServer{
+		CreateClientUseCase:      createClientUseCase,
+		CreateAccountUseCase:     createAccountUseCase,
+		CreateTransactionUseCase: createTransactionUseCase,
+		DepositUseCase:           depositUseCase,
+		WithdrawUseCase:          withdrawUseCase,
+		GetAccountUseCase:        getAccountUseCase,
+		GetBalanceUseCase:        getBalanceUseCase,
+		ListTransactionsUseCase:  listTransactionsUseCase,
+		Balances:                 balances,
+	}
+}
+
+func (s *WalletServer) CreateClient(ctx context.Context, request *walletv1.CreateClientRequest) (*walletv1.CreateClientResponse, error) {
+	output, err := s.CreateClientUseCase.Execute(ctx, createclient.CreateClientInputDTO{
+		Name:  request.GetName(),
+		Email: request.GetEmail(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.CreateClientResponse{
+		Client: &walletv1.Client{
+			Id:    output.ID,
+			Name:  output.Name,
+			Email: output.Email,
+			Tier:  output.Tier,
+		},
+	}, nil
+}
+
+func (s *WalletServer) CreateAccount(ctx context.Context, request *walletv1.CreateAccountRequest) (*walletv1.CreateAccountResponse, error) {
+	output, err := s.CreateAccountUseCase.Execute(ctx, createaccount.CreateAccountInputDTO{ClientID: request.GetClientId()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.CreateAccountResponse{Id: output.ID}, nil
+}
+
+func (s *WalletServer) CreateTransaction(ctx context.Context, request *walletv1.CreateTransactionRequest) (*walletv1.CreateTransactionResponse, error) {
+	amount, err := fromMoney(request.GetAmount())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	output, err := s.CreateTransactionUseCase.Execute(ctx, createtransaction.CreateTransactionInputDTO{
+		AccountIDFrom:  request.GetAccountIdFrom(),
+		AccountIDTo:    request.GetAccountIdTo(),
+		Amount:         amount,
+		IdempotencyKey: request.GetIdempotencyKey(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.CreateTransactionResponse{
+		Id:               output.ID,
+		Amount:           toMoney(output.Amount),
+		Fee:              toMoney(output.Fee),
+		Total:            toMoney(output.Total),
+		FeeTransactionId: output.FeeTransactionID,
+	}, nil
+}
+
+func (s *WalletServer) Deposit(ctx context.Context, request *walletv1.DepositRequest) (*walletv1.DepositResponse, error) {
+	amount, err := fromMoney(request.GetAmount())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	output, err := s.DepositUseCase.Execute(ctx, deposit.DepositInputDTO{
+		AccountID: request.GetAccountId(),
+		Amount:    amount,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.DepositResponse{
+		Id:      output.ID,
+		Amount:  toMoney(output.Amount),
+		Balance: toMoney(output.Balance),
+	}, nil
+}
+
+func (s *WalletServer) Withdraw(ctx context.Context, request *walletv1.WithdrawRequest) (*walletv1.WithdrawResponse, error) {
+	amount, err := fromMoney(request.GetAmount())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	output, err := s.WithdrawUseCase.Execute(ctx, withdraw.WithdrawInputDTO{
+		AccountID: request.GetAccountId(),
+		Amount:    amount,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.WithdrawResponse{
+		Id:      output.ID,
+		Amount:  toMoney(output.Amount),
+		Balance: toMoney(output.Balance),
+	}, nil
+}
+
+func (s *WalletServer) GetAccount(ctx context.Context, request *walletv1.GetAccountRequest) (*walletv1.GetAccountResponse, error) {
+	output, err := s.GetAccountUseCase.Execute(getaccount.GetAccountInputDTO{ID: request.GetId()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.GetAccountResponse{
+		Account: &walletv1.Account{
+			Id:             output.ID,
+			ClientId:       output.ClientID,
+			Balance:        toMoney(output.Balance),
+			HeldAmount:     toMoney(output.HeldAmount),
+			OverdraftLimit: toMoney(output.OverdraftLimit),
+			Status:         output.Status,
+			Version:        int64(output.Version),
+			CreatedAt:      timestamppb.New(output.CreatedAt),
+		},
+	}, nil
+}
+
+func (s *WalletServer) GetBalance(ctx context.Context, request *walletv1.GetBalanceRequest) (*walletv1.GetBalanceResponse, error) {
+	output, err := s.GetBalanceUseCase.Execute(getbalance.GetBalanceInputDTO{AccountID: request.GetAccountId()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &walletv1.GetBalanceResponse{
+		Balance: &walletv1.Balance{
+			AccountId:  output.AccountID,
+			Balance:    toMoney(output.Balance),
+			HeldAmount: toMoney(output.HeldAmount),
+			Version:    int64(output.Version),
+			UpdatedAt:  timestamppb.New(output.UpdatedAt),
+		},
+		LastTransactionId: output.LastTransactionID,
+	}, nil
+}
+
+func (s *WalletServer) ListTransactions(ctx context.Context, request *walletv1.ListTransactionsRequest) (*walletv1.ListTransactionsResponse, error) {
+	output, err := s.ListTransactionsUseCase.Execute(listtransactions.ListTransactionsInputDTO{AccountID: request.GetAccountId()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &walletv1.ListTransactionsResponse{
+		Transactions: make([]*walletv1.Transaction, 0, len(output.Transactions)),
+	}
+	for _, transaction := range output.Transactions {
+		response.Transactions = append(response.Transactions, &walletv1.Transaction{
+			Id:            transaction.ID,
+			Kind:          transaction.Kind,
+			AccountIdFrom: transaction.AccountIDFrom,
+			AccountIdTo:   transaction.AccountIDTo,
+			Amount:        toMoney(transaction.Amount),
+			ReversalOf:    transaction.ReversalOf,
+			FeeOf:         transaction.FeeOf,
+			CreatedAt:     timestamppb.New(transaction.CreatedAt),
+		})
+	}
+	return response, nil
+}
+
+// WatchBalance starts watching before reading the current balance, so no
+// change is lost in between, and skips updates not newer than the last
+// balance sent.
+func (s *WalletServer) WatchBalance(request *walletv1.WatchBalanceRequest, stream walletv1.WalletService_WatchBalanceServer) error {
+	updates, stop := s.Balances.Watch(request.GetAccountId())
+	defer stop()
+
+	account, err := s.GetAccountUseCase.Execute(getaccount.GetAccountInputDTO{ID: request.GetAccountId()})
+	if err != nil {
+		return toStatus(err)
+	}
+	version := int64(account.Version)
+	err = stream.Send(&walletv1.WatchBalanceResponse{
+		Balance: &walletv1.Balance{
+			AccountId:  account.ID,
+			Balance:    toMoney(account.Balance),
+			HeldAmount: toMoney(account.HeldAmount),
+			Version:    version,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if int64(update.Version) <= version {
+				continue
+			}
+			balance, err := fromBalanceUpdated(update)
+			if err != nil {
+				return toStatus(err)
+			}
+			if err := stream.Send(&walletv1.WatchBalanceResponse{Balance: balance}); err != nil {
+				return err
+			}
+			version = balance.Version
+		}
+	}
+}
+
+func toMoney(money entity.Money) *walletv1.Money {
+	return &walletv1.Money{
+		Amount:   money.Decimal(),
+		Currency: money.Currency(),
+	}
+}
+
+// fromMoney parses money, defaulting its currency to entity.DefaultCurrency.
+func fromMoney(money *walletv1.Money) (entity.Money, error) {
+	currency := money.GetCurrency()
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	}
+	return entity.ParseMoney(money.GetAmount(), currency)
+}
+
+func fromBalanceUpdated(event events.BalanceUpdated) (*walletv1.Balance, error) {
+	balance, err := entity.ParseMoney(event.Balance, event.Currency)
+	if err != nil {
+		return nil, err
+	}
+	held, err := entity.ParseMoney(event.HeldAmount, event.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return &walletv1.Balance{
+		AccountId:  event.AccountID,
+		Balance:    toMoney(balance),
+		HeldAmount: toMoney(held),
+		Version:    int64(event.Version),
+		UpdatedAt:  timestamppb.New(event.UpdatedAt),
+	}, nil
+}
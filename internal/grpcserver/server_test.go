@@ -0,0 +1,295 @@
+package grpcserver
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+	"time"
+
+	walletv1 "github.com/AntonioSabino/fc-ms-wallet/api/wallet/v1"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	createaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_account"
+	createclient "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_client"
+	createtransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_transaction"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/usecase/deposit"
+	getaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_account"
+	getbalance "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_balance"
+	listtransactions "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/list_transactions"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/usecase/withdraw"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type WalletServerTestSuite struct {
+	suite.Suite
+	db        *sql.DB
+	balances  *BalanceWatcher
+	projected *database.BalanceDB
+	deposit   *deposit.DepositUseCase
+	withdraw  *withdraw.WithdrawUseCase
+	server    *grpc.Server
+	client    walletv1.WalletServiceClient
+}
+
+func (s *WalletServerTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+
+	accountDB := database.NewAccountDB(db)
+	s.projected = database.NewBalanceDB(db)
+	u := uow.NewUow(db)
+	u.Register(gateway.ClientRepository, func(tx *sql.Tx) interface{} {
+		return database.NewClientDB(tx)
+	})
+	u.Register(gateway.AccountRepository, func(tx *sql.Tx) interface{} {
+		return database.NewAccountDB(tx)
+	})
+	u.Register(gateway.TransactionRepository, func(tx *sql.Tx) interface{} {
+		return database.NewTransactionDB(tx)
+	})
+	u.Register(gateway.LedgerRepository, func(tx *sql.Tx) interface{} {
+		return database.NewLedgerDB(tx)
+	})
+	u.Register(gateway.IdempotencyKeyRepository, func(tx *sql.Tx) interface{} {
+		return database.NewIdempotencyKeyDB(tx)
+	})
+
+	s.balances = NewBalanceWatcher()
+	dispatcher := events.NewEventDispatcher()
+	s.Nil(dispatcher.Register(events.BalanceUpdatedName, s.balances))
+	createTransaction := createtransaction.NewCreateTransactionUseCase(u)
+	createTransaction.Dispatcher = dispatcher
+	// Tests set the treasury account once they have created it.
+	s.deposit = deposit.NewDepositUseCase(u, "")
+	s.deposit.Dispatcher = dispatcher
+	s.withdraw = withdraw.NewWithdrawUseCase(u, "")
+	s.withdraw.Dispatcher = dispatcher
+
+	s.server = grpc.NewServer()
+	walletv1.RegisterWalletServiceServer(s.server, NewWalletServer(
+		createclient.NewCreateClientUseCase(u),
+		createaccount.NewCreateAccountUseCase(u),
+		createTransaction,
+		s.deposit,
+		s.withdraw,
+		getaccount.NewGetAccountUseCase(accountDB),
+		getbalance.NewGetBalanceUseCase(s.projected),
+		listtransactions.NewListTransactionsUseCase(database.NewTransactionDB(db), accountDB),
+		s.balances,
+	))
+
+	listener := bufconn.Listen(1024 * 1024)
+	go s.server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Nil(err)
+	s.T().Cleanup(func() { conn.Close() })
+	s.client = walletv1.NewWalletServiceClient(conn)
+}
+
+func (s *WalletServerTestSuite) TearDownTest() {
+	s.balances.Close()
+	s.server.Stop()
+}
+
+func TestWalletServerTestSuite(t *testing.T) {
+	suite.Run(t, new(WalletServerTestSuite))
+}
+
+func (s *WalletServerTestSuite) createAccount(balance string) string {
+	ctx := context.Background()
+	client, err := s.client.CreateClient(ctx, &walletv1.CreateClientRequest{Name: "John Doe", Email: "john@example.com"})
+	s.Nil(err)
+	account, err := s.client.CreateAccount(ctx, &walletv1.CreateAccountRequest{ClientId: client.GetClient().GetId()})
+	s.Nil(err)
+	_, err = s.db.Exec("UPDATE accounts SET balance = ? WHERE id = ?", balance, account.GetId())
+	s.Nil(err)
+	return account.GetId()
+}
+
+func (s *WalletServerTestSuite) transfer(from, to, amount string) (*walletv1.CreateTransactionResponse, error) {
+	return s.client.CreateTransaction(context.Background(), &walletv1.CreateTransactionRequest{
+		AccountIdFrom: from,
+		AccountIdTo:   to,
+		Amount:        &walletv1.Money{Amount: amount},
+	})
+}
+
+func (s *WalletServerTestSuite) assertCode(err error, code codes.Code) {
+	s.Error(err)
+	s.Equal(code, status.Code(err), err)
+}
+
+func (s *WalletServerTestSuite) TestCreateClient() {
+	response, err := s.client.CreateClient(context.Background(), &walletv1.CreateClientRequest{Name: "John Doe", Email: "john@example.com"})
+	s.Nil(err)
+	s.NotEmpty(response.GetClient().GetId())
+	s.Equal("standard", response.GetClient().GetTier())
+
+	_, err = s.client.CreateClient(context.Background(), &walletv1.CreateClientRequest{Email: "john@example.com"})
+	s.assertCode(err, codes.InvalidArgument)
+}
+
+func (s *WalletServerTestSuite) TestCreateAndGetAccount() {
+	id := s.createAccount("12.34")
+
+	response, err := s.client.GetAccount(context.Background(), &walletv1.GetAccountRequest{Id: id})
+	s.Nil(err)
+	s.Equal(id, response.GetAccount().GetId())
+	s.Equal("12.34", response.GetAccount().GetBalance().GetAmount())
+	s.Equal("BRL", response.GetAccount().GetBalance().GetCurrency())
+	s.Equal("active", response.GetAccount().GetStatus())
+
+	_, err = s.client.GetAccount(context.Background(), &walletv1.GetAccountRequest{Id: "unknown"})
+	s.assertCode(err, codes.NotFound)
+	_, err = s.client.CreateAccount(context.Background(), &walletv1.CreateAccountRequest{ClientId: "unknown"})
+	s.assertCode(err, codes.NotFound)
+}
+
+func (s *WalletServerTestSuite) TestGetBalance() {
+	updatedAt := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+	s.Nil(s.projected.ApplyBalance(&entity.AccountBalance{
+		AccountID:  "account-1",
+		Balance:    entity.NewMoney(12_34, entity.DefaultCurrency),
+		HeldAmount: entity.Zero(entity.DefaultCurrency),
+		Version:    3,
+		UpdatedAt:  updatedAt,
+	}))
+	s.Nil(s.projected.ApplyTransaction("account-1", entity.DefaultCurrency, "transaction-1", updatedAt))
+
+	response, err := s.client.GetBalance(context.Background(), &walletv1.GetBalanceRequest{AccountId: "account-1"})
+	s.Nil(err)
+	s.Equal("account-1", response.GetBalance().GetAccountId())
+	s.Equal("12.34", response.GetBalance().GetBalance().GetAmount())
+	s.Equal("BRL", response.GetBalance().GetBalance().GetCurrency())
+	s.Equal(int64(3), response.GetBalance().GetVersion())
+	s.True(updatedAt.Equal(response.GetBalance().GetUpdatedAt().AsTime()))
+	s.Equal("transaction-1", response.GetLastTransactionId())
+
+	_, err = s.client.GetBalance(context.Background(), &walletv1.GetBalanceRequest{AccountId: "unknown"})
+	s.assertCode(err, codes.NotFound)
+}
+
+func (s *WalletServerTestSuite) TestCreateAndListTransactions() {
+	from := s.createAccount("100")
+	to := s.createAccount("0")
+
+	created, err := s.transfer(from, to, "10.50")
+	s.Nil(err)
+	s.Equal("10.50", created.GetTotal().GetAmount())
+	s.Equal("0.00", created.GetFee().GetAmount())
+
+	response, err := s.client.ListTransactions(context.Background(), &walletv1.ListTransactionsRequest{AccountId: to})
+	s.Nil(err)
+	s.Len(response.GetTransactions(), 1)
+	s.Equal(created.GetId(), response.GetTransactions()[0].GetId())
+	s.Equal(from, response.GetTransactions()[0].GetAccountIdFrom())
+	s.Equal("10.50", response.GetTransactions()[0].GetAmount().GetAmount())
+
+	_, err = s.client.ListTransactions(context.Background(), &walletv1.ListTransactionsRequest{AccountId: "unknown"})
+	s.assertCode(err, codes.NotFound)
+}
+
+func (s *WalletServerTestSuite) TestCreateTransactionErrors() {
+	from := s.createAccount("10")
+	to := s.createAccount("0")
+
+	_, err := s.transfer(from, to, "20")
+	s.assertCode(err, codes.FailedPrecondition)
+	_, err = s.transfer(from, to, "")
+	s.assertCode(err, codes.InvalidArgument)
+	_, err = s.transfer(from, "unknown", "1")
+	s.assertCode(err, codes.NotFound)
+
+	request := &walletv1.CreateTransactionRequest{AccountIdFrom: from, AccountIdTo: to, Amount: &walletv1.Money{Amount: "1"}, IdempotencyKey: "key-1"}
+	_, err = s.client.CreateTransaction(context.Background(), request)
+	s.Nil(err)
+	request.Amount.Amount = "2"
+	_, err = s.client.CreateTransaction(context.Background(), request)
+	s.assertCode(err, codes.AlreadyExists)
+}
+
+func (s *WalletServerTestSuite) TestDepositAndWithdraw() {
+	treasury := s.createAccount("0")
+	s.deposit.TreasuryAccountID = treasury
+	s.withdraw.TreasuryAccountID = treasury
+	account := s.createAccount("0")
+	ctx := context.Background()
+
+	deposited, err := s.client.Deposit(ctx, &walletv1.DepositRequest{AccountId: account, Amount: &walletv1.Money{Amount: "25"}})
+	s.Nil(err)
+	s.NotEmpty(deposited.GetId())
+	s.Equal("25.00", deposited.GetAmount().GetAmount())
+	s.Equal("25.00", deposited.GetBalance().GetAmount())
+	s.Equal("BRL", deposited.GetBalance().GetCurrency())
+
+	withdrawn, err := s.client.Withdraw(ctx, &walletv1.WithdrawRequest{AccountId: account, Amount: &walletv1.Money{Amount: "10"}})
+	s.Nil(err)
+	s.Equal("10.00", withdrawn.GetAmount().GetAmount())
+	s.Equal("15.00", withdrawn.GetBalance().GetAmount())
+
+	_, err = s.client.Withdraw(ctx, &walletv1.WithdrawRequest{AccountId: account, Amount: &walletv1.Money{Amount: "20"}})
+	s.assertCode(err, codes.FailedPrecondition)
+	_, err = s.client.Deposit(ctx, &walletv1.DepositRequest{AccountId: account, Amount: &walletv1.Money{Amount: ""}})
+	s.assertCode(err, codes.InvalidArgument)
+	_, err = s.client.Deposit(ctx, &walletv1.DepositRequest{AccountId: "unknown", Amount: &walletv1.Money{Amount: "1"}})
+	s.assertCode(err, codes.NotFound)
+
+	s.withdraw.TreasuryAccountID = ""
+	_, err = s.client.Withdraw(ctx, &walletv1.WithdrawRequest{AccountId: account, Amount: &walletv1.Money{Amount: "1"}})
+	s.assertCode(err, codes.Internal)
+}
+
+func (s *WalletServerTestSuite) TestWatchBalance() {
+	from := s.createAccount("100")
+	to := s.createAccount("0")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := s.client.WatchBalance(ctx, &walletv1.WatchBalanceRequest{AccountId: to})
+	s.Nil(err)
+
+	first, err := stream.Recv()
+	s.Nil(err)
+	s.Equal("0.00", first.GetBalance().GetBalance().GetAmount())
+
+	_, err = s.transfer(from, to, "30")
+	s.Nil(err)
+	_, err = s.transfer(from, to, "20")
+	s.Nil(err)
+
+	second, err := stream.Recv()
+	s.Nil(err)
+	s.Equal("30.00", second.GetBalance().GetBalance().GetAmount())
+	s.Greater(second.GetBalance().GetVersion(), first.GetBalance().GetVersion())
+	s.NotNil(second.GetBalance().GetUpdatedAt())
+	third, err := stream.Recv()
+	s.Nil(err)
+	s.Equal("50.00", third.GetBalance().GetBalance().GetAmount())
+
+	s.balances.Close()
+	_, err = stream.Recv()
+	s.Error(err)
+}
+
+func (s *WalletServerTestSuite) TestWatchBalanceUnknownAccount() {
+	stream, err := s.client.WatchBalance(context.Background(), &walletv1.WatchBalanceRequest{AccountId: "unknown"})
+	s.Nil(err)
+	_, err = stream.Recv()
+	s.assertCode(err, codes.NotFound)
+}
@@ -0,0 +1,73 @@
+// Package kafkatest runs an in-process broker speaking the Kafka protocol, so
+// code producing to Kafka can be tested without a running cluster.
+package kafkatest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// ConsumeTimeout bounds how long Consume waits for records.
+const ConsumeTimeout = 5 * time.Second
+
+type Broker struct {
+	cluster *kfake.Cluster
+}
+
+// NewBroker starts a single-node broker with the given topics, each with a
+// single partition. The broker is stopped when the test finishes.
+func NewBroker(t testing.TB, topics ...string) *Broker {
+	t.Helper()
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(1, topics...))
+	if err != nil {
+		t.Fatalf("starting fake kafka broker: %v", err)
+	}
+	t.Cleanup(cluster.Close)
+	return &Broker{cluster: cluster}
+}
+
+func (b *Broker) Addrs() []string {
+	return b.cluster.ListenAddrs()
+}
+
+// Client returns a client connected to the broker, closed when the test
+// finishes.
+func (b *Broker) Client(t testing.TB, opts ...kgo.Opt) *kgo.Client {
+	t.Helper()
+	client, err := kgo.NewClient(append([]kgo.Opt{kgo.SeedBrokers(b.Addrs()...)}, opts...)...)
+	if err != nil {
+		t.Fatalf("creating kafka client: %v", err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+// Consume reads the first n records of topic, failing the test if they do not
+// arrive within ConsumeTimeout.
+func (b *Broker) Consume(t testing.TB, topic string, n int) []*kgo.Record {
+	t.Helper()
+	client := b.Client(t,
+		kgo.ConsumeTopics(topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), ConsumeTimeout)
+	defer cancel()
+
+	records := []*kgo.Record{}
+	for len(records) < n {
+		fetches := client.PollFetches(ctx)
+		if ctx.Err() != nil {
+			t.Fatalf("consumed %d of %d records from %s before timing out", len(records), n, topic)
+		}
+		for _, err := range fetches.Errors() {
+			t.Fatalf("consuming from %s: %v", topic, err.Err)
+		}
+		records = append(records, fetches.Records()...)
+	}
+	return records
+}
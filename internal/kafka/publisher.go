@@ -0,0 +1,57 @@
+// Package kafka publishes the wallet's domain events to Kafka topics. Events
+// only reach it through the outbox relay, so they are serialized in one place.
+package kafka
+
+import (
+	"context"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Record headers set on every published event.
+const (
+	HeaderEventName = "event_name"
+	HeaderMessageID = "message_id"
+)
+
+// Publisher sends events as JSON records keyed by the event key, which is the
+// account ID for account, transaction and balance events. Records with the
+// same key land on the same partition, so consumers see the events of an
+// account in order.
+type Publisher struct {
+	Client *kgo.Client
+	// Topic receives the events whose name has no entry in Topics.
+	Topic  string
+	Topics map[string]string
+}
+
+func NewPublisher(client *kgo.Client, topic string) *Publisher {
+	return &Publisher{
+		Client: client,
+		Topic:  topic,
+		Topics: map[string]string{},
+	}
+}
+
+// Publish sends an outbox message and waits for the broker to acknowledge
+// it. It implements outbox.Publisher.
+func (p *Publisher) Publish(ctx context.Context, message *entity.OutboxMessage) error {
+	record := &kgo.Record{
+		Topic: p.topic(message.EventName),
+		Key:   []byte(message.Key),
+		Value: message.Payload,
+		Headers: []kgo.RecordHeader{
+			{Key: HeaderEventName, Value: []byte(message.EventName)},
+			{Key: HeaderMessageID, Value: []byte(message.ID)},
+		},
+	}
+	return p.Client.ProduceSync(ctx, record).FirstErr()
+}
+
+func (p *Publisher) topic(eventName string) string {
+	if topic, ok := p.Topics[eventName]; ok {
+		return topic
+	}
+	return p.Topic
+}
@@ -0,0 +1,139 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/kafka/kafkatest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/outbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type PublisherTestSuite struct {
+	suite.Suite
+	broker    *kafkatest.Broker
+	publisher *Publisher
+}
+
+func (s *PublisherTestSuite) SetupTest() {
+	s.broker = kafkatest.NewBroker(s.T(), "wallet", "balances", "transactions")
+	s.publisher = NewPublisher(s.broker.Client(s.T()), "wallet")
+	s.publisher.Topics[events.BalanceUpdatedName] = "balances"
+	s.publisher.Topics[events.TransactionCreatedName] = "transactions"
+}
+
+func TestPublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(PublisherTestSuite))
+}
+
+// publish writes events to an outbox and relays it, the way events reach
+// Kafka in production.
+func (s *PublisherTestSuite) publish(published ...events.Event) {
+	store := &memoryOutbox{}
+	s.Nil(outbox.Write(store, published...))
+	n, err := outbox.NewRelay(store, s.publisher).RelayBatch(context.Background())
+	s.Nil(err)
+	s.Equal(len(published), n)
+}
+
+func (s *PublisherTestSuite) TestPublishRoutesByName() {
+	balance := events.BalanceUpdated{AccountID: "account-1", Balance: "10.50", HeldAmount: "0.00", Currency: "BRL", Version: 2}
+	transaction := events.TransactionCreated{TransactionID: "transaction-1", Kind: "transfer", AccountIDFrom: "account-2", AccountIDTo: "account-1", Amount: "10.50", Currency: "BRL"}
+	account := events.AccountCreated{AccountID: "account-3", ClientID: "client-1", Currency: "BRL"}
+
+	s.publish(balance, transaction, account)
+
+	records := s.broker.Consume(s.T(), "balances", 1)
+	s.Equal("account-1", string(records[0].Key))
+	s.Equal(events.BalanceUpdatedName, headerValue(records[0], HeaderEventName))
+	s.NotEmpty(headerValue(records[0], HeaderMessageID))
+	var decoded events.BalanceUpdated
+	s.Nil(json.Unmarshal(records[0].Value, &decoded))
+	s.Equal(balance, decoded)
+
+	records = s.broker.Consume(s.T(), "transactions", 1)
+	s.Equal("account-2", string(records[0].Key))
+	s.Equal(events.TransactionCreatedName, headerValue(records[0], HeaderEventName))
+
+	records = s.broker.Consume(s.T(), "wallet", 1)
+	s.Equal("account-3", string(records[0].Key))
+	s.Equal(events.AccountCreatedName, headerValue(records[0], HeaderEventName))
+}
+
+func (s *PublisherTestSuite) TestRelayPublishesOutboxInOrder() {
+	store := &memoryOutbox{}
+	for _, balance := range []string{"1.00", "2.00", "3.00"} {
+		s.Nil(outbox.Write(store, events.BalanceUpdated{AccountID: "account-1", Balance: balance, Currency: "BRL"}))
+	}
+
+	n, err := outbox.NewRelay(store, s.publisher).RelayBatch(context.Background())
+	s.Nil(err)
+	s.Equal(3, n)
+
+	records := s.broker.Consume(s.T(), "balances", 3)
+	for i, balance := range []string{"1.00", "2.00", "3.00"} {
+		var decoded events.BalanceUpdated
+		s.Nil(json.Unmarshal(records[i].Value, &decoded))
+		s.Equal(balance, decoded.Balance)
+		s.Equal(store.messages[i].ID, headerValue(records[i], HeaderMessageID))
+	}
+}
+
+func TestPublishWithCancelledContext(t *testing.T) {
+	broker := kafkatest.NewBroker(t, "wallet")
+	publisher := NewPublisher(broker.Client(t), "wallet")
+
+	message, err := outbox.NewMessage(events.ClientCreated{ClientID: "client-1"})
+	assert.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, publisher.Publish(ctx, message))
+}
+
+func headerValue(record *kgo.Record, key string) string {
+	for _, header := range record.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// memoryOutbox is the smallest gateway.OutboxGateway the relay needs.
+type memoryOutbox struct {
+	messages []*entity.OutboxMessage
+}
+
+func (o *memoryOutbox) Save(messages []*entity.OutboxMessage) error {
+	o.messages = append(o.messages, messages...)
+	return nil
+}
+
+func (o *memoryOutbox) FindUnsent(limit int) ([]*entity.OutboxMessage, error) {
+	unsent := []*entity.OutboxMessage{}
+	for _, message := range o.messages {
+		if !message.IsSent() && len(unsent) < limit {
+			unsent = append(unsent, message)
+		}
+	}
+	return unsent, nil
+}
+
+func (o *memoryOutbox) MarkSent(id string, sentAt time.Time) error {
+	for _, message := range o.messages {
+		if message.ID == id {
+			message.SentAt = sentAt
+		}
+	}
+	return nil
+}
+
+func (o *memoryOutbox) RecordFailure(id string, cause string) error {
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package limits enforces amount and velocity limits on outgoing transfers
+// between accounts. Deposits and withdrawals move money in and out of the
+// wallet rather than between clients; they are neither checked nor counted.
+// Windows are sliding: a daily limit covers the 24 hours before the transfer,
+// not the calendar day.
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+var ErrInvalidRule = errors.New("invalid limit rule")
+
+// Scope selects whose transfers a rule aggregates.
+type Scope string
+
+const (
+	// ScopeAccount aggregates the transfers sent from the paying account.
+	ScopeAccount Scope = "account"
+	// ScopeClient aggregates the transfers sent from every account of the
+	// paying client.
+	ScopeClient Scope = "client"
+)
+
+// Rule is a single limit, identified by Name in the errors it produces. Each
+// of MaxAmount, MaxTotal and MaxCount is only enforced when set, and amount
+// limits only apply to transfers in their currency.
+type Rule struct {
+	Name  string
+	Scope Scope
+	// ID restricts the rule to one account or client, according to Scope.
+	// An empty ID applies the rule to all of them.
+	ID string
+
+	// MaxAmount bounds each transfer.
+	MaxAmount entity.Money
+
+	// MaxTotal and MaxCount bound the transfers sent over the last Window,
+	// including the one being checked.
+	Window   time.Duration
+	MaxTotal entity.Money
+	MaxCount int
+}
+
+func (r Rule) appliesTo(account *entity.Account) bool {
+	switch r.Scope {
+	case ScopeAccount:
+		return r.ID == "" || r.ID == account.ID
+	case ScopeClient:
+		return r.ID == "" || (account.Client != nil && r.ID == account.Client.ID)
+	}
+	return false
+}
+
+func (r Rule) validate() error {
+	if r.Scope != ScopeAccount && r.Scope != ScopeClient {
+		return fmt.Errorf("%w: rule %s has unknown scope %q", ErrInvalidRule, r.Name, r.Scope)
+	}
+	if (!r.MaxTotal.IsZero() || r.MaxCount > 0) && r.Window <= 0 {
+		return fmt.Errorf("%w: rule %s has no window", ErrInvalidRule, r.Name)
+	}
+	return nil
+}
+
+func (r Rule) volume(transactions gateway.TransactionGateway, account *entity.Account, currency string, since time.Time) (gateway.Volume, error) {
+	if r.Scope == ScopeClient {
+		return transactions.OutgoingVolumeByClient(account.Client.ID, currency, since)
+	}
+	return transactions.OutgoingVolumeByAccount(account.ID, currency, since)
+}
+
+func (r Rule) exceeded(format string, args ...any) error {
+	return &entity.LimitExceededError{Rule: r.Name, Message: fmt.Sprintf(format, args...)}
+}
+
+// Checker evaluates a set of rules against the transfers already recorded.
+type Checker struct {
+	Rules []Rule
+	Now   func() time.Time
+}
+
+func NewChecker(rules ...Rule) *Checker {
+	return &Checker{
+		Rules: rules,
+		Now:   time.Now,
+	}
+}
+
+// Check returns an *entity.LimitExceededError naming the first rule that
+// sending amount from account would breach. amount is what the transfer
+// moves, without any fee charged on top of it: fees are not counted in the
+// volumes either, so limits bound the money sent to others. The account is expected to be
+// locked by the unit of work; before adding up the transfers of its client,
+// Check locks the client as well, so that concurrent transfers from
+// different accounts of the client cannot each pass a client limit they
+// breach together.
+func (c *Checker) Check(transactions gateway.TransactionGateway, clients gateway.ClientGateway, account *entity.Account, amount entity.Money) error {
+	now := c.Now()
+	clientLocked := false
+	for _, rule := range c.Rules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+		if !rule.appliesTo(account) {
+			continue
+		}
+
+		if !rule.MaxAmount.IsZero() && rule.MaxAmount.Currency() == amount.Currency() {
+			cmp, err := amount.Cmp(rule.MaxAmount)
+			if err != nil {
+				return err
+			}
+			if cmp > 0 {
+				return rule.exceeded("%s is above the maximum of %s per transfer", amount, rule.MaxAmount)
+			}
+		}
+
+		checkTotal := !rule.MaxTotal.IsZero() && rule.MaxTotal.Currency() == amount.Currency()
+		if !checkTotal && rule.MaxCount == 0 {
+			continue
+		}
+
+		if rule.Scope == ScopeClient && !clientLocked {
+			if _, err := clients.GetForUpdate(account.Client.ID); err != nil {
+				return err
+			}
+			clientLocked = true
+		}
+		volume, err := rule.volume(transactions, account, amount.Currency(), now.Add(-rule.Window))
+		if err != nil {
+			return err
+		}
+
+		if checkTotal {
+			total, err := volume.Total.Add(amount)
+			if err != nil {
+				return err
+			}
+			cmp, err := total.Cmp(rule.MaxTotal)
+			if err != nil {
+				return err
+			}
+			if cmp > 0 {
+				return rule.exceeded("%s sent over %s would be above the maximum of %s", total, rule.Window, rule.MaxTotal)
+			}
+		}
+		if rule.MaxCount > 0 && volume.Count >= rule.MaxCount {
+			return rule.exceeded("%d transfers sent over %s already reached the maximum of %d", volume.Count, rule.Window, rule.MaxCount)
+		}
+	}
+	return nil
+}
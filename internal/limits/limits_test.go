@@ -0,0 +1,156 @@
+package limits
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type TransactionGatewayMock struct {
+	gateway.TransactionGateway
+	mock.Mock
+}
+
+func (m *TransactionGatewayMock) OutgoingVolumeByAccount(accountID, currency string, since time.Time) (gateway.Volume, error) {
+	args := m.Called(accountID, currency, since)
+	return args.Get(0).(gateway.Volume), args.Error(1)
+}
+
+func (m *TransactionGatewayMock) OutgoingVolumeByClient(clientID, currency string, since time.Time) (gateway.Volume, error) {
+	args := m.Called(clientID, currency, since)
+	return args.Get(0).(gateway.Volume), args.Error(1)
+}
+
+type ClientGatewayMock struct {
+	gateway.ClientGateway
+	mock.Mock
+}
+
+func (m *ClientGatewayMock) GetForUpdate(id string) (*entity.Client, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Client), args.Error(1)
+}
+
+func brl(minorUnits int64) entity.Money {
+	return entity.NewMoney(minorUnits, entity.DefaultCurrency)
+}
+
+func newChecker(now time.Time, rules ...Rule) *Checker {
+	checker := NewChecker(rules...)
+	checker.Now = func() time.Time { return now }
+	return checker
+}
+
+func newAccount() *entity.Account {
+	client, _ := entity.NewClient("John Doe", "john@example.com")
+	return entity.NewAccount(client)
+}
+
+func TestCheckMaxAmount(t *testing.T) {
+	account := newAccount()
+	transactions := &TransactionGatewayMock{}
+	checker := NewChecker(Rule{Name: "single", Scope: ScopeAccount, MaxAmount: brl(100_00)})
+
+	assert.NoError(t, checker.Check(transactions, &ClientGatewayMock{}, account, brl(100_00)))
+
+	err := checker.Check(transactions, &ClientGatewayMock{}, account, brl(100_01))
+	assert.ErrorIs(t, err, entity.ErrLimitExceeded)
+	var exceeded *entity.LimitExceededError
+	assert.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, "single", exceeded.Rule)
+
+	assert.NoError(t, checker.Check(transactions, &ClientGatewayMock{}, account, entity.NewMoney(500_00, "USD")))
+	transactions.AssertNotCalled(t, "OutgoingVolumeByAccount", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCheckDailyTotal(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	account := newAccount()
+	transactions := &TransactionGatewayMock{}
+	transactions.On("OutgoingVolumeByAccount", account.ID, entity.DefaultCurrency, now.Add(-24*time.Hour)).Return(gateway.Volume{Total: brl(900_00), Count: 3}, nil)
+	checker := newChecker(now, Rule{Name: "daily", Scope: ScopeAccount, Window: 24 * time.Hour, MaxTotal: brl(1000_00)})
+
+	assert.NoError(t, checker.Check(transactions, &ClientGatewayMock{}, account, brl(100_00)))
+
+	err := checker.Check(transactions, &ClientGatewayMock{}, account, brl(100_01))
+	var exceeded *entity.LimitExceededError
+	assert.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, "daily", exceeded.Rule)
+}
+
+func TestCheckMonthlyCountPerClient(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	account := newAccount()
+	transactions := &TransactionGatewayMock{}
+	transactions.On("OutgoingVolumeByClient", account.Client.ID, entity.DefaultCurrency, now.Add(-30*24*time.Hour)).Return(gateway.Volume{Total: brl(50_00), Count: 10}, nil).Once()
+	transactions.On("OutgoingVolumeByClient", account.Client.ID, entity.DefaultCurrency, now.Add(-30*24*time.Hour)).Return(gateway.Volume{Total: brl(50_00), Count: 9}, nil).Once()
+	clients := &ClientGatewayMock{}
+	clients.On("GetForUpdate", account.Client.ID).Return(account.Client, nil)
+	checker := newChecker(now, Rule{Name: "monthly", Scope: ScopeClient, Window: 30 * 24 * time.Hour, MaxCount: 10})
+
+	err := checker.Check(transactions, clients, account, brl(1_00))
+	assert.ErrorIs(t, err, entity.ErrLimitExceeded)
+
+	assert.NoError(t, checker.Check(transactions, clients, account, brl(1_00)))
+	clients.AssertNumberOfCalls(t, "GetForUpdate", 2)
+}
+
+func TestCheckLocksClientOnce(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	account := newAccount()
+	transactions := &TransactionGatewayMock{}
+	transactions.On("OutgoingVolumeByClient", account.Client.ID, entity.DefaultCurrency, mock.Anything).Return(gateway.Volume{Total: brl(50_00), Count: 1}, nil)
+	transactions.On("OutgoingVolumeByAccount", account.ID, entity.DefaultCurrency, mock.Anything).Return(gateway.Volume{Total: brl(50_00), Count: 1}, nil)
+	clients := &ClientGatewayMock{}
+	clients.On("GetForUpdate", account.Client.ID).Return(account.Client, nil)
+	checker := newChecker(now,
+		Rule{Name: "daily", Scope: ScopeClient, Window: 24 * time.Hour, MaxTotal: brl(1000_00)},
+		Rule{Name: "account", Scope: ScopeAccount, Window: 24 * time.Hour, MaxCount: 10},
+		Rule{Name: "monthly", Scope: ScopeClient, Window: 30 * 24 * time.Hour, MaxCount: 10},
+	)
+
+	assert.NoError(t, checker.Check(transactions, clients, account, brl(1_00)))
+	clients.AssertNumberOfCalls(t, "GetForUpdate", 1)
+}
+
+func TestCheckAccountRulesLeaveClientUnlocked(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	account := newAccount()
+	transactions := &TransactionGatewayMock{}
+	transactions.On("OutgoingVolumeByAccount", account.ID, entity.DefaultCurrency, mock.Anything).Return(gateway.Volume{Total: brl(50_00), Count: 1}, nil)
+	clients := &ClientGatewayMock{}
+	checker := newChecker(now, Rule{Name: "daily", Scope: ScopeAccount, Window: 24 * time.Hour, MaxTotal: brl(1000_00)})
+
+	assert.NoError(t, checker.Check(transactions, clients, account, brl(1_00)))
+	clients.AssertNotCalled(t, "GetForUpdate", mock.Anything)
+}
+
+func TestCheckSkipsRulesForOtherAccounts(t *testing.T) {
+	account := newAccount()
+	checker := NewChecker(
+		Rule{Name: "other account", Scope: ScopeAccount, ID: "other", MaxAmount: brl(1_00)},
+		Rule{Name: "other client", Scope: ScopeClient, ID: "other", MaxAmount: brl(1_00)},
+	)
+
+	assert.NoError(t, checker.Check(&TransactionGatewayMock{}, &ClientGatewayMock{}, account, brl(100_00)))
+
+	checker.Rules = append(checker.Rules, Rule{Name: "this client", Scope: ScopeClient, ID: account.Client.ID, MaxAmount: brl(1_00)})
+	assert.ErrorIs(t, checker.Check(&TransactionGatewayMock{}, &ClientGatewayMock{}, account, brl(100_00)), entity.ErrLimitExceeded)
+}
+
+func TestCheckWithInvalidRule(t *testing.T) {
+	account := newAccount()
+
+	err := NewChecker(Rule{Name: "daily", Scope: ScopeAccount, MaxTotal: brl(1_00)}).Check(&TransactionGatewayMock{}, &ClientGatewayMock{}, account, brl(1_00))
+	assert.ErrorIs(t, err, ErrInvalidRule)
+
+	err = NewChecker(Rule{Name: "single", MaxAmount: brl(1_00)}).Check(&TransactionGatewayMock{}, &ClientGatewayMock{}, account, brl(1_00))
+	assert.ErrorIs(t, err, ErrInvalidRule)
+}
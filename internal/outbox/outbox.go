@@ -0,0 +1,37 @@
+// Package outbox publishes domain events reliably: events are written as
+// outbox messages in the same transaction as the change that produced them,
+// and a Relay publishes the saved messages afterwards.
+package outbox
+
+import (
+	"encoding/json"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// NewMessage serializes event as JSON.
+func NewMessage(event events.Event) (*entity.OutboxMessage, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewOutboxMessage(event.EventName(), event.EventKey(), payload)
+}
+
+// Write saves events to outbox as messages, in order.
+func Write(outbox gateway.OutboxGateway, events ...events.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	messages := make([]*entity.OutboxMessage, 0, len(events))
+	for _, event := range events {
+		message, err := NewMessage(event)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	}
+	return outbox.Save(messages)
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// Publisher delivers a message to the outside world, such as a message
+// broker.
+type Publisher interface {
+	Publish(ctx context.Context, message *entity.OutboxMessage) error
+}
+
+// Publishers delivers each message to all of its publishers in turn,
+// stopping at the first failure. The relay retries a failed message on every
+// publisher, so those that already had it must tolerate duplicates.
+type Publishers []Publisher
+
+func (p Publishers) Publish(ctx context.Context, message *entity.OutboxMessage) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	DefaultBatchSize      = 100
+	DefaultPollInterval   = time.Second
+	DefaultInitialBackoff = time.Second
+	DefaultMaxBackoff     = time.Minute
+)
+
+// Relay publishes the unsent outbox messages in order. A message that fails
+// to publish blocks the ones saved after it, which are retried with it after
+// a backoff. Running more than one relay on the same outbox publishes
+// messages twice, so a single process must relay a shared outbox.
+type Relay struct {
+	Outbox         gateway.OutboxGateway
+	Publisher      Publisher
+	BatchSize      int
+	PollInterval   time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// OnError is called with the error of a failed batch. Failures do not
+	// stop the relay.
+	OnError func(err error)
+	Now     func() time.Time
+}
+
+func NewRelay(outbox gateway.OutboxGateway, publisher Publisher) *Relay {
+	return &Relay{
+		Outbox:         outbox,
+		Publisher:      publisher,
+		BatchSize:      DefaultBatchSize,
+		PollInterval:   DefaultPollInterval,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+		OnError:        func(error) {},
+		Now:            time.Now,
+	}
+}
+
+// RelayBatch publishes up to BatchSize unsent messages, stopping at the first
+// failure, and returns how many were published.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	messages, err := r.Outbox.FindUnsent(r.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, message := range messages {
+		err := r.Publisher.Publish(ctx, message)
+		if err != nil {
+			if recordErr := r.Outbox.RecordFailure(message.ID, err.Error()); recordErr != nil {
+				return i, recordErr
+			}
+			return i, err
+		}
+		err = r.Outbox.MarkSent(message.ID, r.Now())
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(messages), nil
+}
+
+// Run relays messages until ctx is done, returning ctx.Err(). Full batches
+// are followed immediately by the next one; otherwise the relay waits
+// PollInterval, or a backoff doubling from InitialBackoff up to MaxBackoff
+// after consecutive failures.
+func (r *Relay) Run(ctx context.Context) error {
+	var backoff time.Duration
+	for {
+		wait := r.PollInterval
+		n, err := r.RelayBatch(ctx)
+		switch {
+		case err != nil:
+			if ctx.Err() == nil {
+				r.OnError(err)
+			}
+			backoff = r.nextBackoff(backoff)
+			wait = backoff
+		case n == r.BatchSize:
+			backoff = 0
+			wait = 0
+		default:
+			backoff = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (r *Relay) nextBackoff(backoff time.Duration) time.Duration {
+	if backoff == 0 {
+		return r.InitialBackoff
+	}
+	backoff *= 2
+	if backoff > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+	return backoff
+}
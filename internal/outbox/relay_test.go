@@ -0,0 +1,197 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryOutbox keeps messages in a slice, in the order they were saved.
+type memoryOutbox struct {
+	mu       sync.Mutex
+	messages []*entity.OutboxMessage
+}
+
+func (o *memoryOutbox) Save(messages []*entity.OutboxMessage) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages = append(o.messages, messages...)
+	return nil
+}
+
+func (o *memoryOutbox) FindUnsent(limit int) ([]*entity.OutboxMessage, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	unsent := []*entity.OutboxMessage{}
+	for _, message := range o.messages {
+		if !message.IsSent() && len(unsent) < limit {
+			unsent = append(unsent, message)
+		}
+	}
+	return unsent, nil
+}
+
+func (o *memoryOutbox) MarkSent(id string, sentAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, message := range o.messages {
+		if message.ID == id {
+			message.SentAt = sentAt
+		}
+	}
+	return nil
+}
+
+func (o *memoryOutbox) RecordFailure(id string, cause string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, message := range o.messages {
+		if message.ID == id {
+			message.Attempts++
+			message.LastError = cause
+		}
+	}
+	return nil
+}
+
+// publisherStub fails the first failures calls, then records what it
+// publishes.
+type publisherStub struct {
+	mu        sync.Mutex
+	failures  int
+	calls     int
+	published []string
+}
+
+func (p *publisherStub) Publish(ctx context.Context, message *entity.OutboxMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.calls <= p.failures {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, message.Key)
+	return nil
+}
+
+func (p *publisherStub) Published() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.published...)
+}
+
+func newOutbox(t *testing.T, keys ...string) *memoryOutbox {
+	o := &memoryOutbox{}
+	for _, key := range keys {
+		assert.NoError(t, Write(o, events.BalanceUpdated{AccountID: key, Balance: "1.00", Currency: "BRL"}))
+	}
+	return o
+}
+
+func TestNewMessage(t *testing.T) {
+	event := events.BalanceUpdated{AccountID: "account-1", Balance: "10.50", Currency: "BRL", Version: 3}
+
+	message, err := NewMessage(event)
+
+	assert.NoError(t, err)
+	assert.Equal(t, events.BalanceUpdatedName, message.EventName)
+	assert.Equal(t, "account-1", message.Key)
+	var decoded events.BalanceUpdated
+	assert.NoError(t, json.Unmarshal(message.Payload, &decoded))
+	assert.Equal(t, event, decoded)
+}
+
+func TestRelayBatch(t *testing.T) {
+	o := newOutbox(t, "a", "b", "c")
+	publisher := &publisherStub{}
+	relay := NewRelay(o, publisher)
+
+	n, err := relay.RelayBatch(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{"a", "b", "c"}, publisher.Published())
+	unsent, _ := o.FindUnsent(10)
+	assert.Empty(t, unsent)
+}
+
+func TestRelayBatchStopsAtFirstFailure(t *testing.T) {
+	o := newOutbox(t, "a", "b")
+	publisher := &publisherStub{failures: 1}
+	relay := NewRelay(o, publisher)
+
+	n, err := relay.RelayBatch(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, 0, n)
+	assert.Empty(t, publisher.Published())
+	assert.Equal(t, 1, o.messages[0].Attempts)
+	assert.Equal(t, "broker unavailable", o.messages[0].LastError)
+
+	n, err = relay.RelayBatch(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"a", "b"}, publisher.Published())
+}
+
+func TestPublishers(t *testing.T) {
+	o := newOutbox(t, "a", "b")
+	first := &publisherStub{}
+	second := &publisherStub{failures: 1}
+	relay := NewRelay(o, Publishers{first, second})
+
+	_, err := relay.RelayBatch(context.Background())
+	assert.Error(t, err)
+	n, err := relay.RelayBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"a", "a", "b"}, first.Published())
+	assert.Equal(t, []string{"a", "b"}, second.Published())
+}
+
+func TestRelayRunRetriesWithBackoff(t *testing.T) {
+	o := newOutbox(t, "a", "b")
+	publisher := &publisherStub{failures: 3}
+	relay := NewRelay(o, publisher)
+	relay.PollInterval = time.Millisecond
+	relay.InitialBackoff = time.Millisecond
+	relay.MaxBackoff = 4 * time.Millisecond
+	var errs []error
+	relay.OnError = func(err error) { errs = append(errs, err) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- relay.Run(ctx) }()
+
+	assert.Eventually(t, func() bool {
+		return len(publisher.Published()) == 2
+	}, time.Second, time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, []string{"a", "b"}, publisher.Published())
+}
+
+func TestRelayBackoff(t *testing.T) {
+	relay := NewRelay(&memoryOutbox{}, &publisherStub{})
+	relay.InitialBackoff = time.Second
+	relay.MaxBackoff = 5 * time.Second
+
+	var backoffs []time.Duration
+	var backoff time.Duration
+	for i := 0; i < 5; i++ {
+		backoff = relay.nextBackoff(backoff)
+		backoffs = append(backoffs, backoff)
+	}
+
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, backoffs)
+}
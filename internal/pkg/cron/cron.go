@@ -0,0 +1,188 @@
+// Package cron parses standard five-field cron expressions
+// ("minute hour day-of-month month day-of-week") and computes their next
+// activation time.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidExpression = errors.New("invalid cron expression")
+
+// searchLimit bounds how far Next looks ahead, so impossible expressions such
+// as "0 0 30 2 *" terminate.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// Schedule is a parsed cron expression. Each field is a bitmask of the values
+// it matches.
+type Schedule struct {
+	expr       string
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// anyDay is set when either day field matches every value it can take;
+	// cron then matches on the other one only, otherwise a day matches if
+	// either field does.
+	anyDay bool
+}
+
+// Parse accepts "*", single values, ranges ("1-5"), steps ("*/15", "1-10/2")
+// and comma-separated lists of those in each field. Day of week runs from 0
+// (Sunday) to 6; 7 is accepted as Sunday.
+//
+// A day field restricts the days only when it leaves some of them out, so
+// "1-31" counts as "*" but "*/2" does not. When both day fields restrict,
+// a day matches if either of them does: "0 0 */2 * 1" runs on odd days and
+// on Mondays. This differs from Vixie cron, which treats any field starting
+// with "*" as unrestricted.
+func Parse(expr string) (*Schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%w: %q must have %d fields", ErrInvalidExpression, expr, len(fields))
+	}
+
+	masks := make([]uint64, len(fields))
+	for i, part := range parts {
+		f := fields[i]
+		if f.name == "day of week" {
+			f.max = 7
+		}
+		mask, err := parseField(part, f)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", ErrInvalidExpression, expr, err)
+		}
+		masks[i] = mask
+	}
+	if masks[4]&(1<<7) != 0 {
+		masks[4] = masks[4]&^(1<<7) | 1
+	}
+
+	return &Schedule{
+		expr:       strings.Join(parts, " "),
+		minute:     masks[0],
+		hour:       masks[1],
+		dayOfMonth: masks[2],
+		month:      masks[3],
+		dayOfWeek:  masks[4],
+		anyDay:     masks[2] == fields[2].all() || masks[4] == fields[4].all(),
+	}, nil
+}
+
+// all returns the mask of every value f can take.
+func (f field) all() uint64 {
+	return (1<<uint(f.max+1) - 1) &^ (1<<uint(f.min) - 1)
+}
+
+func parseField(s string, f field) (uint64, error) {
+	var mask uint64
+	for _, item := range strings.Split(s, ",") {
+		rangePart, step := item, 1
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			var err error
+			rangePart = item[:i]
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field: %q", f.name, item)
+			}
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = parseValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			if high, err = parseValue(bounds[1], f); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid range in %s field: %q", f.name, item)
+			}
+		default:
+			value, err := parseValue(rangePart, f)
+			if err != nil {
+				return 0, err
+			}
+			low = value
+			if step == 1 {
+				high = value
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+func parseValue(s string, f field) (int, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("%s must be between %d and %d, got %q", f.name, f.min, f.max, s)
+	}
+	return value, nil
+}
+
+// Next returns the first activation strictly after t, in t's location, or the
+// zero time if there is none within the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+
+	for next.Before(limit) {
+		if s.month&(1<<uint(next.Month())) == 0 {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if s.hour&(1<<uint(next.Hour())) == 0 {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if s.minute&(1<<uint(next.Minute())) == 0 {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dow := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.anyDay {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func (s *Schedule) String() string {
+	return s.expr
+}
@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	// 2024-01-10 is a Wednesday.
+	from := time.Date(2024, 1, 10, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 10, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 10, 10, 45, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2024, 1, 11, 9, 0, 0, 0, time.UTC)},
+		{"0 9 1 * *", time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 8 * * 1-5", time.Date(2024, 1, 11, 8, 0, 0, 0, time.UTC)},
+		{"0 8 * * 0", time.Date(2024, 1, 14, 8, 0, 0, 0, time.UTC)},
+		{"0 8 * * 7", time.Date(2024, 1, 14, 8, 0, 0, 0, time.UTC)},
+		{"0 12 15 * 5", time.Date(2024, 1, 12, 12, 0, 0, 0, time.UTC)},
+		{"30 10,18 * * *", time.Date(2024, 1, 10, 18, 30, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Day fields covering every value restrict nothing...
+		{"0 0 1-31 * 1", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 2-31/14 * 0-7", time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)},
+		// ...but steps skipping days do, so either day field matches.
+		{"0 0 */2 * *", time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{"0 0 */2 * 1", time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{"0 0 16 * */3", time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		schedule, err := Parse(tt.expr)
+		assert.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.expected, schedule.Next(from), tt.expr)
+	}
+}
+
+func TestSchedule_NextWithImpossibleDate(t *testing.T) {
+	schedule, err := Parse("0 0 30 2 *")
+	assert.NoError(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestParseWhenExpressionIsInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := Parse(expr)
+		assert.ErrorIs(t, err, ErrInvalidExpression, expr)
+	}
+}
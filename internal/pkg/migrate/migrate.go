@@ -0,0 +1,355 @@
+// Package migrate applies versioned SQL migrations and records them in a
+// migrations table.
+//
+// Migrations are read from pairs of files named
+// <version>_<name>.up.sql and <version>_<name>.down.sql, e.g.
+// 0001_create_clients.up.sql. Each file may hold several statements, each
+// ending with a semicolon at the end of a line.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTable is the table applied migrations are recorded in.
+const DefaultTable = "schema_migrations"
+
+var (
+	ErrInvalidMigration = errors.New("invalid migration")
+	ErrUnknownVersion   = errors.New("applied migration is unknown")
+	ErrLockNotAcquired  = errors.New("migration lock not acquired")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status reports whether a migration is applied. AppliedAt is zero for
+// pending migrations.
+type Status struct {
+	Migration Migration
+	AppliedAt time.Time
+}
+
+func (s Status) Applied() bool {
+	return !s.AppliedAt.IsZero()
+}
+
+// Load reads the migrations in the root of fsys, ordered by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: unexpected file name %s", ErrInvalidMigration, entry.Name())
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", ErrInvalidMigration, entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration := byVersion[version]
+		if migration == nil {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d is used by both %s and %s", ErrInvalidMigration, version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("%w: %d_%s needs both an up and a down file", ErrInvalidMigration, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Migrator applies Migrations to DB. Each migration runs in its own
+// transaction together with its record in Table, so a failing migration
+// leaves no trace, except on databases that commit DDL statements
+// implicitly. MySQL does: there, the statements of a failing migration that
+// ran before the failure stay applied while the migration is not recorded,
+// and the schema has to be repaired by hand before migrating again.
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []Migration
+	Table      string
+	Now        func() time.Time
+	// Placeholder returns the parameter placeholder for the nth argument
+	// of the queries recording migrations. It defaults to QuestionMark.
+	Placeholder func(n int) string
+	// Lock, when set, is held while Up and Down run, so that processes
+	// migrating the same database at once apply each migration only once.
+	Lock Locker
+}
+
+// Locker takes a lock shared by every client of the database on conn, a
+// connection set aside for it, and returns the function releasing it.
+type Locker func(ctx context.Context, conn *sql.Conn) (unlock func() error, err error)
+
+// MySQLLock returns a Locker taking the MySQL named lock name with GET_LOCK.
+func MySQLLock(name string) Locker {
+	return func(ctx context.Context, conn *sql.Conn) (func() error, error) {
+		var acquired sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, -1)", name).Scan(&acquired); err != nil {
+			return nil, err
+		}
+		if acquired.Int64 != 1 {
+			return nil, fmt.Errorf("%w: %s", ErrLockNotAcquired, name)
+		}
+		return func() error {
+			_, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", name)
+			return err
+		}, nil
+	}
+}
+
+// PostgresLock returns a Locker taking the PostgreSQL session-level advisory
+// lock key with pg_advisory_lock.
+func PostgresLock(key int64) Locker {
+	return func(ctx context.Context, conn *sql.Conn) (func() error, error) {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+			return nil, err
+		}
+		return func() error {
+			_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+			return err
+		}, nil
+	}
+}
+
+// QuestionMark is the placeholder of SQLite and MySQL.
+func QuestionMark(int) string {
+	return "?"
+}
+
+// Dollar is the placeholder of PostgreSQL: $1, $2, ...
+func Dollar(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+func NewMigrator(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		DB:          db,
+		Migrations:  migrations,
+		Table:       DefaultTable,
+		Now:         time.Now,
+		Placeholder: QuestionMark,
+	}, nil
+}
+
+// Up applies every pending migration in order and returns the ones applied.
+func (m *Migrator) Up(ctx context.Context) (applied []Migration, err error) {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied = []Migration{}
+	for _, status := range statuses {
+		if status.Applied() {
+			continue
+		}
+		err := m.run(ctx, status.Migration.Up, "INSERT INTO "+m.Table+" (version, name, applied_at) VALUES ("+m.placeholder(1)+", "+m.placeholder(2)+", "+m.placeholder(3)+")", status.Migration.Version, status.Migration.Name, m.Now().UTC())
+		if err != nil {
+			return applied, fmt.Errorf("applying %d_%s: %w", status.Migration.Version, status.Migration.Name, err)
+		}
+		applied = append(applied, status.Migration)
+	}
+	return applied, nil
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// the ones reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) (reverted []Migration, err error) {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, unlock())
+	}()
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reverted = []Migration{}
+	for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+		if !statuses[i].Applied() {
+			continue
+		}
+		migration := statuses[i].Migration
+		err := m.run(ctx, migration.Down, "DELETE FROM "+m.Table+" WHERE version = "+m.placeholder(1), migration.Version)
+		if err != nil {
+			return reverted, fmt.Errorf("reverting %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		reverted = append(reverted, migration)
+	}
+	return reverted, nil
+}
+
+// Status lists every migration, ordered by version, with the time it was
+// applied. It fails with ErrUnknownVersion when the database has a migration
+// applied that is not in Migrations.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.createTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.DB.QueryContext(ctx, "SELECT version, applied_at FROM "+m.Table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appliedAt := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		appliedAt[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.Migrations))
+	for _, migration := range m.Migrations {
+		statuses = append(statuses, Status{Migration: migration, AppliedAt: appliedAt[migration.Version]})
+		delete(appliedAt, migration.Version)
+	}
+	for version := range appliedAt {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) createTable(ctx context.Context) error {
+	_, err := m.DB.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+m.Table+" (version bigint PRIMARY KEY, name varchar(255) NOT NULL, applied_at timestamp NOT NULL)")
+	return err
+}
+
+// lock takes Lock, if set, on a connection of its own, which is returned to
+// the pool once the lock is released.
+func (m *Migrator) lock(ctx context.Context) (unlock func() error, err error) {
+	if m.Lock == nil {
+		return func() error { return nil }, nil
+	}
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	release, err := m.Lock(ctx, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return func() error {
+		return errors.Join(release(), conn.Close())
+	}, nil
+}
+
+func (m *Migrator) placeholder(n int) string {
+	if m.Placeholder == nil {
+		return QuestionMark(n)
+	}
+	return m.Placeholder(n)
+}
+
+// run executes script and the bookkeeping statement in one transaction.
+func (m *Migrator) run(ctx context.Context, script string, record string, args ...any) error {
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range Statements(script) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Statements splits script into the statements it holds. A statement ends
+// with a semicolon at the end of a line; lines starting with -- are
+// comments.
+func Statements(script string) []string {
+	statements := []string{}
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
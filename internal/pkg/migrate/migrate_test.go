@@ -0,0 +1,259 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/suite"
+	_ "modernc.org/sqlite"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"0001_create_items.up.sql":   {Data: []byte("CREATE TABLE items (name varchar(255));\n")},
+		"0001_create_items.down.sql": {Data: []byte("DROP TABLE items;\n")},
+		"0002_add_tags.up.sql": {Data: []byte(`-- tags belong to items
+CREATE TABLE tags (
+    item varchar(255),
+    tag varchar(255)
+);
+INSERT INTO items (name) VALUES ('seed');
+`)},
+		"0002_add_tags.down.sql": {Data: []byte("DROP TABLE tags;\nDELETE FROM items WHERE name = 'seed';\n")},
+		"README.md":              {Data: []byte("ignored")},
+	}
+}
+
+type MigratorTestSuite struct {
+	suite.Suite
+	db       *sql.DB
+	migrator *Migrator
+}
+
+func (s *MigratorTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite", ":memory:")
+	s.Nil(err)
+	db.SetMaxOpenConns(1)
+	s.db = db
+	s.migrator, err = NewMigrator(db, testFS())
+	s.Nil(err)
+}
+
+func (s *MigratorTestSuite) TearDownTest() {
+	s.db.Close()
+}
+
+func TestMigratorTestSuite(t *testing.T) {
+	suite.Run(t, new(MigratorTestSuite))
+}
+
+func (s *MigratorTestSuite) tableExists(name string) bool {
+	var count int
+	s.Nil(s.db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count))
+	return count == 1
+}
+
+func (s *MigratorTestSuite) TestUpAppliesPendingMigrationsOnce() {
+	ctx := context.Background()
+
+	applied, err := s.migrator.Up(ctx)
+	s.Nil(err)
+	s.Len(applied, 2)
+	s.Equal(1, applied[0].Version)
+	s.Equal("add_tags", applied[1].Name)
+	s.True(s.tableExists("tags"))
+
+	applied, err = s.migrator.Up(ctx)
+	s.Nil(err)
+	s.Empty(applied)
+
+	statuses, err := s.migrator.Status(ctx)
+	s.Nil(err)
+	s.Len(statuses, 2)
+	s.True(statuses[0].Applied())
+	s.True(statuses[1].Applied())
+}
+
+func (s *MigratorTestSuite) TestDownRevertsNewestFirst() {
+	ctx := context.Background()
+	_, err := s.migrator.Up(ctx)
+	s.Nil(err)
+
+	reverted, err := s.migrator.Down(ctx, 1)
+	s.Nil(err)
+	s.Len(reverted, 1)
+	s.Equal(2, reverted[0].Version)
+	s.False(s.tableExists("tags"))
+	s.True(s.tableExists("items"))
+
+	statuses, err := s.migrator.Status(ctx)
+	s.Nil(err)
+	s.True(statuses[0].Applied())
+	s.False(statuses[1].Applied())
+
+	reverted, err = s.migrator.Down(ctx, 5)
+	s.Nil(err)
+	s.Len(reverted, 1)
+	s.False(s.tableExists("items"))
+}
+
+func (s *MigratorTestSuite) TestFailedMigrationIsRolledBack() {
+	fsys := testFS()
+	fsys["0003_broken.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE broken (id int);\nNOT SQL;\n")}
+	fsys["0003_broken.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE broken;\n")}
+	migrator, err := NewMigrator(s.db, fsys)
+	s.Nil(err)
+
+	applied, err := migrator.Up(context.Background())
+	s.Error(err)
+	s.Len(applied, 2)
+	s.False(s.tableExists("broken"))
+
+	statuses, err := migrator.Status(context.Background())
+	s.Nil(err)
+	s.False(statuses[2].Applied())
+}
+
+func (s *MigratorTestSuite) TestStatusWithUnknownVersion() {
+	_, err := s.migrator.Up(context.Background())
+	s.Nil(err)
+
+	fsys := testFS()
+	delete(fsys, "0002_add_tags.up.sql")
+	delete(fsys, "0002_add_tags.down.sql")
+	migrator, err := NewMigrator(s.db, fsys)
+	s.Nil(err)
+
+	_, err = migrator.Status(context.Background())
+	s.ErrorIs(err, ErrUnknownVersion)
+}
+
+// mutexLock is a Locker standing in for a database lock, recording how many
+// times it was taken.
+type mutexLock struct {
+	mu    sync.Mutex
+	taken int
+}
+
+func (l *mutexLock) Lock(ctx context.Context, conn *sql.Conn) (func() error, error) {
+	l.mu.Lock()
+	l.taken++
+	return func() error {
+		l.mu.Unlock()
+		return nil
+	}, nil
+}
+
+func TestConcurrentUpAppliesEachMigrationOnce(t *testing.T) {
+	// The lock holds a connection of its own, so the database needs more
+	// than one; a shared cache lets them see the same in-memory database.
+	db, err := sql.Open("sqlite", "file:migrate-lock-test?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	lock := &mutexLock{}
+
+	var wg sync.WaitGroup
+	applied := make([][]Migration, 3)
+	errs := make([]error, 3)
+	for i := range applied {
+		migrator, err := NewMigrator(db, testFS())
+		if err != nil {
+			t.Fatal(err)
+		}
+		migrator.Lock = lock.Lock
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			applied[i], errs[i] = migrator.Up(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for i := range applied {
+		if errs[i] != nil {
+			t.Fatalf("Up: %v", errs[i])
+		}
+		total += len(applied[i])
+	}
+	if total != 2 {
+		t.Fatalf("applied %d migrations, want 2", total)
+	}
+	if lock.taken != 3 {
+		t.Fatalf("lock taken %d times, want 3", lock.taken)
+	}
+}
+
+func TestUpFailsWithoutLock(t *testing.T) {
+	db, err := sql.Open("sqlite", "file:migrate-lock-failure-test?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	migrator, err := NewMigrator(db, testFS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	failure := errors.New("lock timeout")
+	migrator.Lock = func(ctx context.Context, conn *sql.Conn) (func() error, error) {
+		return nil, failure
+	}
+
+	if _, err := migrator.Up(context.Background()); !errors.Is(err, failure) {
+		t.Fatalf("Up: got %v, want %v", err, failure)
+	}
+	if _, err := migrator.Down(context.Background(), 1); !errors.Is(err, failure) {
+		t.Fatalf("Down: got %v, want %v", err, failure)
+	}
+}
+
+func TestLoadRejectsInvalidMigrations(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"missing down": {
+			"0001_a.up.sql": {Data: []byte("SELECT 1;")},
+		},
+		"duplicate version": {
+			"0001_a.up.sql":   {Data: []byte("SELECT 1;")},
+			"0001_a.down.sql": {Data: []byte("SELECT 1;")},
+			"0001_b.up.sql":   {Data: []byte("SELECT 1;")},
+			"0001_b.down.sql": {Data: []byte("SELECT 1;")},
+		},
+		"bad name": {
+			"create_a.sql": {Data: []byte("SELECT 1;")},
+		},
+	}
+	for name, fsys := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestStatements(t *testing.T) {
+	statements := Statements("-- comment\nCREATE TABLE a (\n  id int\n);\n\nDROP TABLE b;\nSELECT 1")
+
+	if len(statements) != 3 {
+		t.Fatalf("got %d statements: %q", len(statements), statements)
+	}
+	if statements[0] != "CREATE TABLE a (\n  id int\n)" || statements[1] != "DROP TABLE b" || statements[2] != "SELECT 1" {
+		t.Fatalf("unexpected statements: %q", statements)
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	if got := QuestionMark(2); got != "?" {
+		t.Fatalf("QuestionMark(2) = %q", got)
+	}
+	if got := Dollar(2); got != "$2" {
+		t.Fatalf("Dollar(2) = %q", got)
+	}
+}
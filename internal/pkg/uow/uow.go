@@ -0,0 +1,122 @@
+package uow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrNoTransaction      = errors.New("no transaction in progress")
+	ErrRepositoryNotFound = errors.New("repository not found")
+)
+
+// RepositoryFactory builds a repository bound to the given SQL transaction.
+type RepositoryFactory func(tx *sql.Tx) interface{}
+
+// UnitOfWork groups the changes made through its repositories so they are
+// committed or rolled back together.
+type UnitOfWork interface {
+	// GetRepository returns the repository registered under name, bound to
+	// the transaction started by Do.
+	GetRepository(ctx context.Context, name string) (interface{}, error)
+	// Do runs fn inside a transaction, committing it when fn returns nil and
+	// rolling it back otherwise. Calling Do on the UnitOfWork received by fn
+	// joins the transaction already in progress.
+	Do(ctx context.Context, fn func(uow UnitOfWork) error) error
+}
+
+type Uow struct {
+	DB           *sql.DB
+	repositories map[string]RepositoryFactory
+	mu           sync.RWMutex
+}
+
+func NewUow(db *sql.DB) *Uow {
+	return &Uow{
+		DB:           db,
+		repositories: make(map[string]RepositoryFactory),
+	}
+}
+
+func (u *Uow) Register(name string, fc RepositoryFactory) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.repositories[name] = fc
+}
+
+func (u *Uow) UnRegister(name string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.repositories, name)
+}
+
+func (u *Uow) GetRepository(ctx context.Context, name string) (interface{}, error) {
+	return nil, ErrNoTransaction
+}
+
+func (u *Uow) Do(ctx context.Context, fn func(uow UnitOfWork) error) error {
+	tx, err := u.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(&txUow{parent: u, tx: tx}); err != nil {
+		if errRb := tx.Rollback(); errRb != nil {
+			return fmt.Errorf("original error: %w, rollback error: %s", err, errRb.Error())
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func (u *Uow) factory(name string) (RepositoryFactory, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	fc, ok := u.repositories[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRepositoryNotFound, name)
+	}
+	return fc, nil
+}
+
+// txUow is the UnitOfWork handed to the function given to Uow.Do. It is bound
+// to a single transaction, so concurrent calls to Do never share state.
+type txUow struct {
+	parent *Uow
+	tx     *sql.Tx
+}
+
+func (u *txUow) GetRepository(ctx context.Context, name string) (interface{}, error) {
+	fc, err := u.parent.factory(name)
+	if err != nil {
+		return nil, err
+	}
+	return fc(u.tx), nil
+}
+
+func (u *txUow) Do(ctx context.Context, fn func(uow UnitOfWork) error) error {
+	return fn(u)
+}
+
+// GetRepository returns the repository registered under name asserted to T.
+func GetRepository[T any](ctx context.Context, uow UnitOfWork, name string) (T, error) {
+	var zero T
+	repo, err := uow.GetRepository(ctx, name)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := repo.(T)
+	if !ok {
+		return zero, fmt.Errorf("repository %s has unexpected type %T", name, repo)
+	}
+	return typed, nil
+}
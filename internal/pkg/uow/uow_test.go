@@ -0,0 +1,147 @@
+package uow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	_ "modernc.org/sqlite"
+)
+
+type itemRepository struct {
+	tx *sql.Tx
+}
+
+func (r *itemRepository) Save(name string) error {
+	_, err := r.tx.Exec("INSERT INTO items (name) VALUES (?)", name)
+	return err
+}
+
+type UowTestSuite struct {
+	suite.Suite
+	db  *sql.DB
+	uow *Uow
+}
+
+func (s *UowTestSuite) SetupTest() {
+	db, err := sql.Open("sqlite", "file::memory:?cache=shared")
+	s.Nil(err)
+	s.db = db
+	db.Exec("CREATE TABLE items (name varchar(255))")
+	s.uow = NewUow(db)
+	s.uow.Register("ItemDB", func(tx *sql.Tx) interface{} {
+		return &itemRepository{tx: tx}
+	})
+}
+
+func (s *UowTestSuite) TearDownTest() {
+	defer s.db.Close()
+	s.db.Exec("DROP TABLE items")
+}
+
+func TestUowTestSuite(t *testing.T) {
+	suite.Run(t, new(UowTestSuite))
+}
+
+func (s *UowTestSuite) countItems() int {
+	var count int
+	s.Nil(s.db.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	return count
+}
+
+func (s *UowTestSuite) TestDoCommits() {
+	ctx := context.Background()
+	err := s.uow.Do(ctx, func(u UnitOfWork) error {
+		repo, err := GetRepository[*itemRepository](ctx, u, "ItemDB")
+		if err != nil {
+			return err
+		}
+		if err := repo.Save("first"); err != nil {
+			return err
+		}
+		return repo.Save("second")
+	})
+	s.Nil(err)
+	s.Equal(2, s.countItems())
+}
+
+func (s *UowTestSuite) TestDoRollsBackOnError() {
+	ctx := context.Background()
+	failure := errors.New("failure")
+	err := s.uow.Do(ctx, func(u UnitOfWork) error {
+		repo, err := GetRepository[*itemRepository](ctx, u, "ItemDB")
+		if err != nil {
+			return err
+		}
+		if err := repo.Save("first"); err != nil {
+			return err
+		}
+		return failure
+	})
+	s.ErrorIs(err, failure)
+	s.Equal(0, s.countItems())
+}
+
+func (s *UowTestSuite) TestDoRollsBackOnPanic() {
+	ctx := context.Background()
+	s.Panics(func() {
+		s.uow.Do(ctx, func(u UnitOfWork) error {
+			repo, _ := GetRepository[*itemRepository](ctx, u, "ItemDB")
+			repo.Save("first")
+			panic("boom")
+		})
+	})
+	s.Equal(0, s.countItems())
+}
+
+func (s *UowTestSuite) TestNestedDoJoinsTransaction() {
+	ctx := context.Background()
+	failure := errors.New("failure")
+	err := s.uow.Do(ctx, func(u UnitOfWork) error {
+		err := u.Do(ctx, func(inner UnitOfWork) error {
+			repo, err := GetRepository[*itemRepository](ctx, inner, "ItemDB")
+			if err != nil {
+				return err
+			}
+			return repo.Save("inner")
+		})
+		if err != nil {
+			return err
+		}
+		return failure
+	})
+	s.ErrorIs(err, failure)
+	s.Equal(0, s.countItems())
+}
+
+func (s *UowTestSuite) TestGetRepositoryOutsideDo() {
+	_, err := s.uow.GetRepository(context.Background(), "ItemDB")
+	s.ErrorIs(err, ErrNoTransaction)
+}
+
+func (s *UowTestSuite) TestGetRepositoryNotRegistered() {
+	ctx := context.Background()
+	err := s.uow.Do(ctx, func(u UnitOfWork) error {
+		_, err := u.GetRepository(ctx, "Unknown")
+		return err
+	})
+	s.ErrorIs(err, ErrRepositoryNotFound)
+
+	s.uow.UnRegister("ItemDB")
+	err = s.uow.Do(ctx, func(u UnitOfWork) error {
+		_, err := u.GetRepository(ctx, "ItemDB")
+		return err
+	})
+	s.ErrorIs(err, ErrRepositoryNotFound)
+}
+
+func (s *UowTestSuite) TestGetRepositoryWithWrongType() {
+	ctx := context.Background()
+	err := s.uow.Do(ctx, func(u UnitOfWork) error {
+		_, err := GetRepository[string](ctx, u, "ItemDB")
+		return err
+	})
+	s.Error(err)
+}
@@ -0,0 +1,139 @@
+package posting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+)
+
+// Poster is the persistence path shared by every use case that moves money:
+// it loads accounts and writes a committed transaction, the new balances and
+// the ledger entries through the same unit of work. The events for
+// everything posted are recorded by the embedded Recorder.
+type Poster struct {
+	Accounts     gateway.AccountGateway
+	Transactions gateway.TransactionGateway
+	Ledger       gateway.LedgerGateway
+	*Recorder
+}
+
+func NewPoster(ctx context.Context, u uow.UnitOfWork) (*Poster, error) {
+	accounts, err := uow.GetRepository[gateway.AccountGateway](ctx, u, gateway.AccountRepository)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := uow.GetRepository[gateway.TransactionGateway](ctx, u, gateway.TransactionRepository)
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := uow.GetRepository[gateway.LedgerGateway](ctx, u, gateway.LedgerRepository)
+	if err != nil {
+		return nil, err
+	}
+	recorder, err := NewRecorder(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	return &Poster{
+		Accounts:     accounts,
+		Transactions: transactions,
+		Ledger:       ledger,
+		Recorder:     recorder,
+	}, nil
+}
+
+// FindAccount loads an account, translating gateway.ErrNotFound into
+// entity.ErrAccountNotFound.
+func (p *Poster) FindAccount(id string) (*entity.Account, error) {
+	return findAccount(p.Accounts.FindByID, id)
+}
+
+// FindAccounts loads and locks the accounts with the given IDs, returned in
+// the same order. Accounts are locked in ascending ID order, so units of
+// work locking the same accounts lock them in the same order and cannot
+// deadlock. An ID given twice gets the same account.
+func (p *Poster) FindAccounts(ids ...string) ([]*entity.Account, error) {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	found := make(map[string]*entity.Account, len(sorted))
+	for _, id := range slices.Compact(sorted) {
+		account, err := findAccount(p.Accounts.FindByIDForUpdate, id)
+		if err != nil {
+			return nil, err
+		}
+		found[id] = account
+	}
+	accounts := make([]*entity.Account, len(ids))
+	for i, id := range ids {
+		accounts[i] = found[id]
+	}
+	return accounts, nil
+}
+
+func findAccount(find func(id string) (*entity.Account, error), id string) (*entity.Account, error) {
+	account, err := find(id)
+	if err != nil {
+		if errors.Is(err, gateway.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrAccountNotFound, id)
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+// Post persists a transaction already committed in memory by
+// entity.NewTransaction.
+func (p *Poster) Post(transaction *entity.Transaction) error {
+	if err := p.Accounts.UpdateBalance(transaction.AccountFrom); err != nil {
+		return err
+	}
+	if err := p.Accounts.UpdateBalance(transaction.AccountTo); err != nil {
+		return err
+	}
+	if err := p.Transactions.Save(transaction); err != nil {
+		return err
+	}
+	if err := p.Ledger.Save(transaction.LedgerEntries()); err != nil {
+		return err
+	}
+	return p.Record(
+		events.NewTransactionCreated(transaction),
+		events.NewBalanceUpdated(transaction.AccountFrom),
+		events.NewBalanceUpdated(transaction.AccountTo),
+	)
+}
+
+// SaveAccount persists a new account and records its AccountCreated event,
+// followed by a BalanceUpdated so the balance projection holds the opening
+// balance before the first transaction.
+func (p *Poster) SaveAccount(account *entity.Account) error {
+	if err := p.Accounts.Save(account); err != nil {
+		return err
+	}
+	return p.Record(events.NewAccountCreated(account), events.NewBalanceUpdated(account))
+}
+
+// UpdateBalance persists the balance of an account changed without a
+// transaction, such as by a hold, and records its BalanceUpdated event.
+func (p *Poster) UpdateBalance(account *entity.Account) error {
+	if err := p.Accounts.UpdateBalance(account); err != nil {
+		return err
+	}
+	return p.Record(events.NewBalanceUpdated(account))
+}
+
+// UpdateOverdraftLimit persists the overdraft limit of an account and records
+// a BalanceUpdated carrying the version the change was written with, so the
+// balance projection stays in step with the account.
+func (p *Poster) UpdateOverdraftLimit(account *entity.Account) error {
+	if err := p.Accounts.UpdateOverdraftLimit(account); err != nil {
+		return err
+	}
+	return p.Record(events.NewBalanceUpdated(account))
+}
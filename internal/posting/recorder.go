@@ -0,0 +1,39 @@
+package posting
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/outbox"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+)
+
+// Recorder collects the events of a unit of work in Events, to be dispatched
+// once it commits. When the unit of work has an outbox repository, the
+// events are also written to the outbox as part of it.
+type Recorder struct {
+	Outbox gateway.OutboxGateway
+	Events []events.Event
+}
+
+func NewRecorder(ctx context.Context, u uow.UnitOfWork) (*Recorder, error) {
+	outbox, err := uow.GetRepository[gateway.OutboxGateway](ctx, u, gateway.OutboxRepository)
+	if err != nil && !errors.Is(err, uow.ErrRepositoryNotFound) {
+		return nil, err
+	}
+	return &Recorder{Outbox: outbox}, nil
+}
+
+// Record writes events to the outbox, when there is one, and adds them to
+// Events.
+func (r *Recorder) Record(recorded ...events.Event) error {
+	if r.Outbox != nil {
+		if err := outbox.Write(r.Outbox, recorded...); err != nil {
+			return err
+		}
+	}
+	r.Events = append(r.Events, recorded...)
+	return nil
+}
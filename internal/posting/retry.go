@@ -0,0 +1,31 @@
+package posting
+
+import (
+	"errors"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// DefaultMaxAttempts bounds how many times Retry runs a unit of work whose
+// accounts are modified concurrently.
+const DefaultMaxAttempts = 3
+
+// Retry runs attempt until it succeeds, fails with an error other than
+// gateway.ErrConflict, or has run maxAttempts times. The events of the
+// attempt that succeeded are sent to dispatcher, if set.
+func Retry[T any](maxAttempts int, dispatcher events.Dispatcher, attempt func() (T, []events.Event, error)) (T, error) {
+	for n := 1; ; n++ {
+		output, pending, err := attempt()
+		if err == nil {
+			if dispatcher != nil {
+				dispatcher.Dispatch(pending...)
+			}
+			return output, nil
+		}
+		if !errors.Is(err, gateway.ErrConflict) || n >= maxAttempts {
+			var zero T
+			return zero, err
+		}
+	}
+}
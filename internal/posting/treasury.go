@@ -0,0 +1,80 @@
+package posting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+)
+
+// ErrTreasuryAccountNotConfigured reports a treasury account ID that is empty
+// or names no account. It is a server misconfiguration, so it deliberately
+// does not match entity.ErrAccountNotFound.
+var ErrTreasuryAccountNotConfigured = errors.New("treasury account not configured")
+
+// PostTreasury posts, in a unit of work of u, the transaction newTransaction
+// builds between the treasury account and the client account with the given
+// IDs. It is how money enters and leaves the wallet: deposits and
+// withdrawals differ only in the transaction they build. The client account
+// is returned with its new balance, along with the events to dispatch once
+// the unit of work commits. A missing treasury account is reported as
+// ErrTreasuryAccountNotConfigured.
+func PostTreasury(ctx context.Context, u uow.UnitOfWork, treasuryAccountID, accountID string, newTransaction func(treasury, account *entity.Account) (*entity.Transaction, error)) (*entity.Transaction, *entity.Account, []events.Event, error) {
+	if treasuryAccountID == "" {
+		return nil, nil, nil, ErrTreasuryAccountNotConfigured
+	}
+
+	var transaction *entity.Transaction
+	var account *entity.Account
+	var pending []events.Event
+	err := u.Do(ctx, func(u uow.UnitOfWork) error {
+		poster, err := NewPoster(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		accounts, err := poster.FindAccounts(treasuryAccountID, accountID)
+		if err != nil {
+			if errors.Is(err, entity.ErrAccountNotFound) {
+				return checkTreasuryAccount(poster.Accounts, treasuryAccountID, err)
+			}
+			return err
+		}
+
+		transaction, err = newTransaction(accounts[0], accounts[1])
+		if err != nil {
+			return err
+		}
+
+		err = poster.Post(transaction)
+		if err != nil {
+			return err
+		}
+
+		account = accounts[1]
+		pending = poster.Events
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return transaction, account, pending, nil
+}
+
+// checkTreasuryAccount turns notFound into ErrTreasuryAccountNotConfigured
+// when the missing account is the treasury account rather than the client's.
+func checkTreasuryAccount(accounts gateway.AccountGateway, treasuryAccountID string, notFound error) error {
+	_, err := accounts.FindByID(treasuryAccountID)
+	if errors.Is(err, gateway.ErrNotFound) {
+		return fmt.Errorf("%w: %s", ErrTreasuryAccountNotConfigured, treasuryAccountID)
+	}
+	if err != nil {
+		return err
+	}
+	return notFound
+}
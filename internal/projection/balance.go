@@ -0,0 +1,100 @@
+// Package projection maintains read models built from the wallet's events.
+package projection
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// BalanceProjection keeps the balances table up to date from BalanceUpdated
+// and TransactionCreated events. Events can arrive more than once and out of
+// order; the gateway only keeps the newest state, so applying them is
+// idempotent.
+type BalanceProjection struct {
+	Balances gateway.BalanceGateway
+	// OnError is called when Handle fails to apply an event.
+	OnError func(err error)
+}
+
+func NewBalanceProjection(balances gateway.BalanceGateway) *BalanceProjection {
+	return &BalanceProjection{
+		Balances: balances,
+		OnError:  func(error) {},
+	}
+}
+
+// Apply updates the projection with event. Events of other kinds are ignored.
+func (p *BalanceProjection) Apply(event events.Event) error {
+	switch e := event.(type) {
+	case events.BalanceUpdated:
+		return p.applyBalance(e)
+	case events.TransactionCreated:
+		return p.applyTransaction(e)
+	}
+	return nil
+}
+
+// ApplyMessage decodes a serialized event, as published by the outbox relay,
+// and applies it.
+func (p *BalanceProjection) ApplyMessage(eventName string, payload []byte) error {
+	switch eventName {
+	case events.BalanceUpdatedName:
+		var event events.BalanceUpdated
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return err
+		}
+		return p.applyBalance(event)
+	case events.TransactionCreatedName:
+		var event events.TransactionCreated
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return err
+		}
+		return p.applyTransaction(event)
+	}
+	return nil
+}
+
+// Publish applies an outbox message, so that an outbox.Relay can feed the
+// projection in-process.
+func (p *BalanceProjection) Publish(ctx context.Context, message *entity.OutboxMessage) error {
+	return p.ApplyMessage(message.EventName, message.Payload)
+}
+
+// Handle lets the projection be registered on an events.EventDispatcher.
+func (p *BalanceProjection) Handle(event events.Event) {
+	if err := p.Apply(event); err != nil {
+		p.OnError(err)
+	}
+}
+
+func (p *BalanceProjection) applyBalance(event events.BalanceUpdated) error {
+	balance, err := entity.ParseMoney(event.Balance, event.Currency)
+	if err != nil {
+		return err
+	}
+	held, err := entity.ParseMoney(event.HeldAmount, event.Currency)
+	if err != nil {
+		return err
+	}
+	return p.Balances.ApplyBalance(&entity.AccountBalance{
+		AccountID:  event.AccountID,
+		Balance:    balance,
+		HeldAmount: held,
+		Version:    event.Version,
+		UpdatedAt:  event.UpdatedAt,
+	})
+}
+
+func (p *BalanceProjection) applyTransaction(event events.TransactionCreated) error {
+	for _, accountID := range []string{event.AccountIDFrom, event.AccountIDTo} {
+		err := p.Balances.ApplyTransaction(accountID, event.Currency, event.TransactionID, event.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
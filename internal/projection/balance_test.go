@@ -0,0 +1,113 @@
+package projection
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/outbox"
+	"github.com/stretchr/testify/suite"
+)
+
+type BalanceProjectionTestSuite struct {
+	suite.Suite
+	db         *sql.DB
+	balances   *database.BalanceDB
+	projection *BalanceProjection
+	client     *entity.Client
+}
+
+func (s *BalanceProjectionTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+	s.balances = database.NewBalanceDB(db)
+	s.projection = NewBalanceProjection(s.balances)
+	s.client, _ = entity.NewClient("John Doe", "john@example.com")
+}
+
+func TestBalanceProjectionTestSuite(t *testing.T) {
+	suite.Run(t, new(BalanceProjectionTestSuite))
+}
+
+func (s *BalanceProjectionTestSuite) transfer(from, to *entity.Account, minorUnits int64) []events.Event {
+	transaction, err := entity.NewTransaction(from, to, entity.NewMoney(minorUnits, entity.DefaultCurrency))
+	s.Nil(err)
+	from.Version++
+	to.Version++
+	return []events.Event{
+		events.NewTransactionCreated(transaction),
+		events.NewBalanceUpdated(from),
+		events.NewBalanceUpdated(to),
+	}
+}
+
+func (s *BalanceProjectionTestSuite) TestApplyOutOfOrderAndDuplicated() {
+	from := entity.NewAccount(s.client)
+	from.Credit(entity.NewMoney(100_00, entity.DefaultCurrency))
+	to := entity.NewAccount(s.client)
+
+	first := s.transfer(from, to, 30_00)
+	second := s.transfer(from, to, 20_00)
+
+	for _, event := range append(append(second, first...), second...) {
+		s.Nil(s.projection.Apply(event))
+	}
+
+	balance, err := s.balances.FindByAccountID(from.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(50_00, entity.DefaultCurrency), balance.Balance)
+	s.Equal(from.Version, balance.Version)
+	s.Equal(second[0].(events.TransactionCreated).TransactionID, balance.LastTransactionID)
+
+	balance, err = s.balances.FindByAccountID(to.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(50_00, entity.DefaultCurrency), balance.Balance)
+}
+
+func (s *BalanceProjectionTestSuite) TestApplyMessage() {
+	account := entity.NewAccount(s.client)
+	account.Credit(entity.NewMoney(12_34, entity.DefaultCurrency))
+	account.Version = 1
+	payload, _ := json.Marshal(events.NewBalanceUpdated(account))
+
+	s.Nil(s.projection.ApplyMessage(events.BalanceUpdatedName, payload))
+	s.Nil(s.projection.ApplyMessage(events.ClientCreatedName, []byte(`{}`)))
+	s.Error(s.projection.ApplyMessage(events.BalanceUpdatedName, []byte(`not json`)))
+
+	balance, err := s.balances.FindByAccountID(account.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(12_34, entity.DefaultCurrency), balance.Balance)
+}
+
+func (s *BalanceProjectionTestSuite) TestRelayedFromOutbox() {
+	from := entity.NewAccount(s.client)
+	from.Credit(entity.NewMoney(100_00, entity.DefaultCurrency))
+	to := entity.NewAccount(s.client)
+	messages := database.NewOutboxDB(s.db)
+	s.Nil(outbox.Write(messages, s.transfer(from, to, 30_00)...))
+
+	n, err := outbox.NewRelay(messages, s.projection).RelayBatch(context.Background())
+	s.Nil(err)
+	s.Equal(3, n)
+
+	balance, err := s.balances.FindByAccountID(to.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(30_00, entity.DefaultCurrency), balance.Balance)
+	unsent, err := messages.FindUnsent(10)
+	s.Nil(err)
+	s.Empty(unsent)
+}
+
+func (s *BalanceProjectionTestSuite) TestHandleReportsErrors() {
+	var reported error
+	s.projection.OnError = func(err error) { reported = err }
+
+	s.projection.Handle(events.BalanceUpdated{AccountID: "account-1", Balance: "oops", Currency: entity.DefaultCurrency})
+
+	s.Error(reported)
+}
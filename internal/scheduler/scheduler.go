@@ -0,0 +1,45 @@
+// Package scheduler runs background jobs, such as executing scheduled
+// transfers or expiring holds, at a fixed interval.
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Job is one run of a background task.
+type Job func(ctx context.Context) error
+
+type Scheduler struct {
+	Job      Job
+	Interval time.Duration
+	// OnError is called with the error of a failed run. Failed runs do not
+	// stop the scheduler.
+	OnError func(err error)
+}
+
+func NewScheduler(job Job, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		Job:      job,
+		Interval: interval,
+		OnError:  func(error) {},
+	}
+}
+
+// Run runs the job immediately and then every Interval until ctx is done,
+// returning ctx.Err(). Runs never overlap.
+func (s *Scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Job(ctx); err != nil && ctx.Err() == nil {
+			s.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := 0
+	var reported []error
+
+	s := NewScheduler(func(ctx context.Context) error {
+		runs++
+		if runs == 3 {
+			cancel()
+		}
+		if runs == 2 {
+			return errors.New("boom")
+		}
+		return nil
+	}, time.Millisecond)
+	s.OnError = func(err error) {
+		reported = append(reported, err)
+	}
+
+	err := s.Run(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 3, runs)
+	assert.Len(t, reported, 1)
+	assert.EqualError(t, reported[0], "boom")
+}
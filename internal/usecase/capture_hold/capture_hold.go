@@ -0,0 +1,109 @@
+package capturehold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+// CaptureHoldInputDTO settles a hold as a transfer to AccountIDTo. A zero
+// Amount captures the whole hold; the uncaptured part is released.
+type CaptureHoldInputDTO struct {
+	HoldID      string
+	AccountIDTo string
+	Amount      entity.Money
+}
+
+type CaptureHoldOutputDTO struct {
+	HoldID         string
+	TransactionID  string
+	CapturedAmount entity.Money
+}
+
+// CaptureHoldUseCase transfers held money. Events are sent to Dispatcher, if
+// set, once the capture is committed.
+type CaptureHoldUseCase struct {
+	Uow         uow.UnitOfWork
+	MaxAttempts int
+	Dispatcher  events.Dispatcher
+}
+
+func NewCaptureHoldUseCase(uow uow.UnitOfWork) *CaptureHoldUseCase {
+	return &CaptureHoldUseCase{
+		Uow:         uow,
+		MaxAttempts: posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *CaptureHoldUseCase) Execute(ctx context.Context, input CaptureHoldInputDTO) (*CaptureHoldOutputDTO, error) {
+	return posting.Retry(uc.MaxAttempts, uc.Dispatcher, func() (*CaptureHoldOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
+}
+
+func (uc *CaptureHoldUseCase) execute(ctx context.Context, input CaptureHoldInputDTO) (*CaptureHoldOutputDTO, []events.Event, error) {
+	output := &CaptureHoldOutputDTO{}
+	var pending []events.Event
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		poster, err := posting.NewPoster(ctx, u)
+		if err != nil {
+			return err
+		}
+		holdRepository, err := uow.GetRepository[gateway.HoldGateway](ctx, u, gateway.HoldRepository)
+		if err != nil {
+			return err
+		}
+
+		hold, err := holdRepository.FindByID(input.HoldID)
+		if err != nil {
+			if errors.Is(err, gateway.ErrNotFound) {
+				return fmt.Errorf("%w: %s", entity.ErrHoldNotFound, input.HoldID)
+			}
+			return err
+		}
+
+		accounts, err := poster.FindAccounts(hold.AccountID, input.AccountIDTo)
+		if err != nil {
+			return err
+		}
+		accountFrom, accountTo := accounts[0], accounts[1]
+
+		amount := input.Amount
+		if amount.IsZero() {
+			amount = hold.Amount
+		}
+
+		transaction, err := hold.Capture(accountFrom, accountTo, amount, time.Now())
+		if err != nil {
+			return err
+		}
+
+		err = poster.Post(transaction)
+		if err != nil {
+			return err
+		}
+
+		err = holdRepository.Update(hold)
+		if err != nil {
+			return err
+		}
+
+		output.HoldID = hold.ID
+		output.TransactionID = transaction.ID
+		output.CapturedAmount = hold.CapturedAmount
+		pending = poster.Events
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return output, pending, nil
+}
@@ -0,0 +1,94 @@
+package capturehold
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaptureHoldUseCase_Execute(t *testing.T) {
+	store, account, merchant := memorytest.NewStore(t, 100_00, 0)
+	hold := memorytest.PlaceHold(t, store, account.ID, 60_00, time.Now().Add(time.Hour))
+
+	uc := NewCaptureHoldUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), CaptureHoldInputDTO{
+		HoldID:      hold.ID,
+		AccountIDTo: merchant.ID,
+		Amount:      entity.NewMoney(45_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, hold.ID, output.HoldID)
+	assert.Equal(t, entity.NewMoney(45_00, entity.DefaultCurrency), output.CapturedAmount)
+
+	captured, err := memory.NewHoldGateway(store).FindByID(hold.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.HoldCaptured, captured.Status)
+	assert.Equal(t, captured.TransactionID, output.TransactionID)
+	account = memorytest.FindAccount(t, store, account.ID)
+	assert.Equal(t, entity.NewMoney(55_00, entity.DefaultCurrency), account.Balance)
+	assert.True(t, account.HeldAmount.IsZero())
+	assert.Equal(t, entity.NewMoney(45_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, merchant.ID).Balance)
+
+	transaction, err := memory.NewTransactionGateway(store).FindByID(output.TransactionID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.NewMoney(45_00, entity.DefaultCurrency), transaction.Amount)
+	entries, err := memory.NewLedgerGateway(store).FindByAccountID(merchant.ID)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestCaptureHoldUseCase_ExecuteFullAmount(t *testing.T) {
+	store, account, merchant := memorytest.NewStore(t, 100_00, 0)
+	hold := memorytest.PlaceHold(t, store, account.ID, 60_00, time.Now().Add(time.Hour))
+
+	uc := NewCaptureHoldUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), CaptureHoldInputDTO{
+		HoldID:      hold.ID,
+		AccountIDTo: merchant.ID,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, entity.NewMoney(60_00, entity.DefaultCurrency), output.CapturedAmount)
+	assert.Equal(t, entity.NewMoney(40_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, account.ID).Balance)
+}
+
+func TestCaptureHoldUseCase_ExecuteMoreThanHeld(t *testing.T) {
+	store, account, merchant := memorytest.NewStore(t, 100_00, 0)
+	hold := memorytest.PlaceHold(t, store, account.ID, 60_00, time.Now().Add(time.Hour))
+
+	uc := NewCaptureHoldUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), CaptureHoldInputDTO{
+		HoldID:      hold.ID,
+		AccountIDTo: merchant.ID,
+		Amount:      entity.NewMoney(60_01, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrCaptureExceedsHold)
+	stored, err := memory.NewHoldGateway(store).FindByID(hold.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.HoldActive, stored.Status)
+	transactions, err := memory.NewTransactionGateway(store).FindByAccountID(account.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, transactions)
+}
+
+func TestCaptureHoldUseCase_ExecuteWithHoldNotFound(t *testing.T) {
+	store, _, merchant := memorytest.NewStore(t, 100_00, 0)
+
+	uc := NewCaptureHoldUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), CaptureHoldInputDTO{HoldID: "unknown", AccountIDTo: merchant.ID})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrHoldNotFound)
+}
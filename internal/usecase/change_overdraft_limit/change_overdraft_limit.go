@@ -0,0 +1,104 @@
+package changeoverdraftlimit
+
+import (
+	"context"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+// ChangeOverdraftLimitInputDTO sets the overdraft limit of an account.
+// ChangedBy identifies who made the change and is recorded with Reason in
+// the audit trail.
+type ChangeOverdraftLimitInputDTO struct {
+	AccountID string
+	Limit     entity.Money
+	ChangedBy string
+	Reason    string
+}
+
+type ChangeOverdraftLimitOutputDTO struct {
+	AccountID     string
+	PreviousLimit entity.Money
+	Limit         entity.Money
+	ChangeID      string
+}
+
+// ChangeOverdraftLimitUseCase sets the overdraft limit of an account and
+// records the change in the audit trail. Events are sent to Dispatcher, if
+// set, once the change is committed.
+type ChangeOverdraftLimitUseCase struct {
+	Uow         uow.UnitOfWork
+	MaxAttempts int
+	Dispatcher  events.Dispatcher
+}
+
+func NewChangeOverdraftLimitUseCase(uow uow.UnitOfWork) *ChangeOverdraftLimitUseCase {
+	return &ChangeOverdraftLimitUseCase{
+		Uow:         uow,
+		MaxAttempts: posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *ChangeOverdraftLimitUseCase) Execute(ctx context.Context, input ChangeOverdraftLimitInputDTO) (*ChangeOverdraftLimitOutputDTO, error) {
+	return posting.Retry(uc.MaxAttempts, uc.Dispatcher, func() (*ChangeOverdraftLimitOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
+}
+
+func (uc *ChangeOverdraftLimitUseCase) execute(ctx context.Context, input ChangeOverdraftLimitInputDTO) (*ChangeOverdraftLimitOutputDTO, []events.Event, error) {
+	output := &ChangeOverdraftLimitOutputDTO{}
+	var pending []events.Event
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		poster, err := posting.NewPoster(ctx, u)
+		if err != nil {
+			return err
+		}
+		changeRepository, err := uow.GetRepository[gateway.OverdraftLimitChangeGateway](ctx, u, gateway.OverdraftLimitChangeRepository)
+		if err != nil {
+			return err
+		}
+
+		accounts, err := poster.FindAccounts(input.AccountID)
+		if err != nil {
+			return err
+		}
+		account := accounts[0]
+
+		previousLimit := account.OverdraftLimit
+		err = account.SetOverdraftLimit(input.Limit)
+		if err != nil {
+			return err
+		}
+
+		change, err := entity.NewOverdraftLimitChange(account.ID, previousLimit, account.OverdraftLimit, input.ChangedBy, input.Reason)
+		if err != nil {
+			return err
+		}
+
+		err = poster.UpdateOverdraftLimit(account)
+		if err != nil {
+			return err
+		}
+
+		err = changeRepository.Save(change)
+		if err != nil {
+			return err
+		}
+
+		output.AccountID = account.ID
+		output.PreviousLimit = previousLimit
+		output.Limit = account.OverdraftLimit
+		output.ChangeID = change.ID
+		pending = poster.Events
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return output, pending, nil
+}
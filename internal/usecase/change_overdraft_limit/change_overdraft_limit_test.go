@@ -0,0 +1,155 @@
+package changeoverdraftlimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type DispatcherMock struct {
+	mock.Mock
+}
+
+func (m *DispatcherMock) Dispatch(events ...events.Event) {
+	m.Called(events)
+}
+
+func TestChangeOverdraftLimitUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+
+	uc := NewChangeOverdraftLimitUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), ChangeOverdraftLimitInputDTO{
+		AccountID: account.ID,
+		Limit:     entity.NewMoney(500_00, entity.DefaultCurrency),
+		ChangedBy: "ops@example.com",
+		Reason:    "credit review",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, output.AccountID)
+	assert.Equal(t, entity.Zero(entity.DefaultCurrency), output.PreviousLimit)
+	assert.Equal(t, entity.NewMoney(500_00, entity.DefaultCurrency), output.Limit)
+	assert.NotEmpty(t, output.ChangeID)
+	assert.Equal(t, entity.NewMoney(500_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, account.ID).OverdraftLimit)
+
+	changes, err := memory.NewOverdraftLimitChangeGateway(store).FindByAccountID(account.ID)
+	assert.Nil(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, output.ChangeID, changes[0].ID)
+	assert.Equal(t, "ops@example.com", changes[0].ChangedBy)
+	assert.Equal(t, "credit review", changes[0].Reason)
+}
+
+func TestChangeOverdraftLimitUseCase_ExecuteEmitsBalanceUpdated(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+	dispatcher := &DispatcherMock{}
+	dispatcher.On("Dispatch", mock.Anything)
+
+	uc := NewChangeOverdraftLimitUseCase(memory.NewUow(store))
+	uc.Dispatcher = dispatcher
+
+	_, err := uc.Execute(context.Background(), ChangeOverdraftLimitInputDTO{
+		AccountID: account.ID,
+		Limit:     entity.NewMoney(500_00, entity.DefaultCurrency),
+		ChangedBy: "ops@example.com",
+	})
+
+	assert.Nil(t, err)
+	dispatcher.AssertNumberOfCalls(t, "Dispatch", 1)
+	dispatched := dispatcher.Calls[0].Arguments.Get(0).([]events.Event)
+	assert.Len(t, dispatched, 1)
+	updated := dispatched[0].(events.BalanceUpdated)
+	assert.Equal(t, account.ID, updated.AccountID)
+	assert.Equal(t, 1, updated.Version)
+
+	messages, err := memory.NewOutboxGateway(store).FindUnsent(-1)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, events.BalanceUpdatedName, messages[0].EventName)
+	assert.Equal(t, account.ID, messages[0].Key)
+}
+
+func TestChangeOverdraftLimitUseCase_ExecuteBelowOverdraftInUse(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+	assert.Nil(t, account.SetOverdraftLimit(entity.NewMoney(100_00, entity.DefaultCurrency)))
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateOverdraftLimit(account))
+	assert.Nil(t, account.Debit(entity.NewMoney(80_00, entity.DefaultCurrency)))
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateBalance(account))
+
+	uc := NewChangeOverdraftLimitUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), ChangeOverdraftLimitInputDTO{
+		AccountID: account.ID,
+		Limit:     entity.NewMoney(50_00, entity.DefaultCurrency),
+		ChangedBy: "ops@example.com",
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrOverdraftLimitTooLow)
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, account.ID).OverdraftLimit)
+	changes, err := memory.NewOverdraftLimitChangeGateway(store).FindByAccountID(account.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestChangeOverdraftLimitUseCase_ExecuteWithoutChangedBy(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+
+	uc := NewChangeOverdraftLimitUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), ChangeOverdraftLimitInputDTO{
+		AccountID: account.ID,
+		Limit:     entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, output)
+	var validationErr *entity.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "changed_by", validationErr.Field)
+	assert.True(t, memorytest.FindAccount(t, store, account.ID).OverdraftLimit.IsZero())
+}
+
+func TestChangeOverdraftLimitUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	uc := NewChangeOverdraftLimitUseCase(memory.NewUow(memory.NewStore()))
+
+	output, err := uc.Execute(context.Background(), ChangeOverdraftLimitInputDTO{
+		AccountID: "unknown",
+		Limit:     entity.NewMoney(50_00, entity.DefaultCurrency),
+		ChangedBy: "ops@example.com",
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
+
+func TestChangeOverdraftLimitUseCase_ExecuteRetriesOnConflict(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+	conflicts := 1
+
+	uc := NewChangeOverdraftLimitUseCase(memorytest.WithConflicts(memory.NewUow(store), &conflicts))
+
+	output, err := uc.Execute(context.Background(), ChangeOverdraftLimitInputDTO{
+		AccountID: account.ID,
+		Limit:     entity.NewMoney(500_00, entity.DefaultCurrency),
+		ChangedBy: "ops@example.com",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, conflicts)
+	changes, err := memory.NewOverdraftLimitChangeGateway(store).FindByAccountID(account.ID)
+	assert.Nil(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, output.ChangeID, changes[0].ID)
+}
@@ -0,0 +1,100 @@
+package closeaccount
+
+import (
+	"context"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+// CloseAccountInputDTO identifies the account to close. When the account
+// still holds funds, SweepAccountID names the account that receives them;
+// without it only accounts with a zero balance can be closed.
+type CloseAccountInputDTO struct {
+	AccountID      string
+	SweepAccountID string
+}
+
+type CloseAccountOutputDTO struct {
+	ID                 string
+	Status             string
+	SweepTransactionID string
+}
+
+// CloseAccountUseCase closes an account, sweeping its funds first when asked
+// to. Events are sent to Dispatcher, if set, once the account is closed.
+type CloseAccountUseCase struct {
+	Uow         uow.UnitOfWork
+	MaxAttempts int
+	Dispatcher  events.Dispatcher
+}
+
+func NewCloseAccountUseCase(uow uow.UnitOfWork) *CloseAccountUseCase {
+	return &CloseAccountUseCase{
+		Uow:         uow,
+		MaxAttempts: posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *CloseAccountUseCase) Execute(ctx context.Context, input CloseAccountInputDTO) (*CloseAccountOutputDTO, error) {
+	return posting.Retry(uc.MaxAttempts, uc.Dispatcher, func() (*CloseAccountOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
+}
+
+func (uc *CloseAccountUseCase) execute(ctx context.Context, input CloseAccountInputDTO) (*CloseAccountOutputDTO, []events.Event, error) {
+	output := &CloseAccountOutputDTO{}
+	var pending []events.Event
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		poster, err := posting.NewPoster(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		ids := []string{input.AccountID}
+		if input.SweepAccountID != "" {
+			ids = append(ids, input.SweepAccountID)
+		}
+		accounts, err := poster.FindAccounts(ids...)
+		if err != nil {
+			return err
+		}
+		account := accounts[0]
+
+		if account.Balance.IsPositive() && input.SweepAccountID != "" {
+			sweepAccount := accounts[1]
+			transaction, err := entity.NewTransaction(account, sweepAccount, account.Balance)
+			if err != nil {
+				return err
+			}
+
+			err = poster.Post(transaction)
+			if err != nil {
+				return err
+			}
+			output.SweepTransactionID = transaction.ID
+		}
+
+		err = account.Close()
+		if err != nil {
+			return err
+		}
+
+		err = poster.Accounts.UpdateStatus(account)
+		if err != nil {
+			return err
+		}
+
+		output.ID = account.ID
+		output.Status = string(account.Status)
+		pending = poster.Events
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return output, pending, nil
+}
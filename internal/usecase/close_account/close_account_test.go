@@ -0,0 +1,112 @@
+package closeaccount
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloseAccountUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+
+	uc := NewCloseAccountUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), CloseAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, output.ID)
+	assert.Equal(t, "closed", output.Status)
+	assert.Empty(t, output.SweepTransactionID)
+	assert.Equal(t, entity.AccountClosed, memorytest.FindAccount(t, store, account.ID).Status)
+}
+
+func TestCloseAccountUseCase_ExecuteWithBalanceAndNoSweepAccount(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 10_00)
+
+	uc := NewCloseAccountUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), CloseAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountHasBalance)
+	assert.Equal(t, entity.AccountActive, memorytest.FindAccount(t, store, account.ID).Status)
+}
+
+func TestCloseAccountUseCase_ExecuteWithSweepAccount(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 10_00)
+	sweepAccount := memorytest.NewAccount(t, store, "John Doe", 0)
+
+	uc := NewCloseAccountUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), CloseAccountInputDTO{
+		AccountID:      account.ID,
+		SweepAccountID: sweepAccount.ID,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "closed", output.Status)
+	assert.NotEmpty(t, output.SweepTransactionID)
+	closed := memorytest.FindAccount(t, store, account.ID)
+	assert.True(t, closed.Balance.IsZero())
+	assert.Equal(t, entity.AccountClosed, closed.Status)
+	assert.Equal(t, entity.NewMoney(10_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, sweepAccount.ID).Balance)
+
+	sweep, err := memory.NewTransactionGateway(store).FindByID(output.SweepTransactionID)
+	assert.Nil(t, err)
+	assert.Equal(t, sweepAccount.ID, sweep.AccountTo.ID)
+	entries, err := memory.NewLedgerGateway(store).FindByAccountID(sweepAccount.ID)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestCloseAccountUseCase_ExecuteWithFrozenAccount(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+	assert.Nil(t, account.Freeze())
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateStatus(account))
+
+	uc := NewCloseAccountUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), CloseAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrInvalidStatusTransition)
+}
+
+func TestCloseAccountUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	uc := NewCloseAccountUseCase(memory.NewUow(memory.NewStore()))
+
+	output, err := uc.Execute(context.Background(), CloseAccountInputDTO{AccountID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
+
+func TestCloseAccountUseCase_ExecuteRetriesOnConflict(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 10_00)
+	sweepAccount := memorytest.NewAccount(t, store, "John Doe", 0)
+	conflicts := 1
+
+	uc := NewCloseAccountUseCase(memorytest.WithConflicts(memory.NewUow(store), &conflicts))
+
+	output, err := uc.Execute(context.Background(), CloseAccountInputDTO{
+		AccountID:      account.ID,
+		SweepAccountID: sweepAccount.ID,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "closed", output.Status)
+	assert.Equal(t, 0, conflicts)
+	assert.Equal(t, entity.NewMoney(10_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, sweepAccount.ID).Balance)
+	entries, err := memory.NewLedgerGateway(store).FindByAccountID(sweepAccount.ID)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -1,8 +1,15 @@
 package createaccount
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
 	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
 )
 
 type CreateAccountInputDTO struct {
@@ -13,31 +20,59 @@ type CreateAccountOutputDTO struct {
 	ID string
 }
 
+// CreateAccountUseCase opens an account for a client. When the unit of work
+// has an outbox repository, an AccountCreated event is written to the outbox
+// along with the account. Dispatcher, if set, receives the event once the
+// account is saved.
 type CreateAccountUseCase struct {
-	AccountGateway gateway.AccountGateway
-	ClientGateway  gateway.ClientGateway
+	Uow        uow.UnitOfWork
+	Dispatcher events.Dispatcher
 }
 
-func NewCreateAccountUseCase(accountGateway gateway.AccountGateway, clientGateway gateway.ClientGateway) *CreateAccountUseCase {
+func NewCreateAccountUseCase(uow uow.UnitOfWork) *CreateAccountUseCase {
 	return &CreateAccountUseCase{
-		AccountGateway: accountGateway,
-		ClientGateway:  clientGateway,
+		Uow: uow,
 	}
 }
 
-func (uc *CreateAccountUseCase) Execute(input CreateAccountInputDTO) (*CreateAccountOutputDTO, error) {
-	client, err := uc.ClientGateway.Get(input.ClientID)
+func (uc *CreateAccountUseCase) Execute(ctx context.Context, input CreateAccountInputDTO) (*CreateAccountOutputDTO, error) {
+	var created []events.Event
+	output := &CreateAccountOutputDTO{}
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		clientRepository, err := uow.GetRepository[gateway.ClientGateway](ctx, u, gateway.ClientRepository)
+		if err != nil {
+			return err
+		}
+		poster, err := posting.NewPoster(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		client, err := clientRepository.Get(input.ClientID)
+		if err != nil {
+			if errors.Is(err, gateway.ErrNotFound) {
+				return fmt.Errorf("%w: %s", entity.ErrClientNotFound, input.ClientID)
+			}
+			return err
+		}
+
+		account := entity.NewAccount(client)
+		err = poster.SaveAccount(account)
+		if err != nil {
+			return err
+		}
+
+		output.ID = account.ID
+		created = poster.Events
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	account := entity.NewAccount(client)
-	err = uc.AccountGateway.Save(account)
-	if err != nil {
-		return nil, err
+	if uc.Dispatcher != nil {
+		uc.Dispatcher.Dispatch(created...)
 	}
 
-	return &CreateAccountOutputDTO{
-		ID: account.ID,
-	}, nil
+	return output, nil
 }
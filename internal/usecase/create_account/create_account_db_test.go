@@ -0,0 +1,67 @@
+package createaccount
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/stretchr/testify/suite"
+)
+
+type CreateAccountDBTestSuite struct {
+	suite.Suite
+	db *sql.DB
+	uc *CreateAccountUseCase
+}
+
+func (s *CreateAccountDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+	u := uow.NewUow(db)
+	u.Register(gateway.ClientRepository, func(tx *sql.Tx) interface{} {
+		return database.NewClientDB(tx)
+	})
+	u.Register(gateway.AccountRepository, func(tx *sql.Tx) interface{} {
+		return database.NewAccountDB(tx)
+	})
+	u.Register(gateway.TransactionRepository, func(tx *sql.Tx) interface{} {
+		return database.NewTransactionDB(tx)
+	})
+	u.Register(gateway.LedgerRepository, func(tx *sql.Tx) interface{} {
+		return database.NewLedgerDB(tx)
+	})
+	u.Register(gateway.OutboxRepository, func(tx *sql.Tx) interface{} {
+		return database.NewOutboxDB(tx)
+	})
+	s.uc = NewCreateAccountUseCase(u)
+}
+
+func TestCreateAccountDBTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateAccountDBTestSuite))
+}
+
+func (s *CreateAccountDBTestSuite) TestExecute() {
+	client, _ := entity.NewClient("John Doe", "john@example.com")
+	s.Nil(database.NewClientDB(s.db).Save(client))
+
+	output, err := s.uc.Execute(context.Background(), CreateAccountInputDTO{ClientID: client.ID})
+	s.Nil(err)
+	s.NotEmpty(output.ID)
+
+	messages, err := database.NewOutboxDB(s.db).FindUnsent(10)
+	s.Nil(err)
+	s.Len(messages, 2)
+	s.Equal(output.ID, messages[0].Key)
+	s.Equal(output.ID, messages[1].Key)
+}
+
+func (s *CreateAccountDBTestSuite) TestExecuteWithUnknownClient() {
+	output, err := s.uc.Execute(context.Background(), CreateAccountInputDTO{ClientID: "unknown"})
+	s.Nil(output)
+	s.ErrorIs(err, entity.ErrClientNotFound)
+}
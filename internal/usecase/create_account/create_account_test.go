@@ -1,152 +1,150 @@
 package createaccount
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-type AccountGatewayMock struct {
-	mock.Mock
+type failingAccounts struct {
+	gateway.AccountGateway
 }
 
-func (m *AccountGatewayMock) Save(account *entity.Account) error {
-	args := m.Called(account)
-	return args.Error(0)
+func (failingAccounts) Save(account *entity.Account) error {
+	return errors.New("database error")
 }
 
-func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
-	args := m.Called(id)
-	return args.Get(0).(*entity.Account), args.Error(1)
+// failingUow hands out the repositories of store, but an account repository
+// failing to save.
+type failingUow struct {
+	store *memory.Store
 }
 
-type ClientGatewayMock struct {
-	mock.Mock
+func (u failingUow) GetRepository(ctx context.Context, name string) (interface{}, error) {
+	switch name {
+	case gateway.ClientRepository:
+		return memory.NewClientGateway(u.store), nil
+	case gateway.AccountRepository:
+		return failingAccounts{}, nil
+	case gateway.TransactionRepository:
+		return memory.NewTransactionGateway(u.store), nil
+	case gateway.LedgerRepository:
+		return memory.NewLedgerGateway(u.store), nil
+	case gateway.OutboxRepository:
+		return memory.NewOutboxGateway(u.store), nil
+	}
+	return nil, uow.ErrRepositoryNotFound
 }
 
-func (m *ClientGatewayMock) Get(id string) (*entity.Client, error) {
-	args := m.Called(id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Client), args.Error(1)
+func (u failingUow) Do(ctx context.Context, fn func(uow uow.UnitOfWork) error) error {
+	return fn(u)
+}
+
+type DispatcherMock struct {
+	mock.Mock
 }
 
-func (m *ClientGatewayMock) Save(client *entity.Client) error {
-	args := m.Called(client)
-	return args.Error(0)
+func (m *DispatcherMock) Dispatch(events ...events.Event) {
+	m.Called(events)
 }
 
 func TestCreateAccountUseCase_Execute(t *testing.T) {
-	client, _ := entity.NewClient("John Doe", "john@example.com")
+	store := memory.NewStore()
+	client := memorytest.NewClient(t, store, "John Doe")
 
-	accountGateway := &AccountGatewayMock{}
-	clientGateway := &ClientGatewayMock{}
+	uc := NewCreateAccountUseCase(memory.NewUow(store))
 
-	clientGateway.On("Get", "123").Return(client, nil)
-	accountGateway.On("Save", mock.Anything).Return(nil)
+	output, err := uc.Execute(context.Background(), CreateAccountInputDTO{ClientID: client.ID})
 
-	uc := NewCreateAccountUseCase(accountGateway, clientGateway)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, output.ID)
+	account, err := memory.NewAccountGateway(store).FindByID(output.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, client.ID, account.Client.ID)
+	assert.True(t, account.Balance.IsZero())
+}
 
-	input := CreateAccountInputDTO{
-		ClientID: "123",
-	}
+func TestCreateAccountUseCase_ExecuteWritesAccountCreatedToOutbox(t *testing.T) {
+	store := memory.NewStore()
+	client := memorytest.NewClient(t, store, "John Doe")
 
-	output, err := uc.Execute(input)
+	output, err := NewCreateAccountUseCase(memory.NewUow(store)).Execute(context.Background(), CreateAccountInputDTO{ClientID: client.ID})
 
 	assert.Nil(t, err)
-	assert.NotNil(t, output)
-	assert.NotEmpty(t, output.ID)
-
-	accountGateway.AssertExpectations(t)
-	clientGateway.AssertExpectations(t)
-	accountGateway.AssertNumberOfCalls(t, "Save", 1)
-	clientGateway.AssertNumberOfCalls(t, "Get", 1)
+	messages, err := memory.NewOutboxGateway(store).FindUnsent(-1)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, events.AccountCreatedName, messages[0].EventName)
+	assert.Equal(t, events.BalanceUpdatedName, messages[1].EventName)
+	for _, message := range messages {
+		assert.Equal(t, output.ID, message.Key)
+	}
 }
 
 func TestCreateAccountUseCase_ExecuteWithClientNotFound(t *testing.T) {
-	accountGateway := &AccountGatewayMock{}
-	clientGateway := &ClientGatewayMock{}
+	store := memory.NewStore()
 
-	clientGateway.On("Get", "123").Return(nil, errors.New("client not found"))
+	uc := NewCreateAccountUseCase(memory.NewUow(store))
 
-	uc := NewCreateAccountUseCase(accountGateway, clientGateway)
+	for _, clientID := range []string{"123", ""} {
+		output, err := uc.Execute(context.Background(), CreateAccountInputDTO{ClientID: clientID})
 
-	input := CreateAccountInputDTO{
-		ClientID: "123",
+		assert.Nil(t, output)
+		assert.ErrorIs(t, err, entity.ErrClientNotFound)
 	}
-
-	output, err := uc.Execute(input)
-
-	assert.NotNil(t, err)
-	assert.Nil(t, output)
-	assert.Equal(t, "client not found", err.Error())
-
-	clientGateway.AssertExpectations(t)
-	clientGateway.AssertNumberOfCalls(t, "Get", 1)
-	accountGateway.AssertNumberOfCalls(t, "Save", 0)
 }
 
 func TestCreateAccountUseCase_ExecuteWithAccountGatewayError(t *testing.T) {
-	client, _ := entity.NewClient("John Doe", "john@example.com")
-
-	accountGateway := &AccountGatewayMock{}
-	clientGateway := &ClientGatewayMock{}
-
-	clientGateway.On("Get", "123").Return(client, nil)
-	accountGateway.On("Save", mock.Anything).Return(errors.New("database error"))
+	store := memory.NewStore()
+	client := memorytest.NewClient(t, store, "John Doe")
 
-	uc := NewCreateAccountUseCase(accountGateway, clientGateway)
-
-	input := CreateAccountInputDTO{
-		ClientID: "123",
-	}
+	uc := NewCreateAccountUseCase(failingUow{store: store})
 
-	output, err := uc.Execute(input)
+	output, err := uc.Execute(context.Background(), CreateAccountInputDTO{ClientID: client.ID})
 
-	assert.NotNil(t, err)
 	assert.Nil(t, output)
-	assert.Equal(t, "database error", err.Error())
-
-	accountGateway.AssertExpectations(t)
-	clientGateway.AssertExpectations(t)
-	accountGateway.AssertNumberOfCalls(t, "Save", 1)
-	clientGateway.AssertNumberOfCalls(t, "Get", 1)
+	assert.EqualError(t, err, "database error")
+	messages, err := memory.NewOutboxGateway(store).FindUnsent(-1)
+	assert.Nil(t, err)
+	assert.Empty(t, messages)
 }
 
-func TestCreateAccountUseCase_ExecuteWithEmptyClientID(t *testing.T) {
-	accountGateway := &AccountGatewayMock{}
-	clientGateway := &ClientGatewayMock{}
-
-	clientGateway.On("Get", "").Return(nil, errors.New("client id is required"))
-
-	uc := NewCreateAccountUseCase(accountGateway, clientGateway)
-
-	input := CreateAccountInputDTO{
-		ClientID: "",
-	}
+func TestNewCreateAccountUseCase(t *testing.T) {
+	u := memory.NewUow(memory.NewStore())
 
-	output, err := uc.Execute(input)
+	uc := NewCreateAccountUseCase(u)
 
-	assert.NotNil(t, err)
-	assert.Nil(t, output)
-	assert.Equal(t, "client id is required", err.Error())
-
-	clientGateway.AssertExpectations(t)
-	clientGateway.AssertNumberOfCalls(t, "Get", 1)
-	accountGateway.AssertNumberOfCalls(t, "Save", 0)
+	assert.NotNil(t, uc)
+	assert.Equal(t, u, uc.Uow)
 }
 
-func TestNewCreateAccountUseCase(t *testing.T) {
-	accountGateway := &AccountGatewayMock{}
-	clientGateway := &ClientGatewayMock{}
+func TestCreateAccountUseCase_ExecuteDispatchesAccountCreated(t *testing.T) {
+	store := memory.NewStore()
+	client := memorytest.NewClient(t, store, "John Doe")
+	dispatcher := &DispatcherMock{}
+	dispatcher.On("Dispatch", mock.Anything)
 
-	uc := NewCreateAccountUseCase(accountGateway, clientGateway)
+	uc := NewCreateAccountUseCase(memory.NewUow(store))
+	uc.Dispatcher = dispatcher
 
-	assert.NotNil(t, uc)
-	assert.Equal(t, accountGateway, uc.AccountGateway)
-	assert.Equal(t, clientGateway, uc.ClientGateway)
+	output, err := uc.Execute(context.Background(), CreateAccountInputDTO{ClientID: client.ID})
+
+	assert.Nil(t, err)
+	dispatched := dispatcher.Calls[0].Arguments.Get(0).([]events.Event)
+	assert.Len(t, dispatched, 2)
+	event := dispatched[0].(events.AccountCreated)
+	assert.Equal(t, output.ID, event.AccountID)
+	assert.Equal(t, client.ID, event.ClientID)
+	balance := dispatched[1].(events.BalanceUpdated)
+	assert.Equal(t, output.ID, balance.AccountID)
+	assert.Equal(t, 0, balance.Version)
 }
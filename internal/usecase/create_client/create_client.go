@@ -1,8 +1,13 @@
 package createclient
 
 import (
+	"context"
+
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
 	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
 )
 
 type CreateClientInputDTO struct {
@@ -14,35 +19,67 @@ type CreateClientOutputDTO struct {
 	ID        string
 	Name      string
 	Email     string
+	Tier      string
 	CreatedAt string
 	UpdatedAt string
 }
 
+// CreateClientUseCase registers a client. When the unit of work has an outbox
+// repository, a ClientCreated event is written to the outbox along with the
+// client. Dispatcher, if set, receives the event once the client is saved.
 type CreateClientUseCase struct {
-	ClientGateway gateway.ClientGateway
+	Uow        uow.UnitOfWork
+	Dispatcher events.Dispatcher
 }
 
-func NewCreateClientUseCase(clientGateway gateway.ClientGateway) *CreateClientUseCase {
+func NewCreateClientUseCase(uow uow.UnitOfWork) *CreateClientUseCase {
 	return &CreateClientUseCase{
-		ClientGateway: clientGateway,
+		Uow: uow,
 	}
 }
 
-func (uc *CreateClientUseCase) Execute(input CreateClientInputDTO) (*CreateClientOutputDTO, error) {
+func (uc *CreateClientUseCase) Execute(ctx context.Context, input CreateClientInputDTO) (*CreateClientOutputDTO, error) {
 	client, err := entity.NewClient(input.Name, input.Email)
 	if err != nil {
 		return nil, err
 	}
 
-	err = uc.ClientGateway.Save(client)
+	var created []events.Event
+	err = uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		clientRepository, err := uow.GetRepository[gateway.ClientGateway](ctx, u, gateway.ClientRepository)
+		if err != nil {
+			return err
+		}
+		recorder, err := posting.NewRecorder(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		err = clientRepository.Save(client)
+		if err != nil {
+			return err
+		}
+
+		err = recorder.Record(events.NewClientCreated(client))
+		if err != nil {
+			return err
+		}
+		created = recorder.Events
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if uc.Dispatcher != nil {
+		uc.Dispatcher.Dispatch(created...)
+	}
+
 	return &CreateClientOutputDTO{
 		ID:        client.ID,
 		Name:      client.Name,
 		Email:     client.Email,
+		Tier:      string(client.Tier),
 		CreatedAt: client.CreatedAt.String(),
 		UpdatedAt: client.UpdatedAt.String(),
 	}, nil
@@ -1,39 +1,64 @@
 package createclient
 
 import (
+	"context"
 	"testing"
 
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-type ClientGatewayMock struct {
-	mock.Mock
+type failingClients struct {
+	gateway.ClientGateway
+}
+
+func (failingClients) Save(client *entity.Client) error {
+	return assert.AnError
+}
+
+// failingUow hands out a client repository failing to save, and an outbox.
+type failingUow struct {
+	outbox *memory.OutboxGateway
 }
 
-func (m *ClientGatewayMock) Get(id string) (*entity.Client, error) {
-	args := m.Called(id)
-	return args.Get(0).(*entity.Client), args.Error(1)
+func (u failingUow) GetRepository(ctx context.Context, name string) (interface{}, error) {
+	switch name {
+	case gateway.ClientRepository:
+		return failingClients{}, nil
+	case gateway.OutboxRepository:
+		return u.outbox, nil
+	}
+	return nil, uow.ErrRepositoryNotFound
 }
 
-func (m *ClientGatewayMock) Save(client *entity.Client) error {
-	args := m.Called(client)
-	return args.Error(0)
+func (u failingUow) Do(ctx context.Context, fn func(uow uow.UnitOfWork) error) error {
+	return fn(u)
+}
+
+type DispatcherMock struct {
+	mock.Mock
+}
+
+func (m *DispatcherMock) Dispatch(events ...events.Event) {
+	m.Called(events)
 }
 
 func TestCreateClientUseCase_Execute(t *testing.T) {
-	m := &ClientGatewayMock{}
-	m.On("Save", mock.Anything).Return(nil)
+	store := memory.NewStore()
 
-	uc := NewCreateClientUseCase(m)
+	uc := NewCreateClientUseCase(memory.NewUow(store))
 
 	input := CreateClientInputDTO{
 		Name:  "John Doe",
 		Email: "john@example.com",
 	}
 
-	output, err := uc.Execute(input)
+	output, err := uc.Execute(context.Background(), input)
 
 	assert.Nil(t, err)
 	assert.NotNil(t, output)
@@ -43,65 +68,101 @@ func TestCreateClientUseCase_Execute(t *testing.T) {
 	assert.NotEmpty(t, output.CreatedAt)
 	assert.NotEmpty(t, output.UpdatedAt)
 
-	m.AssertExpectations(t)
-	m.AssertNumberOfCalls(t, "Save", 1)
+	client, err := memory.NewClientGateway(store).Get(output.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "John Doe", client.Name)
 }
 
-func TestCreateClientUseCase_ExecuteWithInvalidName(t *testing.T) {
-	m := &ClientGatewayMock{}
+func TestCreateClientUseCase_ExecuteWritesClientCreatedToOutbox(t *testing.T) {
+	store := memory.NewStore()
 
-	uc := NewCreateClientUseCase(m)
+	output, err := NewCreateClientUseCase(memory.NewUow(store)).Execute(context.Background(), CreateClientInputDTO{Name: "John Doe", Email: "john@example.com"})
+
+	assert.Nil(t, err)
+	messages, err := memory.NewOutboxGateway(store).FindUnsent(-1)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, events.ClientCreatedName, messages[0].EventName)
+	assert.Equal(t, output.ID, messages[0].Key)
+}
+
+func TestCreateClientUseCase_ExecuteWithInvalidName(t *testing.T) {
+	uc := NewCreateClientUseCase(memory.NewUow(memory.NewStore()))
 
 	input := CreateClientInputDTO{
 		Name:  "",
 		Email: "john@example.com",
 	}
 
-	output, err := uc.Execute(input)
+	output, err := uc.Execute(context.Background(), input)
 
 	assert.NotNil(t, err)
 	assert.Nil(t, output)
-	assert.Error(t, err, "name is required")
-
-	m.AssertNumberOfCalls(t, "Save", 0)
+	var validationErr *entity.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "name", validationErr.Field)
 }
 
 func TestCreateClientUseCase_ExecuteWithInvalidEmail(t *testing.T) {
-	m := &ClientGatewayMock{}
-
-	uc := NewCreateClientUseCase(m)
+	uc := NewCreateClientUseCase(memory.NewUow(memory.NewStore()))
 
 	input := CreateClientInputDTO{
 		Name:  "John Doe",
 		Email: "",
 	}
 
-	output, err := uc.Execute(input)
+	output, err := uc.Execute(context.Background(), input)
 
 	assert.NotNil(t, err)
 	assert.Nil(t, output)
-	assert.Error(t, err, "email is required")
-
-	m.AssertNumberOfCalls(t, "Save", 0)
+	var validationErr *entity.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "email", validationErr.Field)
 }
 
 func TestCreateClientUseCase_ExecuteWithGatewayError(t *testing.T) {
-	m := &ClientGatewayMock{}
-	m.On("Save", mock.Anything).Return(assert.AnError)
-
-	uc := NewCreateClientUseCase(m)
+	outbox := memory.NewOutboxGateway(memory.NewStore())
+	uc := NewCreateClientUseCase(failingUow{outbox: outbox})
 
 	input := CreateClientInputDTO{
 		Name:  "John Doe",
 		Email: "john@example.com",
 	}
 
-	output, err := uc.Execute(input)
+	output, err := uc.Execute(context.Background(), input)
 
-	assert.NotNil(t, err)
 	assert.Nil(t, output)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, assert.AnError)
+	messages, err := outbox.FindUnsent(-1)
+	assert.Nil(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestCreateClientUseCase_ExecuteDispatchesClientCreated(t *testing.T) {
+	dispatcher := &DispatcherMock{}
+	dispatcher.On("Dispatch", mock.Anything)
 
-	m.AssertExpectations(t)
-	m.AssertNumberOfCalls(t, "Save", 1)
+	uc := NewCreateClientUseCase(memory.NewUow(memory.NewStore()))
+	uc.Dispatcher = dispatcher
+
+	output, err := uc.Execute(context.Background(), CreateClientInputDTO{Name: "John Doe", Email: "john@example.com"})
+
+	assert.Nil(t, err)
+	dispatched := dispatcher.Calls[0].Arguments.Get(0).([]events.Event)
+	assert.Len(t, dispatched, 1)
+	event := dispatched[0].(events.ClientCreated)
+	assert.Equal(t, output.ID, event.ClientID)
+	assert.Equal(t, "john@example.com", event.Email)
+}
+
+func TestCreateClientUseCase_ExecuteDoesNotDispatchOnError(t *testing.T) {
+	dispatcher := &DispatcherMock{}
+
+	uc := NewCreateClientUseCase(failingUow{outbox: memory.NewOutboxGateway(memory.NewStore())})
+	uc.Dispatcher = dispatcher
+
+	_, err := uc.Execute(context.Background(), CreateClientInputDTO{Name: "John Doe", Email: "john@example.com"})
+
+	assert.Error(t, err)
+	dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
 }
@@ -0,0 +1,64 @@
+package createscheduledtransfer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+// CreateScheduledTransferInputDTO schedules a transfer once at RunAt, or
+// repeatedly when Recurrence holds a cron expression such as "0 9 5 * *".
+type CreateScheduledTransferInputDTO struct {
+	AccountIDFrom string
+	AccountIDTo   string
+	Amount        entity.Money
+	RunAt         time.Time
+	Recurrence    string
+}
+
+type CreateScheduledTransferOutputDTO struct {
+	ID        string
+	NextRunAt time.Time
+}
+
+type CreateScheduledTransferUseCase struct {
+	ScheduledTransferGateway gateway.ScheduledTransferGateway
+	AccountGateway           gateway.AccountGateway
+}
+
+func NewCreateScheduledTransferUseCase(scheduledTransferGateway gateway.ScheduledTransferGateway, accountGateway gateway.AccountGateway) *CreateScheduledTransferUseCase {
+	return &CreateScheduledTransferUseCase{
+		ScheduledTransferGateway: scheduledTransferGateway,
+		AccountGateway:           accountGateway,
+	}
+}
+
+func (uc *CreateScheduledTransferUseCase) Execute(input CreateScheduledTransferInputDTO) (*CreateScheduledTransferOutputDTO, error) {
+	transfer, err := entity.NewScheduledTransfer(input.AccountIDFrom, input.AccountIDTo, input.Amount, input.RunAt, input.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range []string{transfer.AccountIDFrom, transfer.AccountIDTo} {
+		_, err := uc.AccountGateway.FindByID(id)
+		if err != nil {
+			if errors.Is(err, gateway.ErrNotFound) {
+				return nil, fmt.Errorf("%w: %s", entity.ErrAccountNotFound, id)
+			}
+			return nil, err
+		}
+	}
+
+	err = uc.ScheduledTransferGateway.Save(transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateScheduledTransferOutputDTO{
+		ID:        transfer.ID,
+		NextRunAt: transfer.NextRunAt,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package createscheduledtransfer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateScheduledTransferUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	from := memorytest.NewAccount(t, store, "John Doe", 0)
+	to := memorytest.NewAccount(t, store, "Jane Doe", 0)
+
+	uc := NewCreateScheduledTransferUseCase(memory.NewScheduledTransferGateway(store), memory.NewAccountGateway(store))
+
+	output, err := uc.Execute(CreateScheduledTransferInputDTO{
+		AccountIDFrom: from.ID,
+		AccountIDTo:   to.ID,
+		Amount:        entity.NewMoney(1500_00, entity.DefaultCurrency),
+		Recurrence:    "0 9 5 * *",
+	})
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, output.ID)
+	assert.Equal(t, 5, output.NextRunAt.Day())
+	transfer, err := memory.NewScheduledTransferGateway(store).FindByID(output.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, from.ID, transfer.AccountIDFrom)
+	assert.Equal(t, to.ID, transfer.AccountIDTo)
+}
+
+func TestCreateScheduledTransferUseCase_ExecuteWithInvalidRecurrence(t *testing.T) {
+	store := memory.NewStore()
+	from := memorytest.NewAccount(t, store, "John Doe", 0)
+	to := memorytest.NewAccount(t, store, "Jane Doe", 0)
+
+	uc := NewCreateScheduledTransferUseCase(memory.NewScheduledTransferGateway(store), memory.NewAccountGateway(store))
+
+	output, err := uc.Execute(CreateScheduledTransferInputDTO{
+		AccountIDFrom: from.ID,
+		AccountIDTo:   to.ID,
+		Amount:        entity.NewMoney(1500_00, entity.DefaultCurrency),
+		Recurrence:    "monthly",
+	})
+
+	assert.Nil(t, output)
+	var validationErr *entity.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "recurrence", validationErr.Field)
+}
+
+func TestCreateScheduledTransferUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	store := memory.NewStore()
+	from := memorytest.NewAccount(t, store, "John Doe", 0)
+
+	uc := NewCreateScheduledTransferUseCase(memory.NewScheduledTransferGateway(store), memory.NewAccountGateway(store))
+
+	output, err := uc.Execute(CreateScheduledTransferInputDTO{
+		AccountIDFrom: from.ID,
+		AccountIDTo:   "unknown",
+		Amount:        entity.NewMoney(1500_00, entity.DefaultCurrency),
+		RunAt:         time.Now().Add(time.Hour),
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+	due, err := memory.NewScheduledTransferGateway(store).FindDue(time.Now().Add(2*time.Hour), -1)
+	assert.Nil(t, err)
+	assert.Empty(t, due)
+}
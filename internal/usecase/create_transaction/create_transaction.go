@@ -1,61 +1,256 @@
 package createtransaction
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/fee"
 	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/limits"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
 )
 
+// CreateTransactionInputDTO describes a transfer. When IdempotencyKey is set,
+// repeating the same request with the same key returns the transaction
+// created the first time instead of creating another one.
 type CreateTransactionInputDTO struct {
-	AccountIDFrom string
-	AccountIDTo   string
-	Amount        float64
+	AccountIDFrom  string
+	AccountIDTo    string
+	Amount         entity.Money
+	IdempotencyKey string
 }
 
+// CreateTransactionOutputDTO itemises what the payer was charged: Total is
+// Amount plus Fee. FeeTransactionID is empty when no fee was charged.
 type CreateTransactionOutputDTO struct {
-	ID string
+	ID               string
+	Amount           entity.Money
+	Fee              entity.Money
+	FeeTransactionID string
+	Total            entity.Money
 }
 
+// ErrRevenueAccountNotConfigured reports a FeePolicy set without an existing
+// RevenueAccountID. It is a server misconfiguration, so it deliberately does
+// not match entity.ErrAccountNotFound.
+var ErrRevenueAccountNotConfigured = errors.New("fee policy set without a valid revenue account")
+
+// CreateTransactionUseCase transfers money between accounts. When FeePolicy
+// is set, the fee it computes is charged to the payer and credited to
+// RevenueAccountID, which must exist, in the same unit of work as the
+// transfer; the revenue account itself pays no fee. When Limits is set,
+// transfers breaching one of its rules are rejected; limits apply to the
+// amount transferred, not to the fee charged on top of it. Events are sent
+// to Dispatcher, if set, once the transfer is committed; replaying an
+// idempotent request emits nothing.
 type CreateTransactionUseCase struct {
-	transactionGateway gateway.TransactionGateway
-	accountGateway     gateway.AccountGateway
+	Uow              uow.UnitOfWork
+	MaxAttempts      int
+	FeePolicy        fee.Policy
+	RevenueAccountID string
+	Limits           *limits.Checker
+	Dispatcher       events.Dispatcher
 }
 
-func NewCreateTransactionUseCase(
-	transactionGateway gateway.TransactionGateway,
-	accountGateway gateway.AccountGateway,
-) *CreateTransactionUseCase {
+func NewCreateTransactionUseCase(uow uow.UnitOfWork) *CreateTransactionUseCase {
 	return &CreateTransactionUseCase{
-		transactionGateway: transactionGateway,
-		accountGateway:     accountGateway,
+		Uow:         uow,
+		MaxAttempts: posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *CreateTransactionUseCase) Execute(ctx context.Context, input CreateTransactionInputDTO) (*CreateTransactionOutputDTO, error) {
+	if uc.FeePolicy != nil && uc.RevenueAccountID == "" {
+		return nil, ErrRevenueAccountNotConfigured
 	}
+	return posting.Retry(uc.MaxAttempts, uc.Dispatcher, func() (*CreateTransactionOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
 }
 
-func (uc *CreateTransactionUseCase) Execute(input CreateTransactionInputDTO) (*CreateTransactionOutputDTO, error) {
-	accountFrom, err := uc.accountGateway.FindByID(input.AccountIDFrom)
+func (uc *CreateTransactionUseCase) execute(ctx context.Context, input CreateTransactionInputDTO) (*CreateTransactionOutputDTO, []events.Event, error) {
+	var output *CreateTransactionOutputDTO
+	var pending []events.Event
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		poster, err := posting.NewPoster(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		var idempotencyKeys gateway.IdempotencyKeyGateway
+		if input.IdempotencyKey != "" {
+			idempotencyKeys, err = uow.GetRepository[gateway.IdempotencyKeyGateway](ctx, u, gateway.IdempotencyKeyRepository)
+			if err != nil {
+				return err
+			}
+
+			key, err := idempotencyKeys.FindByKey(input.IdempotencyKey)
+			if err == nil {
+				if !key.Matches(input.hash()) {
+					return fmt.Errorf("%w: %s", entity.ErrIdempotencyKeyReused, input.IdempotencyKey)
+				}
+				output, err = replay(poster.Transactions, key.TransactionID)
+				return err
+			}
+			if !errors.Is(err, gateway.ErrNotFound) {
+				return err
+			}
+		}
+
+		// The revenue account is loaded with the parties, which it may be
+		// one of, so that all three are locked in the same order.
+		ids := []string{input.AccountIDFrom, input.AccountIDTo}
+		if uc.FeePolicy != nil {
+			ids = append(ids, uc.RevenueAccountID)
+		}
+		accounts, err := poster.FindAccounts(ids...)
+		if err != nil {
+			if uc.FeePolicy != nil && errors.Is(err, entity.ErrAccountNotFound) {
+				return uc.checkRevenueAccount(poster.Accounts, err)
+			}
+			return err
+		}
+		accountFrom, accountTo := accounts[0], accounts[1]
+
+		if uc.Limits != nil {
+			clients, err := uow.GetRepository[gateway.ClientGateway](ctx, u, gateway.ClientRepository)
+			if err != nil {
+				return err
+			}
+			err = uc.Limits.Check(poster.Transactions, clients, accountFrom, input.Amount)
+			if err != nil {
+				return err
+			}
+		}
+
+		transaction, err := entity.NewTransaction(
+			accountFrom,
+			accountTo,
+			input.Amount,
+		)
+		if err != nil {
+			return err
+		}
+
+		var revenue *entity.Account
+		if uc.FeePolicy != nil {
+			revenue = accounts[2]
+		}
+		feeTransaction, err := uc.chargeFee(transaction, revenue)
+		if err != nil {
+			return err
+		}
+
+		err = poster.Post(transaction)
+		if err != nil {
+			return err
+		}
+		if feeTransaction != nil {
+			err = poster.Post(feeTransaction)
+			if err != nil {
+				return err
+			}
+		}
+
+		// A concurrent request that stored the same key first makes Save
+		// fail with a conflict; the retry then finds the key and replays it.
+		if idempotencyKeys != nil {
+			key, err := entity.NewIdempotencyKey(input.IdempotencyKey, input.hash(), transaction.ID)
+			if err != nil {
+				return err
+			}
+			err = idempotencyKeys.Save(key)
+			if err != nil {
+				return err
+			}
+		}
+
+		output = newOutput(transaction, feeTransaction)
+		pending = poster.Events
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	return output, pending, nil
+}
+
+// checkRevenueAccount turns notFound into ErrRevenueAccountNotConfigured when
+// the missing account is the revenue account rather than one of the parties.
+func (uc *CreateTransactionUseCase) checkRevenueAccount(accounts gateway.AccountGateway, notFound error) error {
+	_, err := accounts.FindByID(uc.RevenueAccountID)
+	if errors.Is(err, gateway.ErrNotFound) {
+		return fmt.Errorf("%w: %s", ErrRevenueAccountNotConfigured, uc.RevenueAccountID)
 	}
+	if err != nil {
+		return err
+	}
+	return notFound
+}
 
-	accountTo, err := uc.accountGateway.FindByID(input.AccountIDTo)
+// chargeFee applies the fee policy to transfer, crediting revenue, and
+// returns nil when there is nothing to charge. Transfers paid by revenue are
+// not charged, as the fee would go back to the account paying it.
+func (uc *CreateTransactionUseCase) chargeFee(transfer *entity.Transaction, revenue *entity.Account) (*entity.Transaction, error) {
+	if uc.FeePolicy == nil || transfer.AccountFrom.ID == revenue.ID {
+		return nil, nil
+	}
+
+	amount, err := uc.FeePolicy.Fee(transfer.Amount, transfer.AccountFrom.Client)
 	if err != nil {
 		return nil, err
 	}
+	if !amount.IsPositive() {
+		return nil, nil
+	}
 
-	transaction, err := entity.NewTransaction(
-		accountFrom,
-		accountTo,
-		input.Amount,
-	)
+	return entity.NewFee(transfer, revenue, amount)
+}
+
+// replay rebuilds the output of a transfer created by an earlier request.
+func replay(transactions gateway.TransactionGateway, id string) (*CreateTransactionOutputDTO, error) {
+	transaction, err := transactions.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
-
-	err = uc.transactionGateway.Save(transaction)
+	fees, err := transactions.FindFees(id)
 	if err != nil {
 		return nil, err
 	}
+	var feeTransaction *entity.Transaction
+	if len(fees) > 0 {
+		feeTransaction = fees[0]
+	}
+	return newOutput(transaction, feeTransaction), nil
+}
+
+func newOutput(transaction, feeTransaction *entity.Transaction) *CreateTransactionOutputDTO {
+	output := &CreateTransactionOutputDTO{
+		ID:     transaction.ID,
+		Amount: transaction.Amount,
+		Fee:    entity.Zero(transaction.Amount.Currency()),
+		Total:  transaction.Amount,
+	}
+	if feeTransaction != nil {
+		output.Fee = feeTransaction.Amount
+		output.FeeTransactionID = feeTransaction.ID
+		// Both amounts were debited from the same account, so they cannot
+		// overflow or differ in currency.
+		output.Total, _ = transaction.Amount.Add(feeTransaction.Amount)
+	}
+	return output
+}
 
-	return &CreateTransactionOutputDTO{
-		ID: transaction.ID,
-	}, nil
+// hash identifies the payload of a request so that reusing an idempotency key
+// for a different transfer can be detected.
+func (input CreateTransactionInputDTO) hash() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%s", input.AccountIDFrom, input.AccountIDTo, input.Amount)))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,382 @@
+package createtransaction
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/fee"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/limits"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CreateTransactionDBTestSuite struct {
+	suite.Suite
+	db          *sql.DB
+	accountDB   *database.AccountDB
+	accountFrom *entity.Account
+	accountTo   *entity.Account
+	uc          *CreateTransactionUseCase
+}
+
+func (s *CreateTransactionDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+
+	s.accountDB = database.NewAccountDB(db)
+
+	clientFrom, _ := entity.NewClient("John Doe", "john@example.com")
+	clientTo, _ := entity.NewClient("Jane Doe", "jane@example.com")
+	for _, client := range []*entity.Client{clientFrom, clientTo} {
+		db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	}
+
+	s.accountFrom = entity.NewAccount(clientFrom)
+	s.accountFrom.Credit(entity.NewMoney(100_00, entity.DefaultCurrency))
+	s.accountTo = entity.NewAccount(clientTo)
+	s.Nil(s.accountDB.Save(s.accountFrom))
+	s.Nil(s.accountDB.Save(s.accountTo))
+
+	u := uow.NewUow(db)
+	u.Register(gateway.ClientRepository, func(tx *sql.Tx) interface{} {
+		return database.NewClientDB(tx)
+	})
+	u.Register(gateway.AccountRepository, func(tx *sql.Tx) interface{} {
+		return database.NewAccountDB(tx)
+	})
+	u.Register(gateway.TransactionRepository, func(tx *sql.Tx) interface{} {
+		return database.NewTransactionDB(tx)
+	})
+	u.Register(gateway.LedgerRepository, func(tx *sql.Tx) interface{} {
+		return database.NewLedgerDB(tx)
+	})
+	u.Register(gateway.IdempotencyKeyRepository, func(tx *sql.Tx) interface{} {
+		return database.NewIdempotencyKeyDB(tx)
+	})
+	u.Register(gateway.OutboxRepository, func(tx *sql.Tx) interface{} {
+		return database.NewOutboxDB(tx)
+	})
+	s.uc = NewCreateTransactionUseCase(u)
+}
+
+func TestCreateTransactionDBTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateTransactionDBTestSuite))
+}
+
+func (s *CreateTransactionDBTestSuite) TestExecutePersistsBalances() {
+	output, err := s.uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: s.accountFrom.ID,
+		AccountIDTo:   s.accountTo.ID,
+		Amount:        entity.NewMoney(40_00, entity.DefaultCurrency),
+	})
+	s.Nil(err)
+	s.NotEmpty(output.ID)
+
+	accountFrom, err := s.accountDB.FindByID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(60_00, entity.DefaultCurrency), accountFrom.Balance)
+	accountTo, err := s.accountDB.FindByID(s.accountTo.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(40_00, entity.DefaultCurrency), accountTo.Balance)
+
+	entries, err := database.NewLedgerDB(s.db).FindByAccountID(s.accountTo.ID)
+	s.Nil(err)
+	s.Len(entries, 1)
+	s.Equal(output.ID, entries[0].TransactionID)
+	s.Equal(entity.EntryCredit, entries[0].Direction)
+
+	messages, err := database.NewOutboxDB(s.db).FindUnsent(10)
+	s.Nil(err)
+	s.Len(messages, 3)
+	s.Equal("TransactionCreated", messages[0].EventName)
+	s.Equal(s.accountFrom.ID, messages[0].Key)
+	s.Equal("BalanceUpdated", messages[1].EventName)
+	s.Equal(s.accountFrom.ID, messages[1].Key)
+	s.Equal(s.accountTo.ID, messages[2].Key)
+}
+
+func (s *CreateTransactionDBTestSuite) TestExecuteRollsBackBalancesWhenSaveFails() {
+	s.db.Exec("DROP TABLE transactions")
+
+	output, err := s.uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: s.accountFrom.ID,
+		AccountIDTo:   s.accountTo.ID,
+		Amount:        entity.NewMoney(40_00, entity.DefaultCurrency),
+	})
+	s.NotNil(err)
+	s.Nil(output)
+
+	accountFrom, err := s.accountDB.FindByID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(100_00, entity.DefaultCurrency), accountFrom.Balance)
+	accountTo, err := s.accountDB.FindByID(s.accountTo.ID)
+	s.Nil(err)
+	s.True(accountTo.Balance.IsZero())
+
+	messages, err := database.NewOutboxDB(s.db).FindUnsent(10)
+	s.Nil(err)
+	s.Empty(messages)
+}
+
+func (s *CreateTransactionDBTestSuite) TestExecuteToSameAccount() {
+	output, err := s.uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: s.accountFrom.ID,
+		AccountIDTo:   s.accountFrom.ID,
+		Amount:        entity.NewMoney(40_00, entity.DefaultCurrency),
+	})
+	s.Nil(output)
+	var validationErr *entity.ValidationError
+	s.ErrorAs(err, &validationErr)
+	s.Equal("account_to", validationErr.Field)
+
+	accountFrom, err := s.accountDB.FindByID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(100_00, entity.DefaultCurrency), accountFrom.Balance)
+	s.Equal(0, accountFrom.Version)
+}
+
+func (s *CreateTransactionDBTestSuite) TestExecuteWithUnknownAccount() {
+	output, err := s.uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: s.accountFrom.ID,
+		AccountIDTo:   "unknown",
+		Amount:        entity.NewMoney(40_00, entity.DefaultCurrency),
+	})
+	s.Nil(output)
+	s.ErrorIs(err, entity.ErrAccountNotFound)
+
+	accountFrom, err := s.accountDB.FindByID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(100_00, entity.DefaultCurrency), accountFrom.Balance)
+}
+
+func (s *CreateTransactionDBTestSuite) TestExecuteWithIdempotencyKey() {
+	input := CreateTransactionInputDTO{
+		AccountIDFrom:  s.accountFrom.ID,
+		AccountIDTo:    s.accountTo.ID,
+		Amount:         entity.NewMoney(40_00, entity.DefaultCurrency),
+		IdempotencyKey: "key-1",
+	}
+	output, err := s.uc.Execute(context.Background(), input)
+	s.Nil(err)
+
+	replayed, err := s.uc.Execute(context.Background(), input)
+	s.Nil(err)
+	s.Equal(output, replayed)
+
+	accountFrom, err := s.accountDB.FindByID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(60_00, entity.DefaultCurrency), accountFrom.Balance)
+
+	input.Amount = entity.NewMoney(10_00, entity.DefaultCurrency)
+	mismatched, err := s.uc.Execute(context.Background(), input)
+	s.Nil(mismatched)
+	s.ErrorIs(err, entity.ErrIdempotencyKeyReused)
+}
+
+func (s *CreateTransactionDBTestSuite) TestExecuteChargesFee() {
+	client, _ := entity.NewClient("Wallet", "revenue@example.com")
+	s.db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	revenue := entity.NewAccount(client)
+	s.Nil(s.accountDB.Save(revenue))
+
+	s.uc.FeePolicy = fee.Flat{Amount: entity.NewMoney(2_00, entity.DefaultCurrency)}
+	s.uc.RevenueAccountID = revenue.ID
+
+	input := CreateTransactionInputDTO{
+		AccountIDFrom:  s.accountFrom.ID,
+		AccountIDTo:    s.accountTo.ID,
+		Amount:         entity.NewMoney(40_00, entity.DefaultCurrency),
+		IdempotencyKey: "key-1",
+	}
+	output, err := s.uc.Execute(context.Background(), input)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(42_00, entity.DefaultCurrency), output.Total)
+
+	accountFrom, err := s.accountDB.FindByID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(58_00, entity.DefaultCurrency), accountFrom.Balance)
+	revenue, err = s.accountDB.FindByID(revenue.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(2_00, entity.DefaultCurrency), revenue.Balance)
+
+	replayed, err := s.uc.Execute(context.Background(), input)
+	s.Nil(err)
+	s.Equal(output.FeeTransactionID, replayed.FeeTransactionID)
+	s.Equal(output.Total, replayed.Total)
+}
+
+func (s *CreateTransactionDBTestSuite) TestExecuteEnforcesLimits() {
+	s.uc.Limits = limits.NewChecker(limits.Rule{
+		Name:     "daily-count",
+		Scope:    limits.ScopeClient,
+		Window:   24 * time.Hour,
+		MaxCount: 2,
+	})
+	input := CreateTransactionInputDTO{
+		AccountIDFrom: s.accountFrom.ID,
+		AccountIDTo:   s.accountTo.ID,
+		Amount:        entity.NewMoney(10_00, entity.DefaultCurrency),
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := s.uc.Execute(context.Background(), input)
+		s.Nil(err)
+	}
+
+	output, err := s.uc.Execute(context.Background(), input)
+	s.Nil(output)
+	s.ErrorIs(err, entity.ErrLimitExceeded)
+
+	accountFrom, err := s.accountDB.FindByID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(80_00, entity.DefaultCurrency), accountFrom.Balance)
+}
+
+// TestExecuteInOppositeDirections runs transfers from A to B concurrently with
+// transfers from B to A, which deadlock unless both accounts are locked in
+// the same order whatever the direction.
+func TestExecuteInOppositeDirections(t *testing.T) {
+	backends := []struct {
+		name    string
+		open    func(t testing.TB) *sql.DB
+		dialect database.Dialect
+	}{
+		{"SQLite", databasetest.NewDB, database.SQLite},
+		{"MySQL", databasetest.NewMySQLDB, database.MySQL},
+		{"Postgres", databasetest.NewPostgresDB, database.Postgres},
+	}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			db := backend.open(t)
+			clients := &database.ClientDB{DB: db, Dialect: backend.dialect}
+			accounts := &database.AccountDB{DB: db, Dialect: backend.dialect}
+			var pair [2]*entity.Account
+			for i := range pair {
+				client, _ := entity.NewClient("John Doe", "john@example.com")
+				assert.Nil(t, clients.Save(client))
+				pair[i] = entity.NewAccount(client)
+				pair[i].Credit(entity.NewMoney(100_00, entity.DefaultCurrency))
+				assert.Nil(t, accounts.Save(pair[i]))
+			}
+
+			u := uow.NewUow(db)
+			database.RegisterRepositories(u, backend.dialect)
+			uc := NewCreateTransactionUseCase(u)
+			uc.MaxAttempts = 10
+
+			const transfers = 10
+			errs := make(chan error, 2*transfers)
+			var wg sync.WaitGroup
+			for i := 0; i < 2*transfers; i++ {
+				from, to := pair[i%2], pair[1-i%2]
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+						AccountIDFrom: from.ID,
+						AccountIDTo:   to.ID,
+						Amount:        entity.NewMoney(1_00, entity.DefaultCurrency),
+					})
+					errs <- err
+				}()
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				assert.Nil(t, err)
+			}
+			for _, account := range pair {
+				retrieved, err := accounts.FindByID(account.ID)
+				assert.Nil(t, err)
+				assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), retrieved.Balance)
+				assert.Equal(t, 2*transfers, retrieved.Version)
+			}
+		})
+	}
+}
+
+// TestExecuteWithClientLimitConcurrently runs transfers from two accounts of
+// the same client concurrently, which together exceed a client limit unless
+// each one counts the transfers committed before it.
+func TestExecuteWithClientLimitConcurrently(t *testing.T) {
+	backends := []struct {
+		name    string
+		open    func(t testing.TB) *sql.DB
+		dialect database.Dialect
+	}{
+		{"SQLite", databasetest.NewDB, database.SQLite},
+		{"MySQL", databasetest.NewMySQLDB, database.MySQL},
+		{"Postgres", databasetest.NewPostgresDB, database.Postgres},
+	}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			db := backend.open(t)
+			clients := &database.ClientDB{DB: db, Dialect: backend.dialect}
+			accounts := &database.AccountDB{DB: db, Dialect: backend.dialect}
+			client, _ := entity.NewClient("John Doe", "john@example.com")
+			assert.Nil(t, clients.Save(client))
+			var pair [2]*entity.Account
+			for i := range pair {
+				pair[i] = entity.NewAccount(client)
+				pair[i].Credit(entity.NewMoney(100_00, entity.DefaultCurrency))
+				assert.Nil(t, accounts.Save(pair[i]))
+			}
+			recipientClient, _ := entity.NewClient("Jane Doe", "jane@example.com")
+			assert.Nil(t, clients.Save(recipientClient))
+			recipient := entity.NewAccount(recipientClient)
+			assert.Nil(t, accounts.Save(recipient))
+
+			u := uow.NewUow(db)
+			database.RegisterRepositories(u, backend.dialect)
+			uc := NewCreateTransactionUseCase(u)
+			uc.MaxAttempts = 10
+			uc.Limits = limits.NewChecker(limits.Rule{
+				Name:     "daily-total",
+				Scope:    limits.ScopeClient,
+				Window:   24 * time.Hour,
+				MaxTotal: entity.NewMoney(50_00, entity.DefaultCurrency),
+			})
+
+			const transfers = 10
+			errs := make(chan error, transfers)
+			var wg sync.WaitGroup
+			for i := 0; i < transfers; i++ {
+				from := pair[i%2]
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+						AccountIDFrom: from.ID,
+						AccountIDTo:   recipient.ID,
+						Amount:        entity.NewMoney(10_00, entity.DefaultCurrency),
+					})
+					errs <- err
+				}()
+			}
+			wg.Wait()
+			close(errs)
+
+			sent := 0
+			for err := range errs {
+				if err == nil {
+					sent++
+				} else {
+					assert.ErrorIs(t, err, entity.ErrLimitExceeded)
+				}
+			}
+			assert.Equal(t, 5, sent)
+			retrieved, err := accounts.FindByID(recipient.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), retrieved.Balance)
+		})
+	}
+}
@@ -1,284 +1,430 @@
 package createtransaction
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/fee"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/limits"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-type TransactionGatewayMock struct {
-	mock.Mock
+type failingTransactions struct {
+	gateway.TransactionGateway
 }
 
-func (m *TransactionGatewayMock) Save(transaction *entity.Transaction) error {
-	args := m.Called(transaction)
-	return args.Error(0)
+func (failingTransactions) Save(transaction *entity.Transaction) error {
+	return errors.New("database error")
 }
 
-type AccountGatewayMock struct {
+type DispatcherMock struct {
 	mock.Mock
 }
 
-func (m *AccountGatewayMock) Save(account *entity.Account) error {
-	args := m.Called(account)
-	return args.Error(0)
-}
-
-func (m *AccountGatewayMock) FindByID(id string) (*entity.Account, error) {
-	args := m.Called(id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Account), args.Error(1)
+func (m *DispatcherMock) Dispatch(events ...events.Event) {
+	m.Called(events)
 }
 
 func TestCreateTransactionUseCase_Execute(t *testing.T) {
-	clientFrom, _ := entity.NewClient("John Doe", "john@example.com")
-	clientTo, _ := entity.NewClient("Jane Doe", "jane@example.com")
-
-	accountFrom := entity.NewAccount(clientFrom)
-	accountFrom.Credit(100.0) // Adding balance to account
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
 
-	accountTo := entity.NewAccount(clientTo)
-
-	transactionGateway := &TransactionGatewayMock{}
-	accountGateway := &AccountGatewayMock{}
-
-	accountGateway.On("FindByID", "account-from-id").Return(accountFrom, nil)
-	accountGateway.On("FindByID", "account-to-id").Return(accountTo, nil)
-	transactionGateway.On("Save", mock.Anything).Return(nil)
-
-	uc := NewCreateTransactionUseCase(transactionGateway, accountGateway)
-
-	input := CreateTransactionInputDTO{
-		AccountIDFrom: "account-from-id",
-		AccountIDTo:   "account-to-id",
-		Amount:        50.0,
-	}
-
-	output, err := uc.Execute(input)
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
 
 	assert.Nil(t, err)
-	assert.NotNil(t, output)
 	assert.NotEmpty(t, output.ID)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountTo.ID).Balance)
 
-	// Verify that balances were updated
-	assert.Equal(t, 50.0, accountFrom.Balance)
-	assert.Equal(t, 50.0, accountTo.Balance)
-
-	transactionGateway.AssertExpectations(t)
-	accountGateway.AssertExpectations(t)
-	transactionGateway.AssertNumberOfCalls(t, "Save", 1)
-	accountGateway.AssertNumberOfCalls(t, "FindByID", 2)
+	transaction, err := memory.NewTransactionGateway(store).FindByID(output.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, accountFrom.ID, transaction.AccountFrom.ID)
+	assert.Equal(t, accountTo.ID, transaction.AccountTo.ID)
+	entries, err := memory.NewLedgerGateway(store).FindByAccountID(accountFrom.ID)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
 }
 
 func TestCreateTransactionUseCase_ExecuteWithAccountFromNotFound(t *testing.T) {
-	transactionGateway := &TransactionGatewayMock{}
-	accountGateway := &AccountGatewayMock{}
+	store, _, accountTo := memorytest.NewStore(t, 100_00, 0)
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
 
-	accountGateway.On("FindByID", "account-from-id").Return(nil, errors.New("account not found"))
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: "unknown",
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
 
-	uc := NewCreateTransactionUseCase(transactionGateway, accountGateway)
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
 
-	input := CreateTransactionInputDTO{
-		AccountIDFrom: "account-from-id",
-		AccountIDTo:   "account-to-id",
-		Amount:        50.0,
-	}
+func TestCreateTransactionUseCase_ExecuteWithAccountToNotFound(t *testing.T) {
+	store, accountFrom, _ := memorytest.NewStore(t, 100_00, 0)
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
 
-	output, err := uc.Execute(input)
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   "unknown",
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
 
-	assert.NotNil(t, err)
 	assert.Nil(t, output)
-	assert.Equal(t, "account not found", err.Error())
-
-	accountGateway.AssertExpectations(t)
-	accountGateway.AssertNumberOfCalls(t, "FindByID", 1)
-	transactionGateway.AssertNumberOfCalls(t, "Save", 0)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
 }
 
-func TestCreateTransactionUseCase_ExecuteWithAccountToNotFound(t *testing.T) {
-	clientFrom, _ := entity.NewClient("John Doe", "john@example.com")
-	accountFrom := entity.NewAccount(clientFrom)
-	accountFrom.Credit(100.0)
+func TestCreateTransactionUseCase_ExecuteWithInsufficientFunds(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(150_00, entity.DefaultCurrency),
+	})
 
-	transactionGateway := &TransactionGatewayMock{}
-	accountGateway := &AccountGatewayMock{}
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrInsufficientFunds)
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+	assert.True(t, memorytest.FindAccount(t, store, accountTo.ID).Balance.IsZero())
+}
 
-	accountGateway.On("FindByID", "account-from-id").Return(accountFrom, nil)
-	accountGateway.On("FindByID", "account-to-id").Return(nil, errors.New("account not found"))
+func TestCreateTransactionUseCase_ExecuteWithInvalidAmount(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
 
-	uc := NewCreateTransactionUseCase(transactionGateway, accountGateway)
+	for _, amount := range []int64{0, -10_00} {
+		output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+			AccountIDFrom: accountFrom.ID,
+			AccountIDTo:   accountTo.ID,
+			Amount:        entity.NewMoney(amount, entity.DefaultCurrency),
+		})
 
-	input := CreateTransactionInputDTO{
-		AccountIDFrom: "account-from-id",
-		AccountIDTo:   "account-to-id",
-		Amount:        50.0,
+		assert.Nil(t, output)
+		assert.ErrorIs(t, err, entity.ErrInvalidAmount)
 	}
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+}
 
-	output, err := uc.Execute(input)
+func TestCreateTransactionUseCase_ExecuteWithTransactionGatewayError(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	u := memorytest.Wrap(memory.NewUow(store), func(name string, repository interface{}) interface{} {
+		if name == gateway.TransactionRepository {
+			return failingTransactions{TransactionGateway: repository.(gateway.TransactionGateway)}
+		}
+		return repository
+	})
+	uc := NewCreateTransactionUseCase(u)
+
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
 
-	assert.NotNil(t, err)
 	assert.Nil(t, output)
-	assert.Equal(t, "account not found", err.Error())
-
-	accountGateway.AssertExpectations(t)
-	accountGateway.AssertNumberOfCalls(t, "FindByID", 2)
-	transactionGateway.AssertNumberOfCalls(t, "Save", 0)
+	assert.EqualError(t, err, "database error")
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+	assert.True(t, memorytest.FindAccount(t, store, accountTo.ID).Balance.IsZero())
 }
 
-func TestCreateTransactionUseCase_ExecuteWithInsufficientFunds(t *testing.T) {
-	clientFrom, _ := entity.NewClient("John Doe", "john@example.com")
-	clientTo, _ := entity.NewClient("Jane Doe", "jane@example.com")
+func TestCreateTransactionUseCase_ExecuteRetriesOnConflict(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	conflicts := 1
+	uc := NewCreateTransactionUseCase(memorytest.WithConflicts(memory.NewUow(store), &conflicts))
 
-	accountFrom := entity.NewAccount(clientFrom)
-	accountFrom.Credit(30.0) // Less than the transaction amount
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
 
-	accountTo := entity.NewAccount(clientTo)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, output.ID)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountTo.ID).Balance)
+}
 
-	transactionGateway := &TransactionGatewayMock{}
-	accountGateway := &AccountGatewayMock{}
+func TestCreateTransactionUseCase_ExecuteGivesUpAfterMaxAttempts(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	conflicts := posting.DefaultMaxAttempts + 1
+	uc := NewCreateTransactionUseCase(memorytest.WithConflicts(memory.NewUow(store), &conflicts))
 
-	accountGateway.On("FindByID", "account-from-id").Return(accountFrom, nil)
-	accountGateway.On("FindByID", "account-to-id").Return(accountTo, nil)
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
 
-	uc := NewCreateTransactionUseCase(transactionGateway, accountGateway)
+	assert.Nil(t, output)
+	var conflict *gateway.ConflictError
+	assert.ErrorAs(t, err, &conflict)
+	assert.ErrorIs(t, err, gateway.ErrConflict)
+	assert.Equal(t, 1, conflicts)
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+}
+
+func TestCreateTransactionUseCase_ExecuteStoresIdempotencyKey(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
 
 	input := CreateTransactionInputDTO{
-		AccountIDFrom: "account-from-id",
-		AccountIDTo:   "account-to-id",
-		Amount:        50.0,
+		AccountIDFrom:  accountFrom.ID,
+		AccountIDTo:    accountTo.ID,
+		Amount:         entity.NewMoney(50_00, entity.DefaultCurrency),
+		IdempotencyKey: "key-1",
 	}
+	output, err := uc.Execute(context.Background(), input)
 
-	output, err := uc.Execute(input)
-
-	assert.NotNil(t, err)
-	assert.Nil(t, output)
-	assert.Equal(t, "insufficient funds in account from", err.Error())
-
-	accountGateway.AssertExpectations(t)
-	accountGateway.AssertNumberOfCalls(t, "FindByID", 2)
-	transactionGateway.AssertNumberOfCalls(t, "Save", 0)
+	assert.Nil(t, err)
+	key, err := memory.NewIdempotencyKeyGateway(store).FindByKey("key-1")
+	assert.Nil(t, err)
+	assert.Equal(t, output.ID, key.TransactionID)
+	assert.True(t, key.Matches(input.hash()))
 }
 
-func TestCreateTransactionUseCase_ExecuteWithZeroAmount(t *testing.T) {
-	clientFrom, _ := entity.NewClient("John Doe", "john@example.com")
-	clientTo, _ := entity.NewClient("Jane Doe", "jane@example.com")
-
-	accountFrom := entity.NewAccount(clientFrom)
-	accountFrom.Credit(100.0)
+func TestCreateTransactionUseCase_ExecuteReplaysIdempotencyKey(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	revenue := entity.NewAccount(accountTo.Client)
+	assert.Nil(t, memory.NewAccountGateway(store).Save(revenue))
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
+	uc.FeePolicy = fee.Flat{Amount: entity.NewMoney(1_00, entity.DefaultCurrency)}
+	uc.RevenueAccountID = revenue.ID
 
-	accountTo := entity.NewAccount(clientTo)
-
-	transactionGateway := &TransactionGatewayMock{}
-	accountGateway := &AccountGatewayMock{}
+	input := CreateTransactionInputDTO{
+		AccountIDFrom:  accountFrom.ID,
+		AccountIDTo:    accountTo.ID,
+		Amount:         entity.NewMoney(50_00, entity.DefaultCurrency),
+		IdempotencyKey: "key-1",
+	}
+	first, err := uc.Execute(context.Background(), input)
+	assert.Nil(t, err)
+	output, err := uc.Execute(context.Background(), input)
 
-	accountGateway.On("FindByID", "account-from-id").Return(accountFrom, nil)
-	accountGateway.On("FindByID", "account-to-id").Return(accountTo, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, first, output)
+	assert.NotEmpty(t, output.FeeTransactionID)
+	assert.Equal(t, entity.NewMoney(51_00, entity.DefaultCurrency), output.Total)
+	assert.Equal(t, entity.NewMoney(49_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+}
 
-	uc := NewCreateTransactionUseCase(transactionGateway, accountGateway)
+func TestCreateTransactionUseCase_ExecuteWithReusedIdempotencyKey(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
 
 	input := CreateTransactionInputDTO{
-		AccountIDFrom: "account-from-id",
-		AccountIDTo:   "account-to-id",
-		Amount:        0.0,
+		AccountIDFrom:  accountFrom.ID,
+		AccountIDTo:    accountTo.ID,
+		Amount:         entity.NewMoney(50_00, entity.DefaultCurrency),
+		IdempotencyKey: "key-1",
 	}
+	_, err := uc.Execute(context.Background(), input)
+	assert.Nil(t, err)
+	input.Amount = entity.NewMoney(10_00, entity.DefaultCurrency)
+	output, err := uc.Execute(context.Background(), input)
 
-	output, err := uc.Execute(input)
-
-	assert.NotNil(t, err)
 	assert.Nil(t, output)
-	assert.Equal(t, "amount must be greater than zero", err.Error())
-
-	accountGateway.AssertExpectations(t)
-	accountGateway.AssertNumberOfCalls(t, "FindByID", 2)
-	transactionGateway.AssertNumberOfCalls(t, "Save", 0)
+	assert.ErrorIs(t, err, entity.ErrIdempotencyKeyReused)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
 }
 
-func TestCreateTransactionUseCase_ExecuteWithNegativeAmount(t *testing.T) {
-	clientFrom, _ := entity.NewClient("John Doe", "john@example.com")
-	clientTo, _ := entity.NewClient("Jane Doe", "jane@example.com")
+func TestCreateTransactionUseCase_ExecuteChargesFee(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	revenue := entity.NewAccount(accountTo.Client)
+	assert.Nil(t, memory.NewAccountGateway(store).Save(revenue))
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
+	uc.FeePolicy = fee.Percentage{BasisPoints: 150}
+	uc.RevenueAccountID = revenue.ID
 
-	accountFrom := entity.NewAccount(clientFrom)
-	accountFrom.Credit(100.0)
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
 
-	accountTo := entity.NewAccount(clientTo)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), output.Amount)
+	assert.Equal(t, entity.NewMoney(75, entity.DefaultCurrency), output.Fee)
+	assert.Equal(t, entity.NewMoney(50_75, entity.DefaultCurrency), output.Total)
 
-	transactionGateway := &TransactionGatewayMock{}
-	accountGateway := &AccountGatewayMock{}
+	assert.Equal(t, entity.NewMoney(49_25, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountTo.ID).Balance)
+	assert.Equal(t, entity.NewMoney(75, entity.DefaultCurrency), memorytest.FindAccount(t, store, revenue.ID).Balance)
 
-	accountGateway.On("FindByID", "account-from-id").Return(accountFrom, nil)
-	accountGateway.On("FindByID", "account-to-id").Return(accountTo, nil)
+	fees, err := memory.NewTransactionGateway(store).FindFees(output.ID)
+	assert.Nil(t, err)
+	assert.Len(t, fees, 1)
+	assert.Equal(t, output.FeeTransactionID, fees[0].ID)
+}
 
-	uc := NewCreateTransactionUseCase(transactionGateway, accountGateway)
+func TestCreateTransactionUseCase_ExecuteFromRevenueAccount(t *testing.T) {
+	store, revenue, accountTo := memorytest.NewStore(t, 100_00, 0)
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
+	uc.FeePolicy = fee.Flat{Amount: entity.NewMoney(1_00, entity.DefaultCurrency)}
+	uc.RevenueAccountID = revenue.ID
 
-	input := CreateTransactionInputDTO{
-		AccountIDFrom: "account-from-id",
-		AccountIDTo:   "account-to-id",
-		Amount:        -10.0,
-	}
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: revenue.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
 
-	output, err := uc.Execute(input)
+	assert.Nil(t, err)
+	assert.True(t, output.Fee.IsZero())
+	assert.Empty(t, output.FeeTransactionID)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), output.Total)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, revenue.ID).Balance)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountTo.ID).Balance)
+}
 
-	assert.NotNil(t, err)
+func TestCreateTransactionUseCase_ExecuteWithoutRevenueAccount(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
+	uc.FeePolicy = fee.Flat{Amount: entity.NewMoney(1_00, entity.DefaultCurrency)}
+
+	for _, id := range []string{"", "missing"} {
+		uc.RevenueAccountID = id
+		output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+			AccountIDFrom: accountFrom.ID,
+			AccountIDTo:   accountTo.ID,
+			Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+		})
+
+		assert.Nil(t, output)
+		assert.ErrorIs(t, err, ErrRevenueAccountNotConfigured)
+		assert.NotErrorIs(t, err, entity.ErrAccountNotFound)
+	}
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+
+	uc.RevenueAccountID = accountTo.ID
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   "missing-payee",
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
 	assert.Nil(t, output)
-	assert.Equal(t, "amount must be greater than zero", err.Error())
-
-	accountGateway.AssertExpectations(t)
-	accountGateway.AssertNumberOfCalls(t, "FindByID", 2)
-	transactionGateway.AssertNumberOfCalls(t, "Save", 0)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
 }
 
-func TestCreateTransactionUseCase_ExecuteWithTransactionGatewayError(t *testing.T) {
-	clientFrom, _ := entity.NewClient("John Doe", "john@example.com")
-	clientTo, _ := entity.NewClient("Jane Doe", "jane@example.com")
+func TestCreateTransactionUseCase_ExecuteWithoutFundsForFee(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	revenue := entity.NewAccount(accountTo.Client)
+	assert.Nil(t, memory.NewAccountGateway(store).Save(revenue))
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
+	uc.FeePolicy = fee.Flat{Amount: entity.NewMoney(1_00, entity.DefaultCurrency)}
+	uc.RevenueAccountID = revenue.ID
 
-	accountFrom := entity.NewAccount(clientFrom)
-	accountFrom.Credit(100.0)
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(100_00, entity.DefaultCurrency),
+	})
 
-	accountTo := entity.NewAccount(clientTo)
-
-	transactionGateway := &TransactionGatewayMock{}
-	accountGateway := &AccountGatewayMock{}
-
-	accountGateway.On("FindByID", "account-from-id").Return(accountFrom, nil)
-	accountGateway.On("FindByID", "account-to-id").Return(accountTo, nil)
-	transactionGateway.On("Save", mock.Anything).Return(errors.New("database error"))
-
-	uc := NewCreateTransactionUseCase(transactionGateway, accountGateway)
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrInsufficientFunds)
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+	assert.True(t, memorytest.FindAccount(t, store, accountTo.ID).Balance.IsZero())
+	assert.True(t, memorytest.FindAccount(t, store, revenue.ID).Balance.IsZero())
+}
 
+func TestCreateTransactionUseCase_ExecuteWithLimitExceeded(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
+	uc.Limits = limits.NewChecker(limits.Rule{
+		Name:     "daily-total",
+		Scope:    limits.ScopeAccount,
+		Window:   24 * time.Hour,
+		MaxTotal: entity.NewMoney(100_00, entity.DefaultCurrency),
+	})
 	input := CreateTransactionInputDTO{
-		AccountIDFrom: "account-from-id",
-		AccountIDTo:   "account-to-id",
-		Amount:        50.0,
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(40_00, entity.DefaultCurrency),
+	}
+	for range 2 {
+		_, err := uc.Execute(context.Background(), input)
+		assert.Nil(t, err)
 	}
 
-	output, err := uc.Execute(input)
+	input.Amount = entity.NewMoney(30_00, entity.DefaultCurrency)
+	output, err := uc.Execute(context.Background(), input)
 
-	assert.NotNil(t, err)
 	assert.Nil(t, output)
-	assert.Equal(t, "database error", err.Error())
+	var exceeded *entity.LimitExceededError
+	assert.ErrorAs(t, err, &exceeded)
+	assert.Equal(t, "daily-total", exceeded.Rule)
+	assert.Equal(t, entity.NewMoney(20_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
+}
 
-	// Note: The transaction was committed (balances changed) but save failed
-	assert.Equal(t, 50.0, accountFrom.Balance)
-	assert.Equal(t, 50.0, accountTo.Balance)
+func TestCreateTransactionUseCase_ExecuteLimitsExcludeFee(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	revenue := entity.NewAccount(accountTo.Client)
+	assert.Nil(t, memory.NewAccountGateway(store).Save(revenue))
+	uc := NewCreateTransactionUseCase(memory.NewUow(store))
+	uc.FeePolicy = fee.Flat{Amount: entity.NewMoney(1_00, entity.DefaultCurrency)}
+	uc.RevenueAccountID = revenue.ID
+	uc.Limits = limits.NewChecker(limits.Rule{
+		Name:      "per-transfer",
+		Scope:     limits.ScopeAccount,
+		MaxAmount: entity.NewMoney(50_00, entity.DefaultCurrency),
+		Window:    24 * time.Hour,
+		MaxTotal:  entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
+
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
 
-	transactionGateway.AssertExpectations(t)
-	accountGateway.AssertExpectations(t)
-	transactionGateway.AssertNumberOfCalls(t, "Save", 1)
-	accountGateway.AssertNumberOfCalls(t, "FindByID", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.NewMoney(51_00, entity.DefaultCurrency), output.Total)
+	assert.Equal(t, entity.NewMoney(49_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, accountFrom.ID).Balance)
 }
 
 func TestNewCreateTransactionUseCase(t *testing.T) {
-	transactionGateway := &TransactionGatewayMock{}
-	accountGateway := &AccountGatewayMock{}
+	u := memory.NewUow(memory.NewStore())
 
-	uc := NewCreateTransactionUseCase(transactionGateway, accountGateway)
+	uc := NewCreateTransactionUseCase(u)
 
 	assert.NotNil(t, uc)
-	assert.Equal(t, transactionGateway, uc.transactionGateway)
-	assert.Equal(t, accountGateway, uc.accountGateway)
+	assert.Equal(t, u, uc.Uow)
+	assert.Equal(t, posting.DefaultMaxAttempts, uc.MaxAttempts)
+}
+
+func TestCreateTransactionUseCase_ExecuteDispatchesEvents(t *testing.T) {
+	store, accountFrom, accountTo := memorytest.NewStore(t, 100_00, 0)
+	conflicts := 1
+	dispatcher := &DispatcherMock{}
+	dispatcher.On("Dispatch", mock.Anything)
+	uc := NewCreateTransactionUseCase(memorytest.WithConflicts(memory.NewUow(store), &conflicts))
+	uc.Dispatcher = dispatcher
+
+	output, err := uc.Execute(context.Background(), CreateTransactionInputDTO{
+		AccountIDFrom: accountFrom.ID,
+		AccountIDTo:   accountTo.ID,
+		Amount:        entity.NewMoney(30_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, err)
+	dispatcher.AssertNumberOfCalls(t, "Dispatch", 1)
+	dispatched := dispatcher.Calls[0].Arguments.Get(0).([]events.Event)
+	assert.Len(t, dispatched, 3)
+	assert.Equal(t, output.ID, dispatched[0].(events.TransactionCreated).TransactionID)
+	assert.Equal(t, accountFrom.ID, dispatched[1].(events.BalanceUpdated).AccountID)
+	assert.Equal(t, accountTo.ID, dispatched[2].(events.BalanceUpdated).AccountID)
 }
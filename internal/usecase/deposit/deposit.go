@@ -0,0 +1,62 @@
+package deposit
+
+import (
+	"context"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+type DepositInputDTO struct {
+	AccountID string
+	Amount    entity.Money
+}
+
+type DepositOutputDTO struct {
+	ID        string
+	AccountID string
+	Amount    entity.Money
+	Balance   entity.Money
+}
+
+// DepositUseCase credits money received from outside the wallet to a client
+// account, taking it from the treasury account. Events are sent to
+// Dispatcher, if set, once the deposit is committed.
+type DepositUseCase struct {
+	Uow               uow.UnitOfWork
+	TreasuryAccountID string
+	MaxAttempts       int
+	Dispatcher        events.Dispatcher
+}
+
+func NewDepositUseCase(uow uow.UnitOfWork, treasuryAccountID string) *DepositUseCase {
+	return &DepositUseCase{
+		Uow:               uow,
+		TreasuryAccountID: treasuryAccountID,
+		MaxAttempts:       posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *DepositUseCase) Execute(ctx context.Context, input DepositInputDTO) (*DepositOutputDTO, error) {
+	return posting.Retry(uc.MaxAttempts, uc.Dispatcher, func() (*DepositOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
+}
+
+func (uc *DepositUseCase) execute(ctx context.Context, input DepositInputDTO) (*DepositOutputDTO, []events.Event, error) {
+	deposit, account, pending, err := posting.PostTreasury(ctx, uc.Uow, uc.TreasuryAccountID, input.AccountID, func(treasury, account *entity.Account) (*entity.Transaction, error) {
+		return entity.NewDeposit(treasury, account, input.Amount)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &DepositOutputDTO{
+		ID:        deposit.ID,
+		AccountID: account.ID,
+		Amount:    deposit.Amount,
+		Balance:   account.Balance,
+	}, pending, nil
+}
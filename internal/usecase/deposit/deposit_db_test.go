@@ -0,0 +1,76 @@
+package deposit
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/stretchr/testify/suite"
+)
+
+type DepositDBTestSuite struct {
+	suite.Suite
+	db        *sql.DB
+	accountDB *database.AccountDB
+	treasury  *entity.Account
+	account   *entity.Account
+	uc        *DepositUseCase
+}
+
+func (s *DepositDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+
+	s.accountDB = database.NewAccountDB(db)
+
+	system, _ := entity.NewClient("Wallet", "treasury@example.com")
+	client, _ := entity.NewClient("John Doe", "john@example.com")
+	for _, c := range []*entity.Client{system, client} {
+		db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", c.ID, c.Name, c.Email, c.CreatedAt)
+	}
+
+	s.treasury = entity.NewAccount(system)
+	s.account = entity.NewAccount(client)
+	s.Nil(s.accountDB.Save(s.treasury))
+	s.Nil(s.accountDB.Save(s.account))
+
+	u := uow.NewUow(db)
+	u.Register(gateway.AccountRepository, func(tx *sql.Tx) interface{} {
+		return database.NewAccountDB(tx)
+	})
+	u.Register(gateway.TransactionRepository, func(tx *sql.Tx) interface{} {
+		return database.NewTransactionDB(tx)
+	})
+	u.Register(gateway.LedgerRepository, func(tx *sql.Tx) interface{} {
+		return database.NewLedgerDB(tx)
+	})
+	s.uc = NewDepositUseCase(u, s.treasury.ID)
+}
+
+func TestDepositDBTestSuite(t *testing.T) {
+	suite.Run(t, new(DepositDBTestSuite))
+}
+
+func (s *DepositDBTestSuite) TestExecutePersistsDeposit() {
+	output, err := s.uc.Execute(context.Background(), DepositInputDTO{
+		AccountID: s.account.ID,
+		Amount:    entity.NewMoney(75_00, entity.DefaultCurrency),
+	})
+	s.Nil(err)
+
+	account, err := s.accountDB.FindByID(s.account.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(75_00, entity.DefaultCurrency), account.Balance)
+	treasury, err := s.accountDB.FindByID(s.treasury.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(-75_00, entity.DefaultCurrency), treasury.Balance)
+
+	transaction, err := database.NewTransactionDB(s.db).FindByID(output.ID)
+	s.Nil(err)
+	s.Equal(entity.TransactionDeposit, transaction.Kind)
+}
@@ -0,0 +1,113 @@
+package deposit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDepositUseCase_Execute(t *testing.T) {
+	store, treasury, account := memorytest.NewStore(t, 0, 0)
+
+	uc := NewDepositUseCase(memory.NewUow(store), treasury.ID)
+
+	output, err := uc.Execute(context.Background(), DepositInputDTO{
+		AccountID: account.ID,
+		Amount:    entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, output.ID)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), output.Balance)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, account.ID).Balance)
+	assert.Equal(t, entity.NewMoney(-50_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, treasury.ID).Balance)
+
+	transaction, err := memory.NewTransactionGateway(store).FindByID(output.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.TransactionDeposit, transaction.Kind)
+}
+
+func TestDepositUseCase_ExecuteWithInvalidAmount(t *testing.T) {
+	store, treasury, account := memorytest.NewStore(t, 0, 0)
+
+	uc := NewDepositUseCase(memory.NewUow(store), treasury.ID)
+
+	output, err := uc.Execute(context.Background(), DepositInputDTO{
+		AccountID: account.ID,
+		Amount:    entity.Zero(entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrInvalidAmount)
+	transactions, err := memory.NewTransactionGateway(store).FindByAccountID(account.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, transactions)
+}
+
+func TestDepositUseCase_ExecuteIntoFrozenAccount(t *testing.T) {
+	store, treasury, account := memorytest.NewStore(t, 0, 0)
+	assert.Nil(t, account.Freeze())
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateStatus(account))
+
+	uc := NewDepositUseCase(memory.NewUow(store), treasury.ID)
+
+	output, err := uc.Execute(context.Background(), DepositInputDTO{
+		AccountID: account.ID,
+		Amount:    entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotActive)
+	assert.True(t, memorytest.FindAccount(t, store, treasury.ID).Balance.IsZero())
+}
+
+func TestDepositUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	store, treasury, _ := memorytest.NewStore(t, 0, 0)
+
+	uc := NewDepositUseCase(memory.NewUow(store), treasury.ID)
+
+	output, err := uc.Execute(context.Background(), DepositInputDTO{
+		AccountID: "unknown",
+		Amount:    entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
+
+func TestDepositUseCase_ExecuteWithTreasuryNotConfigured(t *testing.T) {
+	store, _, account := memorytest.NewStore(t, 0, 0)
+
+	for _, treasuryAccountID := range []string{"", "treasury"} {
+		uc := NewDepositUseCase(memory.NewUow(store), treasuryAccountID)
+
+		output, err := uc.Execute(context.Background(), DepositInputDTO{
+			AccountID: account.ID,
+			Amount:    entity.NewMoney(50_00, entity.DefaultCurrency),
+		})
+
+		assert.Nil(t, output)
+		assert.ErrorIs(t, err, posting.ErrTreasuryAccountNotConfigured)
+		assert.NotErrorIs(t, err, entity.ErrAccountNotFound)
+	}
+}
+
+func TestDepositUseCase_ExecuteIntoTreasury(t *testing.T) {
+	store, treasury, _ := memorytest.NewStore(t, 0, 0)
+
+	uc := NewDepositUseCase(memory.NewUow(store), treasury.ID)
+
+	output, err := uc.Execute(context.Background(), DepositInputDTO{
+		AccountID: treasury.ID,
+		Amount:    entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, output)
+	var validationErr *entity.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
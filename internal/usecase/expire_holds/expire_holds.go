@@ -0,0 +1,123 @@
+package expireholds
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+// ExpireHoldsInputDTO releases the active holds that expired by Now. A zero
+// Now means the current time.
+type ExpireHoldsInputDTO struct {
+	Now time.Time
+}
+
+// ExpireHoldsOutputDTO lists the holds released. Holds whose account changed
+// concurrently are left for the next run; holds settled since they were
+// found are skipped.
+type ExpireHoldsOutputDTO struct {
+	ExpiredHoldIDs []string
+}
+
+// ExpireHoldsUseCase releases expired holds. The BalanceUpdated event of each
+// account is written to the outbox, when the unit of work has one, and sent
+// to Dispatcher, if set, once its hold is expired.
+type ExpireHoldsUseCase struct {
+	Uow        uow.UnitOfWork
+	Dispatcher events.Dispatcher
+}
+
+func NewExpireHoldsUseCase(uow uow.UnitOfWork) *ExpireHoldsUseCase {
+	return &ExpireHoldsUseCase{
+		Uow: uow,
+	}
+}
+
+func (uc *ExpireHoldsUseCase) Execute(ctx context.Context, input ExpireHoldsInputDTO) (*ExpireHoldsOutputDTO, error) {
+	now := input.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	var holds []*entity.Hold
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		holdRepository, err := uow.GetRepository[gateway.HoldGateway](ctx, u, gateway.HoldRepository)
+		if err != nil {
+			return err
+		}
+		holds, err = holdRepository.FindExpired(now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ExpireHoldsOutputDTO{ExpiredHoldIDs: []string{}}
+	for _, hold := range holds {
+		updated, err := uc.expire(ctx, hold.ID, now)
+		if errors.Is(err, gateway.ErrConflict) || errors.Is(err, entity.ErrHoldNotActive) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if uc.Dispatcher != nil {
+			uc.Dispatcher.Dispatch(updated...)
+		}
+		output.ExpiredHoldIDs = append(output.ExpiredHoldIDs, hold.ID)
+	}
+	return output, nil
+}
+
+// expire releases a single hold in its own unit of work, so one failure does
+// not undo the holds already expired. The hold is read again, as it may have
+// been captured or voided since it was found; it then fails with
+// entity.ErrHoldNotActive.
+func (uc *ExpireHoldsUseCase) expire(ctx context.Context, holdID string, now time.Time) ([]events.Event, error) {
+	var updated []events.Event
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		poster, err := posting.NewPoster(ctx, u)
+		if err != nil {
+			return err
+		}
+		holdRepository, err := uow.GetRepository[gateway.HoldGateway](ctx, u, gateway.HoldRepository)
+		if err != nil {
+			return err
+		}
+
+		hold, err := holdRepository.FindByID(holdID)
+		if err != nil {
+			return err
+		}
+
+		account, err := poster.FindAccount(hold.AccountID)
+		if err != nil {
+			return err
+		}
+
+		err = hold.Expire(account, now)
+		if err != nil {
+			return err
+		}
+
+		err = poster.UpdateBalance(account)
+		if err != nil {
+			return err
+		}
+
+		err = holdRepository.Update(hold)
+		if err != nil {
+			return err
+		}
+
+		updated = poster.Events
+		return nil
+	})
+	return updated, err
+}
@@ -0,0 +1,153 @@
+package expireholds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// conflictingHolds fails updating the hold with ID id as if it had been
+// modified concurrently.
+type conflictingHolds struct {
+	gateway.HoldGateway
+	id string
+}
+
+func (h conflictingHolds) Update(hold *entity.Hold) error {
+	if hold.ID == h.id {
+		return &gateway.ConflictError{Entity: "hold", ID: hold.ID}
+	}
+	return h.HoldGateway.Update(hold)
+}
+
+// staleHolds finds the given holds expired, as they were before being
+// settled.
+type staleHolds struct {
+	gateway.HoldGateway
+	expired []*entity.Hold
+}
+
+func (h staleHolds) FindExpired(now time.Time) ([]*entity.Hold, error) {
+	return h.expired, nil
+}
+
+type DispatcherMock struct {
+	mock.Mock
+}
+
+func (m *DispatcherMock) Dispatch(events ...events.Event) {
+	m.Called(events)
+}
+
+func TestExpireHoldsUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 100_00)
+	first := memorytest.PlaceHold(t, store, account.ID, 20_00, time.Now().Add(time.Minute))
+	second := memorytest.PlaceHold(t, store, account.ID, 30_00, time.Now().Add(time.Minute))
+	now := time.Now().Add(time.Hour)
+	u := memorytest.Wrap(memory.NewUow(store), func(name string, repository interface{}) interface{} {
+		if name == gateway.HoldRepository {
+			return conflictingHolds{HoldGateway: repository.(gateway.HoldGateway), id: second.ID}
+		}
+		return repository
+	})
+
+	uc := NewExpireHoldsUseCase(u)
+
+	output, err := uc.Execute(context.Background(), ExpireHoldsInputDTO{Now: now})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{first.ID}, output.ExpiredHoldIDs)
+	expired, err := memory.NewHoldGateway(store).FindByID(first.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.HoldExpired, expired.Status)
+	skipped, err := memory.NewHoldGateway(store).FindByID(second.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.HoldActive, skipped.Status)
+	assert.Equal(t, entity.NewMoney(30_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, account.ID).HeldAmount)
+}
+
+func TestExpireHoldsUseCase_ExecuteSkipsSettledHolds(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 100_00)
+	voided := memorytest.PlaceHold(t, store, account.ID, 20_00, time.Now().Add(time.Minute))
+	active := memorytest.PlaceHold(t, store, account.ID, 30_00, time.Now().Add(time.Minute))
+	now := time.Now().Add(time.Hour)
+	expired, err := memory.NewHoldGateway(store).FindExpired(now)
+	assert.Nil(t, err)
+	assert.Len(t, expired, 2)
+
+	account = memorytest.FindAccount(t, store, account.ID)
+	assert.Nil(t, voided.Void(account, now))
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateBalance(account))
+	assert.Nil(t, memory.NewHoldGateway(store).Update(voided))
+
+	u := memorytest.Wrap(memory.NewUow(store), func(name string, repository interface{}) interface{} {
+		if name == gateway.HoldRepository {
+			return staleHolds{HoldGateway: repository.(gateway.HoldGateway), expired: expired}
+		}
+		return repository
+	})
+
+	uc := NewExpireHoldsUseCase(u)
+
+	output, err := uc.Execute(context.Background(), ExpireHoldsInputDTO{Now: now})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{active.ID}, output.ExpiredHoldIDs)
+	stored, err := memory.NewHoldGateway(store).FindByID(voided.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.HoldVoided, stored.Status)
+	account = memorytest.FindAccount(t, store, account.ID)
+	assert.True(t, account.HeldAmount.IsZero())
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), account.Balance)
+}
+
+func TestExpireHoldsUseCase_ExecuteWithNothingExpired(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 100_00)
+	memorytest.PlaceHold(t, store, account.ID, 20_00, time.Now().Add(time.Hour))
+
+	uc := NewExpireHoldsUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), ExpireHoldsInputDTO{Now: time.Now()})
+
+	assert.Nil(t, err)
+	assert.Empty(t, output.ExpiredHoldIDs)
+}
+
+func TestExpireHoldsUseCase_ExecuteEmitsBalanceUpdated(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 100_00)
+	hold := memorytest.PlaceHold(t, store, account.ID, 30_00, time.Now().Add(time.Minute))
+	dispatcher := &DispatcherMock{}
+	dispatcher.On("Dispatch", mock.Anything)
+
+	uc := NewExpireHoldsUseCase(memory.NewUow(store))
+	uc.Dispatcher = dispatcher
+
+	output, err := uc.Execute(context.Background(), ExpireHoldsInputDTO{Now: time.Now().Add(time.Hour)})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{hold.ID}, output.ExpiredHoldIDs)
+	dispatcher.AssertNumberOfCalls(t, "Dispatch", 1)
+	dispatched := dispatcher.Calls[0].Arguments.Get(0).([]events.Event)
+	assert.Len(t, dispatched, 1)
+	updated := dispatched[0].(events.BalanceUpdated)
+	assert.Equal(t, account.ID, updated.AccountID)
+	assert.Equal(t, entity.Zero(entity.DefaultCurrency).Decimal(), updated.HeldAmount)
+
+	messages, err := memory.NewOutboxGateway(store).FindUnsent(-1)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, events.BalanceUpdatedName, messages[0].EventName)
+	assert.Equal(t, account.ID, messages[0].Key)
+}
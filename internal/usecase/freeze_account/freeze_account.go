@@ -0,0 +1,77 @@
+package freezeaccount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+type FreezeAccountInputDTO struct {
+	AccountID string
+}
+
+type FreezeAccountOutputDTO struct {
+	ID     string
+	Status string
+}
+
+type FreezeAccountUseCase struct {
+	Uow         uow.UnitOfWork
+	MaxAttempts int
+}
+
+func NewFreezeAccountUseCase(uow uow.UnitOfWork) *FreezeAccountUseCase {
+	return &FreezeAccountUseCase{
+		Uow:         uow,
+		MaxAttempts: posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *FreezeAccountUseCase) Execute(ctx context.Context, input FreezeAccountInputDTO) (*FreezeAccountOutputDTO, error) {
+	return posting.Retry(uc.MaxAttempts, nil, func() (*FreezeAccountOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
+}
+
+func (uc *FreezeAccountUseCase) execute(ctx context.Context, input FreezeAccountInputDTO) (*FreezeAccountOutputDTO, []events.Event, error) {
+	output := &FreezeAccountOutputDTO{}
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		accountRepository, err := uow.GetRepository[gateway.AccountGateway](ctx, u, gateway.AccountRepository)
+		if err != nil {
+			return err
+		}
+
+		account, err := accountRepository.FindByID(input.AccountID)
+		if err != nil {
+			if errors.Is(err, gateway.ErrNotFound) {
+				return fmt.Errorf("%w: %s", entity.ErrAccountNotFound, input.AccountID)
+			}
+			return err
+		}
+
+		err = account.Freeze()
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateStatus(account)
+		if err != nil {
+			return err
+		}
+
+		output.ID = account.ID
+		output.Status = string(account.Status)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return output, nil, nil
+}
@@ -0,0 +1,92 @@
+package freezeaccount
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingAccounts struct {
+	gateway.AccountGateway
+}
+
+func (failingAccounts) UpdateStatus(account *entity.Account) error {
+	return errors.New("database error")
+}
+
+func TestFreezeAccountUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+
+	uc := NewFreezeAccountUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), FreezeAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, output.ID)
+	assert.Equal(t, "frozen", output.Status)
+	account = memorytest.FindAccount(t, store, account.ID)
+	assert.Equal(t, entity.AccountStatus("frozen"), account.Status)
+	assert.Equal(t, 1, account.Version)
+}
+
+func TestFreezeAccountUseCase_ExecuteWithInvalidTransition(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+	assert.Nil(t, account.Freeze())
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateStatus(account))
+
+	uc := NewFreezeAccountUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), FreezeAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrInvalidStatusTransition)
+	assert.Equal(t, 1, memorytest.FindAccount(t, store, account.ID).Version)
+}
+
+func TestFreezeAccountUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	uc := NewFreezeAccountUseCase(memory.NewUow(memory.NewStore()))
+
+	output, err := uc.Execute(context.Background(), FreezeAccountInputDTO{AccountID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
+
+func TestFreezeAccountUseCase_ExecuteWithGatewayError(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+
+	uc := NewFreezeAccountUseCase(memorytest.Wrap(memory.NewUow(store), func(name string, repository interface{}) interface{} {
+		if name == gateway.AccountRepository {
+			return failingAccounts{AccountGateway: repository.(gateway.AccountGateway)}
+		}
+		return repository
+	}))
+
+	output, err := uc.Execute(context.Background(), FreezeAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, output)
+	assert.Equal(t, "database error", err.Error())
+}
+
+func TestFreezeAccountUseCase_ExecuteRetriesOnConflict(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+	conflicts := 1
+
+	uc := NewFreezeAccountUseCase(memorytest.WithConflicts(memory.NewUow(store), &conflicts))
+
+	output, err := uc.Execute(context.Background(), FreezeAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, output.ID)
+	assert.Equal(t, 0, conflicts)
+}
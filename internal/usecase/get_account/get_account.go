@@ -0,0 +1,56 @@
+package getaccount
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type GetAccountInputDTO struct {
+	ID string
+}
+
+type GetAccountOutputDTO struct {
+	ID             string
+	ClientID       string
+	Balance        entity.Money
+	HeldAmount     entity.Money
+	OverdraftLimit entity.Money
+	Status         string
+	Version        int
+	CreatedAt      time.Time
+}
+
+type GetAccountUseCase struct {
+	AccountGateway gateway.AccountGateway
+}
+
+func NewGetAccountUseCase(accountGateway gateway.AccountGateway) *GetAccountUseCase {
+	return &GetAccountUseCase{
+		AccountGateway: accountGateway,
+	}
+}
+
+func (uc *GetAccountUseCase) Execute(input GetAccountInputDTO) (*GetAccountOutputDTO, error) {
+	account, err := uc.AccountGateway.FindByID(input.ID)
+	if err != nil {
+		if errors.Is(err, gateway.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrAccountNotFound, input.ID)
+		}
+		return nil, err
+	}
+
+	return &GetAccountOutputDTO{
+		ID:             account.ID,
+		ClientID:       account.Client.ID,
+		Balance:        account.Balance,
+		HeldAmount:     account.HeldAmount,
+		OverdraftLimit: account.OverdraftLimit,
+		Status:         string(account.Status),
+		Version:        account.Version,
+		CreatedAt:      account.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package getaccount
+
+import (
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAccountUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	accounts := memory.NewAccountGateway(store)
+	client, _ := entity.NewClient("John Doe", "john@example.com")
+	account := entity.NewAccount(client)
+	account.Credit(entity.NewMoney(10_00, entity.DefaultCurrency))
+	assert.Nil(t, memory.NewClientGateway(store).Save(client))
+	assert.Nil(t, accounts.Save(account))
+
+	output, err := NewGetAccountUseCase(accounts).Execute(GetAccountInputDTO{ID: account.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, output.ID)
+	assert.Equal(t, client.ID, output.ClientID)
+	assert.Equal(t, entity.NewMoney(10_00, entity.DefaultCurrency), output.Balance)
+	assert.Equal(t, "active", output.Status)
+}
+
+func TestGetAccountUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	accounts := memory.NewAccountGateway(memory.NewStore())
+
+	output, err := NewGetAccountUseCase(accounts).Execute(GetAccountInputDTO{ID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
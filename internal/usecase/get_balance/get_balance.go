@@ -0,0 +1,64 @@
+package getbalance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type GetBalanceInputDTO struct {
+	AccountID string
+}
+
+// GetBalanceOutputDTO is the balance as last seen by the balance projection,
+// which can lag behind the account by the time it takes events to arrive.
+type GetBalanceOutputDTO struct {
+	AccountID         string
+	Balance           entity.Money
+	HeldAmount        entity.Money
+	Version           int
+	LastTransactionID string
+	UpdatedAt         time.Time
+}
+
+// GetBalanceUseCase reads balances from the projection instead of the
+// accounts table. An account whose balance has not been projected yet is
+// reported as not found, including when the projection has only seen its
+// transactions so far. A new account is projected with a zero balance from
+// the BalanceUpdated recorded when it is created.
+type GetBalanceUseCase struct {
+	BalanceGateway gateway.BalanceGateway
+}
+
+func NewGetBalanceUseCase(balanceGateway gateway.BalanceGateway) *GetBalanceUseCase {
+	return &GetBalanceUseCase{
+		BalanceGateway: balanceGateway,
+	}
+}
+
+func (uc *GetBalanceUseCase) Execute(input GetBalanceInputDTO) (*GetBalanceOutputDTO, error) {
+	balance, err := uc.BalanceGateway.FindByAccountID(input.AccountID)
+	if err != nil {
+		if errors.Is(err, gateway.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrAccountNotFound, input.AccountID)
+		}
+		return nil, err
+	}
+	if !balance.Projected() {
+		// Only transaction events reached the projection; the zero amounts
+		// stored with them are placeholders, not the account's balance.
+		return nil, fmt.Errorf("%w: %s", entity.ErrAccountNotFound, input.AccountID)
+	}
+
+	return &GetBalanceOutputDTO{
+		AccountID:         balance.AccountID,
+		Balance:           balance.Balance,
+		HeldAmount:        balance.HeldAmount,
+		Version:           balance.Version,
+		LastTransactionID: balance.LastTransactionID,
+		UpdatedAt:         balance.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package getbalance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/projection"
+	createaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_account"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type BalanceGatewayMock struct {
+	mock.Mock
+}
+
+func (m *BalanceGatewayMock) ApplyBalance(balance *entity.AccountBalance) error {
+	args := m.Called(balance)
+	return args.Error(0)
+}
+
+func (m *BalanceGatewayMock) ApplyTransaction(accountID, currency, transactionID string, at time.Time) error {
+	args := m.Called(accountID, currency, transactionID, at)
+	return args.Error(0)
+}
+
+func (m *BalanceGatewayMock) FindByAccountID(accountID string) (*entity.AccountBalance, error) {
+	args := m.Called(accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AccountBalance), args.Error(1)
+}
+
+func TestGetBalanceUseCase_Execute(t *testing.T) {
+	m := &BalanceGatewayMock{}
+	m.On("FindByAccountID", "account-1").Return(&entity.AccountBalance{
+		AccountID:         "account-1",
+		Balance:           entity.NewMoney(10_00, entity.DefaultCurrency),
+		HeldAmount:        entity.NewMoney(2_00, entity.DefaultCurrency),
+		Version:           3,
+		LastTransactionID: "transaction-1",
+	}, nil)
+
+	uc := NewGetBalanceUseCase(m)
+
+	output, err := uc.Execute(GetBalanceInputDTO{AccountID: "account-1"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "account-1", output.AccountID)
+	assert.Equal(t, entity.NewMoney(10_00, entity.DefaultCurrency), output.Balance)
+	assert.Equal(t, entity.NewMoney(2_00, entity.DefaultCurrency), output.HeldAmount)
+	assert.Equal(t, 3, output.Version)
+	assert.Equal(t, "transaction-1", output.LastTransactionID)
+}
+
+func TestGetBalanceUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	m := &BalanceGatewayMock{}
+	m.On("FindByAccountID", "unknown").Return(nil, &gateway.NotFoundError{Entity: "balance", ID: "unknown"})
+
+	uc := NewGetBalanceUseCase(m)
+
+	output, err := uc.Execute(GetBalanceInputDTO{AccountID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
+
+func TestGetBalanceUseCase_ExecuteWithBalanceNotProjected(t *testing.T) {
+	m := &BalanceGatewayMock{}
+	m.On("FindByAccountID", "account-1").Return(&entity.AccountBalance{
+		AccountID:         "account-1",
+		Balance:           entity.Zero(entity.DefaultCurrency),
+		HeldAmount:        entity.Zero(entity.DefaultCurrency),
+		Version:           entity.UnprojectedVersion,
+		LastTransactionID: "transaction-1",
+	}, nil)
+
+	uc := NewGetBalanceUseCase(m)
+
+	output, err := uc.Execute(GetBalanceInputDTO{AccountID: "account-1"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
+
+func TestGetBalanceUseCase_ExecuteWithNewAccount(t *testing.T) {
+	store := memory.NewStore()
+	client := memorytest.NewClient(t, store, "John Doe")
+	balances := memory.NewBalanceGateway(store)
+	dispatcher := events.NewEventDispatcher()
+	assert.Nil(t, dispatcher.Register(events.BalanceUpdatedName, projection.NewBalanceProjection(balances)))
+	create := createaccount.NewCreateAccountUseCase(memory.NewUow(store))
+	create.Dispatcher = dispatcher
+	account, err := create.Execute(context.Background(), createaccount.CreateAccountInputDTO{ClientID: client.ID})
+	assert.Nil(t, err)
+
+	output, err := NewGetBalanceUseCase(balances).Execute(GetBalanceInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, entity.Zero(entity.DefaultCurrency), output.Balance)
+	assert.Equal(t, entity.Zero(entity.DefaultCurrency), output.HeldAmount)
+	assert.Equal(t, 0, output.Version)
+	assert.Empty(t, output.LastTransactionID)
+}
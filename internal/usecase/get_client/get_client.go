@@ -0,0 +1,47 @@
+package getclient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type GetClientInputDTO struct {
+	ID string
+}
+
+type GetClientOutputDTO struct {
+	ID    string
+	Name  string
+	Email string
+	Tier  string
+}
+
+type GetClientUseCase struct {
+	ClientGateway gateway.ClientGateway
+}
+
+func NewGetClientUseCase(clientGateway gateway.ClientGateway) *GetClientUseCase {
+	return &GetClientUseCase{
+		ClientGateway: clientGateway,
+	}
+}
+
+func (uc *GetClientUseCase) Execute(input GetClientInputDTO) (*GetClientOutputDTO, error) {
+	client, err := uc.ClientGateway.Get(input.ID)
+	if err != nil {
+		if errors.Is(err, gateway.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrClientNotFound, input.ID)
+		}
+		return nil, err
+	}
+
+	return &GetClientOutputDTO{
+		ID:    client.ID,
+		Name:  client.Name,
+		Email: client.Email,
+		Tier:  string(client.Tier),
+	}, nil
+}
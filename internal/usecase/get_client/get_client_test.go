@@ -0,0 +1,32 @@
+package getclient
+
+import (
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClientUseCase_Execute(t *testing.T) {
+	clients := memory.NewClientGateway(memory.NewStore())
+	client, _ := entity.NewClient("John Doe", "john@example.com")
+	assert.Nil(t, clients.Save(client))
+
+	output, err := NewGetClientUseCase(clients).Execute(GetClientInputDTO{ID: client.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, client.ID, output.ID)
+	assert.Equal(t, "John Doe", output.Name)
+	assert.Equal(t, "john@example.com", output.Email)
+	assert.Equal(t, "standard", output.Tier)
+}
+
+func TestGetClientUseCase_ExecuteWithClientNotFound(t *testing.T) {
+	clients := memory.NewClientGateway(memory.NewStore())
+
+	output, err := NewGetClientUseCase(clients).Execute(GetClientInputDTO{ID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrClientNotFound)
+}
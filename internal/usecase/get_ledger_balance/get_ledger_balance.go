@@ -0,0 +1,62 @@
+package getledgerbalance
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type GetLedgerBalanceInputDTO struct {
+	AccountID string
+}
+
+// GetLedgerBalanceOutputDTO compares the balance derived from the ledger
+// with the balance stored on the account. Reconciled is false when they
+// differ, e.g. because funds were added outside of a ledger posting.
+type GetLedgerBalanceOutputDTO struct {
+	AccountID      string
+	LedgerBalance  entity.Money
+	AccountBalance entity.Money
+	Reconciled     bool
+}
+
+type GetLedgerBalanceUseCase struct {
+	LedgerGateway  gateway.LedgerGateway
+	AccountGateway gateway.AccountGateway
+}
+
+func NewGetLedgerBalanceUseCase(ledgerGateway gateway.LedgerGateway, accountGateway gateway.AccountGateway) *GetLedgerBalanceUseCase {
+	return &GetLedgerBalanceUseCase{
+		LedgerGateway:  ledgerGateway,
+		AccountGateway: accountGateway,
+	}
+}
+
+func (uc *GetLedgerBalanceUseCase) Execute(input GetLedgerBalanceInputDTO) (*GetLedgerBalanceOutputDTO, error) {
+	account, err := uc.AccountGateway.FindByID(input.AccountID)
+	if err != nil {
+		if errors.Is(err, gateway.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrAccountNotFound, input.AccountID)
+		}
+		return nil, err
+	}
+
+	entries, err := uc.LedgerGateway.FindByAccountID(account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerBalance, err := entity.BalanceFromEntries(account.Balance.Currency(), entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetLedgerBalanceOutputDTO{
+		AccountID:      account.ID,
+		LedgerBalance:  ledgerBalance,
+		AccountBalance: account.Balance,
+		Reconciled:     ledgerBalance == account.Balance,
+	}, nil
+}
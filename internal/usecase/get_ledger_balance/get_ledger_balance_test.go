@@ -0,0 +1,52 @@
+package getledgerbalance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLedgerBalanceUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 70_00)
+	now := time.Now()
+	memorytest.Journal(t, store, "t1", "treasury", account.ID, 100_00, now)
+	memorytest.Journal(t, store, "t2", account.ID, "treasury", 30_00, now)
+
+	uc := NewGetLedgerBalanceUseCase(memory.NewLedgerGateway(store), memory.NewAccountGateway(store))
+
+	output, err := uc.Execute(GetLedgerBalanceInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, entity.NewMoney(70_00, entity.DefaultCurrency), output.LedgerBalance)
+	assert.Equal(t, entity.NewMoney(70_00, entity.DefaultCurrency), output.AccountBalance)
+	assert.True(t, output.Reconciled)
+}
+
+func TestGetLedgerBalanceUseCase_ExecuteWhenBalancesDiffer(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 50_00)
+
+	uc := NewGetLedgerBalanceUseCase(memory.NewLedgerGateway(store), memory.NewAccountGateway(store))
+
+	output, err := uc.Execute(GetLedgerBalanceInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, err)
+	assert.True(t, output.LedgerBalance.IsZero())
+	assert.False(t, output.Reconciled)
+}
+
+func TestGetLedgerBalanceUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	store := memory.NewStore()
+
+	uc := NewGetLedgerBalanceUseCase(memory.NewLedgerGateway(store), memory.NewAccountGateway(store))
+
+	output, err := uc.Execute(GetLedgerBalanceInputDTO{AccountID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
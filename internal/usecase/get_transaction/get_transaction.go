@@ -0,0 +1,59 @@
+package gettransaction
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type GetTransactionInputDTO struct {
+	ID string
+}
+
+// GetTransactionOutputDTO describes a stored transaction. ReversalOf and
+// FeeOf are empty unless the transaction reverses, or is the fee of,
+// another one.
+type GetTransactionOutputDTO struct {
+	ID            string
+	Kind          string
+	AccountIDFrom string
+	AccountIDTo   string
+	Amount        entity.Money
+	ReversalOf    string
+	FeeOf         string
+	CreatedAt     time.Time
+}
+
+type GetTransactionUseCase struct {
+	TransactionGateway gateway.TransactionGateway
+}
+
+func NewGetTransactionUseCase(transactionGateway gateway.TransactionGateway) *GetTransactionUseCase {
+	return &GetTransactionUseCase{
+		TransactionGateway: transactionGateway,
+	}
+}
+
+func (uc *GetTransactionUseCase) Execute(input GetTransactionInputDTO) (*GetTransactionOutputDTO, error) {
+	transaction, err := uc.TransactionGateway.FindByID(input.ID)
+	if err != nil {
+		if errors.Is(err, gateway.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrTransactionNotFound, input.ID)
+		}
+		return nil, err
+	}
+
+	return &GetTransactionOutputDTO{
+		ID:            transaction.ID,
+		Kind:          string(transaction.Kind),
+		AccountIDFrom: transaction.AccountFrom.ID,
+		AccountIDTo:   transaction.AccountTo.ID,
+		Amount:        transaction.Amount,
+		ReversalOf:    transaction.ReversalOf,
+		FeeOf:         transaction.FeeOf,
+		CreatedAt:     transaction.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package gettransaction
+
+import (
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTransactionUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	client, _ := entity.NewClient("John Doe", "john@example.com")
+	from := entity.NewAccount(client)
+	from.Credit(entity.NewMoney(10_00, entity.DefaultCurrency))
+	to := entity.NewAccount(client)
+	transaction, _ := entity.NewTransaction(from, to, entity.NewMoney(4_00, entity.DefaultCurrency))
+	assert.Nil(t, memory.NewClientGateway(store).Save(client))
+	assert.Nil(t, memory.NewAccountGateway(store).Save(from))
+	assert.Nil(t, memory.NewAccountGateway(store).Save(to))
+	assert.Nil(t, memory.NewTransactionGateway(store).Save(transaction))
+
+	output, err := NewGetTransactionUseCase(memory.NewTransactionGateway(store)).Execute(GetTransactionInputDTO{ID: transaction.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, transaction.ID, output.ID)
+	assert.Equal(t, "transfer", output.Kind)
+	assert.Equal(t, from.ID, output.AccountIDFrom)
+	assert.Equal(t, to.ID, output.AccountIDTo)
+	assert.Equal(t, entity.NewMoney(4_00, entity.DefaultCurrency), output.Amount)
+}
+
+func TestGetTransactionUseCase_ExecuteWithTransactionNotFound(t *testing.T) {
+	transactions := memory.NewTransactionGateway(memory.NewStore())
+
+	output, err := NewGetTransactionUseCase(transactions).Execute(GetTransactionInputDTO{ID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrTransactionNotFound)
+}
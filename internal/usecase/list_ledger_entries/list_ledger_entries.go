@@ -0,0 +1,78 @@
+package listledgerentries
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type ListLedgerEntriesInputDTO struct {
+	AccountID string
+}
+
+type LedgerEntryOutputDTO struct {
+	ID             string
+	TransactionID  string
+	Direction      string
+	Amount         entity.Money
+	RunningBalance entity.Money
+	CreatedAt      time.Time
+}
+
+type ListLedgerEntriesOutputDTO struct {
+	AccountID string
+	Balance   entity.Money
+	Entries   []LedgerEntryOutputDTO
+}
+
+type ListLedgerEntriesUseCase struct {
+	LedgerGateway  gateway.LedgerGateway
+	AccountGateway gateway.AccountGateway
+}
+
+func NewListLedgerEntriesUseCase(ledgerGateway gateway.LedgerGateway, accountGateway gateway.AccountGateway) *ListLedgerEntriesUseCase {
+	return &ListLedgerEntriesUseCase{
+		LedgerGateway:  ledgerGateway,
+		AccountGateway: accountGateway,
+	}
+}
+
+func (uc *ListLedgerEntriesUseCase) Execute(input ListLedgerEntriesInputDTO) (*ListLedgerEntriesOutputDTO, error) {
+	account, err := uc.AccountGateway.FindByID(input.AccountID)
+	if err != nil {
+		if errors.Is(err, gateway.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrAccountNotFound, input.AccountID)
+		}
+		return nil, err
+	}
+
+	entries, err := uc.LedgerGateway.FindByAccountID(account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListLedgerEntriesOutputDTO{
+		AccountID: account.ID,
+		Balance:   entity.Zero(account.Balance.Currency()),
+		Entries:   make([]LedgerEntryOutputDTO, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		output.Balance, err = output.Balance.Add(entry.SignedAmount())
+		if err != nil {
+			return nil, err
+		}
+		output.Entries = append(output.Entries, LedgerEntryOutputDTO{
+			ID:             entry.ID,
+			TransactionID:  entry.TransactionID,
+			Direction:      string(entry.Direction),
+			Amount:         entry.Amount,
+			RunningBalance: output.Balance,
+			CreatedAt:      entry.CreatedAt,
+		})
+	}
+
+	return output, nil
+}
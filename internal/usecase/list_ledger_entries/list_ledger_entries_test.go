@@ -0,0 +1,58 @@
+package listledgerentries
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListLedgerEntriesUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+	now := time.Now()
+	memorytest.Journal(t, store, "t1", "treasury", account.ID, 100_00, now)
+	memorytest.Journal(t, store, "t2", account.ID, "treasury", 30_00, now.Add(time.Second))
+	memorytest.Journal(t, store, "t3", "treasury", account.ID, 5_50, now.Add(2*time.Second))
+
+	uc := NewListLedgerEntriesUseCase(memory.NewLedgerGateway(store), memory.NewAccountGateway(store))
+
+	output, err := uc.Execute(ListLedgerEntriesInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, output.AccountID)
+	assert.Equal(t, entity.NewMoney(75_50, entity.DefaultCurrency), output.Balance)
+	assert.Len(t, output.Entries, 3)
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), output.Entries[0].RunningBalance)
+	assert.Equal(t, entity.NewMoney(70_00, entity.DefaultCurrency), output.Entries[1].RunningBalance)
+	assert.Equal(t, "debit", output.Entries[1].Direction)
+	assert.Equal(t, now.Add(time.Second), output.Entries[1].CreatedAt)
+	assert.Equal(t, entity.NewMoney(75_50, entity.DefaultCurrency), output.Entries[2].RunningBalance)
+}
+
+func TestListLedgerEntriesUseCase_ExecuteWithNoEntries(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+
+	uc := NewListLedgerEntriesUseCase(memory.NewLedgerGateway(store), memory.NewAccountGateway(store))
+
+	output, err := uc.Execute(ListLedgerEntriesInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, err)
+	assert.True(t, output.Balance.IsZero())
+	assert.Empty(t, output.Entries)
+}
+
+func TestListLedgerEntriesUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	store := memory.NewStore()
+
+	uc := NewListLedgerEntriesUseCase(memory.NewLedgerGateway(store), memory.NewAccountGateway(store))
+
+	output, err := uc.Execute(ListLedgerEntriesInputDTO{AccountID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
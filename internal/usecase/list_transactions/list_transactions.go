@@ -0,0 +1,78 @@
+package listtransactions
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+type ListTransactionsInputDTO struct {
+	AccountID string
+}
+
+type TransactionOutputDTO struct {
+	ID            string
+	Kind          string
+	AccountIDFrom string
+	AccountIDTo   string
+	Amount        entity.Money
+	ReversalOf    string
+	FeeOf         string
+	CreatedAt     time.Time
+}
+
+// ListTransactionsOutputDTO lists the transactions sent or received by an
+// account, oldest first.
+type ListTransactionsOutputDTO struct {
+	AccountID    string
+	Transactions []TransactionOutputDTO
+}
+
+type ListTransactionsUseCase struct {
+	TransactionGateway gateway.TransactionGateway
+	AccountGateway     gateway.AccountGateway
+}
+
+func NewListTransactionsUseCase(transactionGateway gateway.TransactionGateway, accountGateway gateway.AccountGateway) *ListTransactionsUseCase {
+	return &ListTransactionsUseCase{
+		TransactionGateway: transactionGateway,
+		AccountGateway:     accountGateway,
+	}
+}
+
+func (uc *ListTransactionsUseCase) Execute(input ListTransactionsInputDTO) (*ListTransactionsOutputDTO, error) {
+	account, err := uc.AccountGateway.FindByID(input.AccountID)
+	if err != nil {
+		if errors.Is(err, gateway.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrAccountNotFound, input.AccountID)
+		}
+		return nil, err
+	}
+
+	transactions, err := uc.TransactionGateway.FindByAccountID(account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListTransactionsOutputDTO{
+		AccountID:    account.ID,
+		Transactions: make([]TransactionOutputDTO, 0, len(transactions)),
+	}
+	for _, transaction := range transactions {
+		output.Transactions = append(output.Transactions, TransactionOutputDTO{
+			ID:            transaction.ID,
+			Kind:          string(transaction.Kind),
+			AccountIDFrom: transaction.AccountFrom.ID,
+			AccountIDTo:   transaction.AccountTo.ID,
+			Amount:        transaction.Amount,
+			ReversalOf:    transaction.ReversalOf,
+			FeeOf:         transaction.FeeOf,
+			CreatedAt:     transaction.CreatedAt,
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,42 @@
+package listtransactions
+
+import (
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTransactionsUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	client, _ := entity.NewClient("John Doe", "john@example.com")
+	from := entity.NewAccount(client)
+	from.Credit(entity.NewMoney(10_00, entity.DefaultCurrency))
+	to := entity.NewAccount(client)
+	transaction, _ := entity.NewTransaction(from, to, entity.NewMoney(4_00, entity.DefaultCurrency))
+	assert.Nil(t, memory.NewClientGateway(store).Save(client))
+	assert.Nil(t, memory.NewAccountGateway(store).Save(from))
+	assert.Nil(t, memory.NewAccountGateway(store).Save(to))
+	assert.Nil(t, memory.NewTransactionGateway(store).Save(transaction))
+
+	uc := NewListTransactionsUseCase(memory.NewTransactionGateway(store), memory.NewAccountGateway(store))
+	output, err := uc.Execute(ListTransactionsInputDTO{AccountID: from.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, from.ID, output.AccountID)
+	assert.Len(t, output.Transactions, 1)
+	assert.Equal(t, transaction.ID, output.Transactions[0].ID)
+	assert.Equal(t, to.ID, output.Transactions[0].AccountIDTo)
+	assert.Equal(t, entity.NewMoney(4_00, entity.DefaultCurrency), output.Transactions[0].Amount)
+}
+
+func TestListTransactionsUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	store := memory.NewStore()
+
+	uc := NewListTransactionsUseCase(memory.NewTransactionGateway(store), memory.NewAccountGateway(store))
+	output, err := uc.Execute(ListTransactionsInputDTO{AccountID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
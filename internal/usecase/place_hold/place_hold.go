@@ -0,0 +1,108 @@
+package placehold
+
+import (
+	"context"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+// PlaceHoldInputDTO reserves Amount on an account. Holds without ExpiresAt
+// expire after DefaultHoldDuration.
+type PlaceHoldInputDTO struct {
+	AccountID string
+	Amount    entity.Money
+	ExpiresAt time.Time
+}
+
+type PlaceHoldOutputDTO struct {
+	ID               string
+	AccountID        string
+	Amount           entity.Money
+	AvailableBalance entity.Money
+	ExpiresAt        time.Time
+}
+
+const DefaultHoldDuration = 7 * 24 * time.Hour
+
+// PlaceHoldUseCase reserves money on an account. The BalanceUpdated event of
+// the account is written to the outbox, when the unit of work has one, and
+// sent to Dispatcher, if set, once the hold is committed.
+type PlaceHoldUseCase struct {
+	Uow         uow.UnitOfWork
+	MaxAttempts int
+	Dispatcher  events.Dispatcher
+}
+
+func NewPlaceHoldUseCase(uow uow.UnitOfWork) *PlaceHoldUseCase {
+	return &PlaceHoldUseCase{
+		Uow:         uow,
+		MaxAttempts: posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *PlaceHoldUseCase) Execute(ctx context.Context, input PlaceHoldInputDTO) (*PlaceHoldOutputDTO, error) {
+	if input.ExpiresAt.IsZero() {
+		input.ExpiresAt = time.Now().Add(DefaultHoldDuration)
+	}
+	return posting.Retry(uc.MaxAttempts, uc.Dispatcher, func() (*PlaceHoldOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
+}
+
+func (uc *PlaceHoldUseCase) execute(ctx context.Context, input PlaceHoldInputDTO) (*PlaceHoldOutputDTO, []events.Event, error) {
+	output := &PlaceHoldOutputDTO{}
+	var pending []events.Event
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		poster, err := posting.NewPoster(ctx, u)
+		if err != nil {
+			return err
+		}
+		holdRepository, err := uow.GetRepository[gateway.HoldGateway](ctx, u, gateway.HoldRepository)
+		if err != nil {
+			return err
+		}
+
+		account, err := poster.FindAccount(input.AccountID)
+		if err != nil {
+			return err
+		}
+
+		hold, err := entity.NewHold(account, input.Amount, input.ExpiresAt)
+		if err != nil {
+			return err
+		}
+
+		err = poster.UpdateBalance(account)
+		if err != nil {
+			return err
+		}
+
+		err = holdRepository.Save(hold)
+		if err != nil {
+			return err
+		}
+
+		available, err := account.AvailableBalance()
+		if err != nil {
+			return err
+		}
+
+		output.ID = hold.ID
+		output.AccountID = account.ID
+		output.Amount = hold.Amount
+		output.AvailableBalance = available
+		output.ExpiresAt = hold.ExpiresAt
+		pending = poster.Events
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return output, pending, nil
+}
@@ -0,0 +1,106 @@
+package placehold
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type DispatcherMock struct {
+	mock.Mock
+}
+
+func (m *DispatcherMock) Dispatch(events ...events.Event) {
+	m.Called(events)
+}
+
+func TestPlaceHoldUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 100_00)
+
+	uc := NewPlaceHoldUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), PlaceHoldInputDTO{
+		AccountID: account.ID,
+		Amount:    entity.NewMoney(30_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, output.ID)
+	assert.Equal(t, entity.NewMoney(30_00, entity.DefaultCurrency), output.Amount)
+	assert.Equal(t, entity.NewMoney(70_00, entity.DefaultCurrency), output.AvailableBalance)
+	assert.WithinDuration(t, time.Now().Add(DefaultHoldDuration), output.ExpiresAt, time.Minute)
+	assert.Equal(t, entity.NewMoney(30_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, account.ID).HeldAmount)
+
+	hold, err := memory.NewHoldGateway(store).FindByID(output.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, hold.AccountID)
+	assert.Equal(t, entity.HoldActive, hold.Status)
+}
+
+func TestPlaceHoldUseCase_ExecuteWithInsufficientFunds(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 10_00)
+
+	uc := NewPlaceHoldUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), PlaceHoldInputDTO{
+		AccountID: account.ID,
+		Amount:    entity.NewMoney(30_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrInsufficientFunds)
+	account = memorytest.FindAccount(t, store, account.ID)
+	assert.True(t, account.HeldAmount.IsZero())
+	assert.Equal(t, 0, account.Version)
+}
+
+func TestPlaceHoldUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	uc := NewPlaceHoldUseCase(memory.NewUow(memory.NewStore()))
+
+	output, err := uc.Execute(context.Background(), PlaceHoldInputDTO{
+		AccountID: "unknown",
+		Amount:    entity.NewMoney(30_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
+
+func TestPlaceHoldUseCase_ExecuteEmitsBalanceUpdated(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 100_00)
+	dispatcher := &DispatcherMock{}
+	dispatcher.On("Dispatch", mock.Anything)
+
+	uc := NewPlaceHoldUseCase(memory.NewUow(store))
+	uc.Dispatcher = dispatcher
+
+	_, err := uc.Execute(context.Background(), PlaceHoldInputDTO{
+		AccountID: account.ID,
+		Amount:    entity.NewMoney(30_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, err)
+	dispatcher.AssertNumberOfCalls(t, "Dispatch", 1)
+	dispatched := dispatcher.Calls[0].Arguments.Get(0).([]events.Event)
+	assert.Len(t, dispatched, 1)
+	updated := dispatched[0].(events.BalanceUpdated)
+	assert.Equal(t, account.ID, updated.AccountID)
+	assert.Equal(t, entity.NewMoney(30_00, entity.DefaultCurrency).Decimal(), updated.HeldAmount)
+	assert.Equal(t, 1, updated.Version)
+
+	messages, err := memory.NewOutboxGateway(store).FindUnsent(-1)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, events.BalanceUpdatedName, messages[0].EventName)
+	assert.Equal(t, account.ID, messages[0].Key)
+}
@@ -0,0 +1,120 @@
+package reversetransaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+// ReverseTransactionInputDTO names the transaction to reverse. A zero Amount
+// reverses whatever has not been reversed yet; otherwise only Amount is
+// refunded.
+type ReverseTransactionInputDTO struct {
+	TransactionID string
+	Amount        entity.Money
+}
+
+type ReverseTransactionOutputDTO struct {
+	ID              string
+	ReversalOf      string
+	Amount          entity.Money
+	RemainingAmount entity.Money
+}
+
+// ReverseTransactionUseCase pays back a transaction, in full or in part.
+// Events are sent to Dispatcher, if set, once the reversal is committed.
+type ReverseTransactionUseCase struct {
+	Uow         uow.UnitOfWork
+	MaxAttempts int
+	Dispatcher  events.Dispatcher
+}
+
+func NewReverseTransactionUseCase(uow uow.UnitOfWork) *ReverseTransactionUseCase {
+	return &ReverseTransactionUseCase{
+		Uow:         uow,
+		MaxAttempts: posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *ReverseTransactionUseCase) Execute(ctx context.Context, input ReverseTransactionInputDTO) (*ReverseTransactionOutputDTO, error) {
+	return posting.Retry(uc.MaxAttempts, uc.Dispatcher, func() (*ReverseTransactionOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
+}
+
+func (uc *ReverseTransactionUseCase) execute(ctx context.Context, input ReverseTransactionInputDTO) (*ReverseTransactionOutputDTO, []events.Event, error) {
+	output := &ReverseTransactionOutputDTO{}
+	var pending []events.Event
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		poster, err := posting.NewPoster(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		// Locking the original first makes concurrent reversals of it run one
+		// after the other, each seeing the reversals committed before it.
+		original, err := poster.Transactions.FindByIDForUpdate(input.TransactionID)
+		if err != nil {
+			if errors.Is(err, gateway.ErrNotFound) {
+				return fmt.Errorf("%w: %s", entity.ErrTransactionNotFound, input.TransactionID)
+			}
+			return err
+		}
+
+		reversals, err := poster.Transactions.FindReversals(original.ID)
+		if err != nil {
+			return err
+		}
+
+		amount := input.Amount
+		if amount.IsZero() {
+			amount, err = original.RemainingAmount(reversals)
+			if err != nil {
+				return err
+			}
+			if amount.IsZero() {
+				return fmt.Errorf("%w: %s is already fully reversed", entity.ErrInvalidReversal, original.ID)
+			}
+		}
+
+		// The funds go back from the original recipient to the original sender.
+		accounts, err := poster.FindAccounts(original.AccountTo.ID, original.AccountFrom.ID)
+		if err != nil {
+			return err
+		}
+		accountFrom, accountTo := accounts[0], accounts[1]
+
+		reversal, err := entity.NewReversal(original, accountFrom, accountTo, amount, reversals)
+		if err != nil {
+			return err
+		}
+
+		err = poster.Post(reversal)
+		if err != nil {
+			return err
+		}
+
+		remaining, err := original.RemainingAmount(append(reversals, reversal))
+		if err != nil {
+			return err
+		}
+
+		output.ID = reversal.ID
+		output.ReversalOf = original.ID
+		output.Amount = reversal.Amount
+		output.RemainingAmount = remaining
+		pending = poster.Events
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return output, pending, nil
+}
@@ -0,0 +1,165 @@
+package reversetransaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReverseTransactionDBTestSuite struct {
+	suite.Suite
+	db            *sql.DB
+	accountDB     *database.AccountDB
+	transactionDB *database.TransactionDB
+	original      *entity.Transaction
+	uc            *ReverseTransactionUseCase
+}
+
+func (s *ReverseTransactionDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+
+	s.accountDB = database.NewAccountDB(db)
+	s.transactionDB = database.NewTransactionDB(db)
+
+	clientFrom, _ := entity.NewClient("John Doe", "john@example.com")
+	clientTo, _ := entity.NewClient("Jane Doe", "jane@example.com")
+	for _, client := range []*entity.Client{clientFrom, clientTo} {
+		db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	}
+
+	sender := entity.NewAccount(clientFrom)
+	sender.Credit(entity.NewMoney(100_00, entity.DefaultCurrency))
+	recipient := entity.NewAccount(clientTo)
+	original, err := entity.NewTransaction(sender, recipient, entity.NewMoney(100_00, entity.DefaultCurrency))
+	s.Nil(err)
+	s.original = original
+	s.Nil(s.accountDB.Save(sender))
+	s.Nil(s.accountDB.Save(recipient))
+	s.Nil(s.transactionDB.Save(s.original))
+
+	u := uow.NewUow(db)
+	u.Register(gateway.AccountRepository, func(tx *sql.Tx) interface{} {
+		return database.NewAccountDB(tx)
+	})
+	u.Register(gateway.TransactionRepository, func(tx *sql.Tx) interface{} {
+		return database.NewTransactionDB(tx)
+	})
+	u.Register(gateway.LedgerRepository, func(tx *sql.Tx) interface{} {
+		return database.NewLedgerDB(tx)
+	})
+	s.uc = NewReverseTransactionUseCase(u)
+}
+
+func TestReverseTransactionDBTestSuite(t *testing.T) {
+	suite.Run(t, new(ReverseTransactionDBTestSuite))
+}
+
+func (s *ReverseTransactionDBTestSuite) TestExecuteRecordsReversals() {
+	output, err := s.uc.Execute(context.Background(), ReverseTransactionInputDTO{
+		TransactionID: s.original.ID,
+		Amount:        entity.NewMoney(40_00, entity.DefaultCurrency),
+	})
+	s.Nil(err)
+	s.Equal(entity.NewMoney(60_00, entity.DefaultCurrency), output.RemainingAmount)
+
+	output, err = s.uc.Execute(context.Background(), ReverseTransactionInputDTO{TransactionID: s.original.ID})
+	s.Nil(err)
+	s.Equal(entity.NewMoney(60_00, entity.DefaultCurrency), output.Amount)
+
+	reversals, err := s.transactionDB.FindReversals(s.original.ID)
+	s.Nil(err)
+	s.Len(reversals, 2)
+
+	sender, err := s.accountDB.FindByID(s.original.AccountFrom.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(100_00, entity.DefaultCurrency), sender.Balance)
+	recipient, err := s.accountDB.FindByID(s.original.AccountTo.ID)
+	s.Nil(err)
+	s.True(recipient.Balance.IsZero())
+
+	_, err = s.uc.Execute(context.Background(), ReverseTransactionInputDTO{TransactionID: s.original.ID})
+	s.ErrorIs(err, entity.ErrInvalidReversal)
+}
+
+// TestExecuteConcurrently runs reversals of the same transaction
+// concurrently, which refund more than the transaction unless each one sees
+// the reversals committed before it.
+func TestExecuteConcurrently(t *testing.T) {
+	backends := []struct {
+		name    string
+		open    func(t testing.TB) *sql.DB
+		dialect database.Dialect
+	}{
+		{"SQLite", databasetest.NewDB, database.SQLite},
+		{"MySQL", databasetest.NewMySQLDB, database.MySQL},
+		{"Postgres", databasetest.NewPostgresDB, database.Postgres},
+	}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			db := backend.open(t)
+			clients := &database.ClientDB{DB: db, Dialect: backend.dialect}
+			accounts := &database.AccountDB{DB: db, Dialect: backend.dialect}
+			var pair [2]*entity.Account
+			for i := range pair {
+				client, _ := entity.NewClient("John Doe", "john@example.com")
+				assert.Nil(t, clients.Save(client))
+				pair[i] = entity.NewAccount(client)
+			}
+			sender, recipient := pair[0], pair[1]
+			sender.Credit(entity.NewMoney(100_00, entity.DefaultCurrency))
+			original, err := entity.NewTransaction(sender, recipient, entity.NewMoney(100_00, entity.DefaultCurrency))
+			assert.Nil(t, err)
+			// Enough for the recipient to pay back more than it received.
+			recipient.Credit(entity.NewMoney(1000_00, entity.DefaultCurrency))
+			assert.Nil(t, accounts.Save(sender))
+			assert.Nil(t, accounts.Save(recipient))
+			assert.Nil(t, (&database.TransactionDB{DB: db, Dialect: backend.dialect}).Save(original))
+
+			u := uow.NewUow(db)
+			database.RegisterRepositories(u, backend.dialect)
+			uc := NewReverseTransactionUseCase(u)
+			uc.MaxAttempts = 10
+
+			const reversals = 10
+			errs := make(chan error, reversals)
+			var wg sync.WaitGroup
+			for i := 0; i < reversals; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := uc.Execute(context.Background(), ReverseTransactionInputDTO{
+						TransactionID: original.ID,
+						Amount:        entity.NewMoney(30_00, entity.DefaultCurrency),
+					})
+					errs <- err
+				}()
+			}
+			wg.Wait()
+			close(errs)
+
+			reversed := 0
+			for err := range errs {
+				if err == nil {
+					reversed++
+				} else if !errors.Is(err, entity.ErrReversalExceedsOriginal) {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+			assert.Equal(t, 3, reversed)
+			retrieved, err := accounts.FindByID(sender.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, entity.NewMoney(90_00, entity.DefaultCurrency), retrieved.Balance)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package reversetransaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+)
+
+// reverse stores an earlier reversal of amount minor units of original.
+func reverse(t *testing.T, store *memory.Store, original *entity.Transaction, amount int64) {
+	recipient := memorytest.FindAccount(t, store, original.AccountTo.ID)
+	sender := memorytest.FindAccount(t, store, original.AccountFrom.ID)
+	reversal, err := entity.NewReversal(original, recipient, sender, entity.NewMoney(amount, entity.DefaultCurrency), nil)
+	assert.Nil(t, err)
+	memorytest.Post(t, store, reversal)
+}
+
+func TestReverseTransactionUseCase_Execute(t *testing.T) {
+	store, sender, recipient := memorytest.NewStore(t, 100_00, 0)
+	original := memorytest.Transfer(t, store, sender, recipient, 100_00)
+
+	uc := NewReverseTransactionUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), ReverseTransactionInputDTO{TransactionID: original.ID})
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, output.ID)
+	assert.Equal(t, original.ID, output.ReversalOf)
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), output.Amount)
+	assert.True(t, output.RemainingAmount.IsZero())
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, sender.ID).Balance)
+	assert.True(t, memorytest.FindAccount(t, store, recipient.ID).Balance.IsZero())
+
+	reversals, err := memory.NewTransactionGateway(store).FindReversals(original.ID)
+	assert.Nil(t, err)
+	assert.Len(t, reversals, 1)
+	assert.Equal(t, output.ID, reversals[0].ID)
+	entries, err := memory.NewLedgerGateway(store).FindByAccountID(sender.ID)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestReverseTransactionUseCase_ExecutePartialAmount(t *testing.T) {
+	store, sender, recipient := memorytest.NewStore(t, 100_00, 0)
+	original := memorytest.Transfer(t, store, sender, recipient, 100_00)
+	reverse(t, store, original, 30_00)
+
+	uc := NewReverseTransactionUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), ReverseTransactionInputDTO{
+		TransactionID: original.ID,
+		Amount:        entity.NewMoney(50_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, entity.NewMoney(50_00, entity.DefaultCurrency), output.Amount)
+	assert.Equal(t, entity.NewMoney(20_00, entity.DefaultCurrency), output.RemainingAmount)
+	assert.Equal(t, entity.NewMoney(20_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, recipient.ID).Balance)
+}
+
+func TestReverseTransactionUseCase_ExecuteMoreThanOriginal(t *testing.T) {
+	store, sender, recipient := memorytest.NewStore(t, 100_00, 0)
+	original := memorytest.Transfer(t, store, sender, recipient, 100_00)
+	reverse(t, store, original, 30_00)
+
+	uc := NewReverseTransactionUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), ReverseTransactionInputDTO{
+		TransactionID: original.ID,
+		Amount:        entity.NewMoney(70_01, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrReversalExceedsOriginal)
+	assert.Equal(t, entity.NewMoney(70_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, recipient.ID).Balance)
+	reversals, err := memory.NewTransactionGateway(store).FindReversals(original.ID)
+	assert.Nil(t, err)
+	assert.Len(t, reversals, 1)
+}
+
+func TestReverseTransactionUseCase_ExecuteFullyReversed(t *testing.T) {
+	store, sender, recipient := memorytest.NewStore(t, 100_00, 0)
+	original := memorytest.Transfer(t, store, sender, recipient, 100_00)
+	reverse(t, store, original, 100_00)
+
+	uc := NewReverseTransactionUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), ReverseTransactionInputDTO{TransactionID: original.ID})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrInvalidReversal)
+}
+
+func TestReverseTransactionUseCase_ExecuteWithInsufficientFunds(t *testing.T) {
+	store, sender, recipient := memorytest.NewStore(t, 100_00, 0)
+	original := memorytest.Transfer(t, store, sender, recipient, 100_00)
+	recipient = memorytest.FindAccount(t, store, recipient.ID)
+	assert.Nil(t, recipient.Debit(entity.NewMoney(80_00, entity.DefaultCurrency)))
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateBalance(recipient))
+
+	uc := NewReverseTransactionUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), ReverseTransactionInputDTO{TransactionID: original.ID})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrInsufficientFunds)
+}
+
+func TestReverseTransactionUseCase_ExecuteWithTransactionNotFound(t *testing.T) {
+	store, _, _ := memorytest.NewStore(t, 100_00, 0)
+
+	uc := NewReverseTransactionUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), ReverseTransactionInputDTO{TransactionID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrTransactionNotFound)
+}
@@ -0,0 +1,211 @@
+package runscheduledtransfers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	createtransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_transaction"
+)
+
+// TransactionCreator is implemented by createtransaction.CreateTransactionUseCase.
+type TransactionCreator interface {
+	Execute(ctx context.Context, input createtransaction.CreateTransactionInputDTO) (*createtransaction.CreateTransactionOutputDTO, error)
+}
+
+// RunScheduledTransfersInputDTO runs the transfers due at Now. A zero Now
+// means the current time.
+type RunScheduledTransfersInputDTO struct {
+	Now time.Time
+}
+
+type ScheduledTransferRunOutputDTO struct {
+	ScheduledTransferID string
+	TransactionID       string
+	Status              string
+	Error               string
+}
+
+type RunScheduledTransfersOutputDTO struct {
+	Runs []ScheduledTransferRunOutputDTO
+}
+
+const (
+	DefaultBatchSize    = 100
+	DefaultMaxFailures  = 3
+	DefaultRetryDelay   = 6 * time.Hour
+	DefaultClaimTimeout = 5 * time.Minute
+)
+
+// RunScheduledTransfersUseCase executes due scheduled transfers through
+// CreateTransaction and records the outcome of each. A transfer failing for
+// insufficient funds is retried after RetryDelay and paused after MaxFailures
+// consecutive failures; other business errors pause it right away.
+//
+// Each transfer is claimed for ClaimTimeout before it runs, so several
+// runners can share the scheduled transfers without executing one twice.
+// ClaimTimeout must exceed the time a transfer takes to run; a transfer left
+// claimed by a runner that stopped is retried once the claim expires.
+type RunScheduledTransfersUseCase struct {
+	Uow               uow.UnitOfWork
+	CreateTransaction TransactionCreator
+	BatchSize         int
+	MaxFailures       int
+	RetryDelay        time.Duration
+	ClaimTimeout      time.Duration
+}
+
+func NewRunScheduledTransfersUseCase(uow uow.UnitOfWork, createTransaction TransactionCreator) *RunScheduledTransfersUseCase {
+	return &RunScheduledTransfersUseCase{
+		Uow:               uow,
+		CreateTransaction: createTransaction,
+		BatchSize:         DefaultBatchSize,
+		MaxFailures:       DefaultMaxFailures,
+		RetryDelay:        DefaultRetryDelay,
+		ClaimTimeout:      DefaultClaimTimeout,
+	}
+}
+
+func (uc *RunScheduledTransfersUseCase) Execute(ctx context.Context, input RunScheduledTransfersInputDTO) (*RunScheduledTransfersOutputDTO, error) {
+	now := input.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	var due []*entity.ScheduledTransfer
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		repository, err := uow.GetRepository[gateway.ScheduledTransferGateway](ctx, u, gateway.ScheduledTransferRepository)
+		if err != nil {
+			return err
+		}
+		due, err = repository.FindDue(now, uc.BatchSize)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := &RunScheduledTransfersOutputDTO{Runs: []ScheduledTransferRunOutputDTO{}}
+	for _, found := range due {
+		transfer, err := uc.claim(ctx, found.ID, now)
+		if errors.Is(err, gateway.ErrConflict) {
+			// Another runner claimed or ran the transfer since it was found.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		transaction, err := uc.CreateTransaction.Execute(ctx, createtransaction.CreateTransactionInputDTO{
+			AccountIDFrom:  transfer.AccountIDFrom,
+			AccountIDTo:    transfer.AccountIDTo,
+			Amount:         transfer.Amount,
+			IdempotencyKey: transfer.RunKey(),
+		})
+		if errors.Is(err, gateway.ErrConflict) {
+			// Still contended after the use case's own retries; the transfer
+			// stays due and is picked up by the next run.
+			if err := uc.release(ctx, transfer); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil && !isBusinessError(err) {
+			return nil, err
+		}
+
+		var transactionID string
+		if transaction != nil {
+			transactionID = transaction.ID
+		}
+		run, err := uc.record(ctx, transfer, transactionID, err, now)
+		if err != nil {
+			return nil, err
+		}
+		output.Runs = append(output.Runs, ScheduledTransferRunOutputDTO{
+			ScheduledTransferID: transfer.ID,
+			TransactionID:       run.TransactionID,
+			Status:              string(run.Status),
+			Error:               run.Error,
+		})
+	}
+	return output, nil
+}
+
+// claim reserves a due transfer for this run and reads it again, as it may
+// have changed since it was found.
+func (uc *RunScheduledTransfersUseCase) claim(ctx context.Context, transferID string, now time.Time) (*entity.ScheduledTransfer, error) {
+	var transfer *entity.ScheduledTransfer
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		repository, err := uow.GetRepository[gateway.ScheduledTransferGateway](ctx, u, gateway.ScheduledTransferRepository)
+		if err != nil {
+			return err
+		}
+
+		err = repository.Claim(transferID, now, now.Add(uc.ClaimTimeout))
+		if err != nil {
+			return err
+		}
+		transfer, err = repository.FindByID(transferID)
+		return err
+	})
+	return transfer, err
+}
+
+// release gives up the claim on a transfer left unchanged.
+func (uc *RunScheduledTransfersUseCase) release(ctx context.Context, transfer *entity.ScheduledTransfer) error {
+	return uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		repository, err := uow.GetRepository[gateway.ScheduledTransferGateway](ctx, u, gateway.ScheduledTransferRepository)
+		if err != nil {
+			return err
+		}
+		return repository.Update(transfer)
+	})
+}
+
+func (uc *RunScheduledTransfersUseCase) record(ctx context.Context, transfer *entity.ScheduledTransfer, transactionID string, cause error, now time.Time) (*entity.ScheduledTransferRun, error) {
+	run := entity.NewScheduledTransferRun(transfer, transactionID, cause, now)
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		repository, err := uow.GetRepository[gateway.ScheduledTransferGateway](ctx, u, gateway.ScheduledTransferRepository)
+		if err != nil {
+			return err
+		}
+
+		if cause == nil {
+			err = transfer.RecordSuccess(now)
+			if err != nil {
+				return err
+			}
+		} else {
+			transfer.RecordFailure(cause, now, uc.MaxFailures, uc.RetryDelay)
+		}
+
+		err = repository.SaveRun(run)
+		if err != nil {
+			return err
+		}
+		return repository.Update(transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// isBusinessError reports whether err comes from the transfer itself rather
+// than from the infrastructure, so it is recorded against the transfer
+// instead of aborting the run.
+func isBusinessError(err error) bool {
+	var validationErr *entity.ValidationError
+	return errors.As(err, &validationErr) ||
+		errors.Is(err, entity.ErrInsufficientFunds) ||
+		errors.Is(err, entity.ErrInvalidAmount) ||
+		errors.Is(err, entity.ErrAccountNotFound) ||
+		errors.Is(err, entity.ErrAccountNotActive) ||
+		errors.Is(err, entity.ErrCurrencyMismatch) ||
+		errors.Is(err, entity.ErrIdempotencyKeyReused) ||
+		errors.Is(err, entity.ErrLimitExceeded)
+}
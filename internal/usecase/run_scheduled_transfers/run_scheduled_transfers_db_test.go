@@ -0,0 +1,102 @@
+package runscheduledtransfers
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	createtransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_transaction"
+	"github.com/stretchr/testify/suite"
+)
+
+type RunScheduledTransfersDBTestSuite struct {
+	suite.Suite
+	db                  *sql.DB
+	accountDB           *database.AccountDB
+	scheduledTransferDB *database.ScheduledTransferDB
+	accountFrom         *entity.Account
+	accountTo           *entity.Account
+	uc                  *RunScheduledTransfersUseCase
+}
+
+func (s *RunScheduledTransfersDBTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+
+	s.accountDB = database.NewAccountDB(db)
+	s.scheduledTransferDB = database.NewScheduledTransferDB(db)
+
+	clientFrom, _ := entity.NewClient("John Doe", "john@example.com")
+	clientTo, _ := entity.NewClient("Jane Doe", "jane@example.com")
+	for _, client := range []*entity.Client{clientFrom, clientTo} {
+		db.Exec("INSERT INTO clients (id, name, email, created_at) VALUES (?, ?, ?, ?)", client.ID, client.Name, client.Email, client.CreatedAt)
+	}
+	s.accountFrom = entity.NewAccount(clientFrom)
+	s.accountFrom.Credit(entity.NewMoney(150_00, entity.DefaultCurrency))
+	s.accountTo = entity.NewAccount(clientTo)
+	s.Nil(s.accountDB.Save(s.accountFrom))
+	s.Nil(s.accountDB.Save(s.accountTo))
+
+	u := uow.NewUow(db)
+	u.Register(gateway.AccountRepository, func(tx *sql.Tx) interface{} {
+		return database.NewAccountDB(tx)
+	})
+	u.Register(gateway.TransactionRepository, func(tx *sql.Tx) interface{} {
+		return database.NewTransactionDB(tx)
+	})
+	u.Register(gateway.LedgerRepository, func(tx *sql.Tx) interface{} {
+		return database.NewLedgerDB(tx)
+	})
+	u.Register(gateway.IdempotencyKeyRepository, func(tx *sql.Tx) interface{} {
+		return database.NewIdempotencyKeyDB(tx)
+	})
+	u.Register(gateway.ScheduledTransferRepository, func(tx *sql.Tx) interface{} {
+		return database.NewScheduledTransferDB(tx)
+	})
+	s.uc = NewRunScheduledTransfersUseCase(u, createtransaction.NewCreateTransactionUseCase(u))
+}
+
+func TestRunScheduledTransfersDBTestSuite(t *testing.T) {
+	suite.Run(t, new(RunScheduledTransfersDBTestSuite))
+}
+
+func (s *RunScheduledTransfersDBTestSuite) TestExecuteRecurringTransfer() {
+	start := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+	transfer, err := entity.NewScheduledTransfer(s.accountFrom.ID, s.accountTo.ID, entity.NewMoney(100_00, entity.DefaultCurrency), start, "0 9 5 * *")
+	s.Nil(err)
+	s.Nil(s.scheduledTransferDB.Save(transfer))
+
+	output, err := s.uc.Execute(context.Background(), RunScheduledTransfersInputDTO{Now: start})
+	s.Nil(err)
+	s.Len(output.Runs, 1)
+	s.Equal("succeeded", output.Runs[0].Status)
+
+	output, err = s.uc.Execute(context.Background(), RunScheduledTransfersInputDTO{Now: start.Add(time.Hour)})
+	s.Nil(err)
+	s.Empty(output.Runs)
+
+	nextMonth := time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC)
+	output, err = s.uc.Execute(context.Background(), RunScheduledTransfersInputDTO{Now: nextMonth})
+	s.Nil(err)
+	s.Len(output.Runs, 1)
+	s.Equal("failed", output.Runs[0].Status)
+
+	accountFrom, err := s.accountDB.FindByID(s.accountFrom.ID)
+	s.Nil(err)
+	s.Equal(entity.NewMoney(50_00, entity.DefaultCurrency), accountFrom.Balance)
+
+	retrievedTransfer, err := s.scheduledTransferDB.FindByID(transfer.ID)
+	s.Nil(err)
+	s.Equal(1, retrievedTransfer.ConsecutiveFailures)
+	s.True(nextMonth.Add(DefaultRetryDelay).Equal(retrievedTransfer.NextRunAt))
+
+	runs, err := s.scheduledTransferDB.FindRuns(transfer.ID)
+	s.Nil(err)
+	s.Len(runs, 2)
+}
@@ -0,0 +1,181 @@
+package runscheduledtransfers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	createtransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type TransactionCreatorMock struct {
+	mock.Mock
+}
+
+func (m *TransactionCreatorMock) Execute(ctx context.Context, input createtransaction.CreateTransactionInputDTO) (*createtransaction.CreateTransactionOutputDTO, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*createtransaction.CreateTransactionOutputDTO), args.Error(1)
+}
+
+// schedule saves a transfer of amount minor units from account a1 to
+// accountIDTo.
+func schedule(t *testing.T, store *memory.Store, accountIDTo string, amount int64, runAt time.Time, recurrence string) *entity.ScheduledTransfer {
+	transfer, err := entity.NewScheduledTransfer("a1", accountIDTo, entity.NewMoney(amount, entity.DefaultCurrency), runAt, recurrence)
+	assert.Nil(t, err)
+	assert.Nil(t, memory.NewScheduledTransferGateway(store).Save(transfer))
+	return transfer
+}
+
+func findTransfer(t *testing.T, store *memory.Store, id string) *entity.ScheduledTransfer {
+	transfer, err := memory.NewScheduledTransferGateway(store).FindByID(id)
+	assert.Nil(t, err)
+	return transfer
+}
+
+func findRuns(t *testing.T, store *memory.Store, transferID string) []*entity.ScheduledTransferRun {
+	runs, err := memory.NewScheduledTransferGateway(store).FindRuns(transferID)
+	assert.Nil(t, err)
+	return runs
+}
+
+func TestRunScheduledTransfersUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+	store := memory.NewStore()
+	oneOff := schedule(t, store, "a2", 10_00, now.Add(-time.Minute), "")
+	recurring := schedule(t, store, "a3", 20_00, now, "0 9 5 * *")
+	oneOffKey := oneOff.RunKey()
+
+	createTransaction := &TransactionCreatorMock{}
+	createTransaction.On("Execute", mock.Anything, mock.MatchedBy(func(input createtransaction.CreateTransactionInputDTO) bool {
+		return input.AccountIDTo == "a2"
+	})).Return(&createtransaction.CreateTransactionOutputDTO{ID: "t1"}, nil)
+	createTransaction.On("Execute", mock.Anything, mock.MatchedBy(func(input createtransaction.CreateTransactionInputDTO) bool {
+		return input.AccountIDTo == "a3"
+	})).Return(nil, entity.ErrInsufficientFunds)
+
+	uc := NewRunScheduledTransfersUseCase(memory.NewUow(store), createTransaction)
+
+	output, err := uc.Execute(context.Background(), RunScheduledTransfersInputDTO{Now: now})
+
+	assert.Nil(t, err)
+	assert.Len(t, output.Runs, 2)
+	assert.Equal(t, ScheduledTransferRunOutputDTO{ScheduledTransferID: oneOff.ID, TransactionID: "t1", Status: "succeeded"}, output.Runs[0])
+	assert.Equal(t, ScheduledTransferRunOutputDTO{ScheduledTransferID: recurring.ID, Status: "failed", Error: "insufficient funds"}, output.Runs[1])
+
+	input := createTransaction.Calls[0].Arguments.Get(1).(createtransaction.CreateTransactionInputDTO)
+	assert.Equal(t, oneOffKey, input.IdempotencyKey)
+
+	assert.Equal(t, entity.ScheduledTransferCompleted, findTransfer(t, store, oneOff.ID).Status)
+	recurring = findTransfer(t, store, recurring.ID)
+	assert.Equal(t, entity.ScheduledTransferActive, recurring.Status)
+	assert.Equal(t, 1, recurring.ConsecutiveFailures)
+	assert.Equal(t, now.Add(DefaultRetryDelay), recurring.NextRunAt)
+	assert.Len(t, findRuns(t, store, oneOff.ID), 1)
+	assert.Len(t, findRuns(t, store, recurring.ID), 1)
+}
+
+func TestRunScheduledTransfersUseCase_ExecutePausesAfterMaxFailures(t *testing.T) {
+	now := time.Now()
+	store := memory.NewStore()
+	transfer := schedule(t, store, "a2", 10_00, now, "")
+	transfer.ConsecutiveFailures = DefaultMaxFailures - 1
+	assert.Nil(t, memory.NewScheduledTransferGateway(store).Update(transfer))
+
+	createTransaction := &TransactionCreatorMock{}
+	createTransaction.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrInsufficientFunds)
+
+	uc := NewRunScheduledTransfersUseCase(memory.NewUow(store), createTransaction)
+
+	_, err := uc.Execute(context.Background(), RunScheduledTransfersInputDTO{Now: now})
+
+	assert.Nil(t, err)
+	assert.Equal(t, entity.ScheduledTransferPaused, findTransfer(t, store, transfer.ID).Status)
+}
+
+func TestRunScheduledTransfersUseCase_ExecuteStopsOnInfrastructureError(t *testing.T) {
+	now := time.Now()
+	store := memory.NewStore()
+	transfer := schedule(t, store, "a2", 10_00, now, "")
+
+	createTransaction := &TransactionCreatorMock{}
+	createTransaction.On("Execute", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	uc := NewRunScheduledTransfersUseCase(memory.NewUow(store), createTransaction)
+
+	output, err := uc.Execute(context.Background(), RunScheduledTransfersInputDTO{Now: now})
+
+	assert.Nil(t, output)
+	assert.EqualError(t, err, "connection refused")
+	assert.Equal(t, entity.ScheduledTransferActive, findTransfer(t, store, transfer.ID).Status)
+	assert.Empty(t, findRuns(t, store, transfer.ID))
+}
+
+func TestRunScheduledTransfersUseCase_ExecuteSkipsConflicts(t *testing.T) {
+	now := time.Now()
+	store := memory.NewStore()
+	transfer := schedule(t, store, "a2", 10_00, now, "")
+
+	createTransaction := &TransactionCreatorMock{}
+	createTransaction.On("Execute", mock.Anything, mock.Anything).Return(nil, &gateway.ConflictError{Entity: "account", ID: "a1"})
+
+	uc := NewRunScheduledTransfersUseCase(memory.NewUow(store), createTransaction)
+
+	output, err := uc.Execute(context.Background(), RunScheduledTransfersInputDTO{Now: now})
+
+	assert.Nil(t, err)
+	assert.Empty(t, output.Runs)
+	assert.Empty(t, findRuns(t, store, transfer.ID))
+}
+
+func TestRunScheduledTransfersUseCase_ExecuteSkipsClaimedTransfers(t *testing.T) {
+	now := time.Now()
+	store := memory.NewStore()
+	claimed := schedule(t, store, "a2", 10_00, now, "")
+	transfer := schedule(t, store, "a3", 20_00, now, "")
+	// Another runner holds claimed.
+	assert.Nil(t, memory.NewScheduledTransferGateway(store).Claim(claimed.ID, now, now.Add(time.Minute)))
+
+	createTransaction := &TransactionCreatorMock{}
+	createTransaction.On("Execute", mock.Anything, mock.Anything).Return(&createtransaction.CreateTransactionOutputDTO{ID: "t1"}, nil)
+
+	uc := NewRunScheduledTransfersUseCase(memory.NewUow(store), createTransaction)
+
+	output, err := uc.Execute(context.Background(), RunScheduledTransfersInputDTO{Now: now})
+
+	assert.Nil(t, err)
+	assert.Len(t, output.Runs, 1)
+	assert.Equal(t, transfer.ID, output.Runs[0].ScheduledTransferID)
+	createTransaction.AssertNumberOfCalls(t, "Execute", 1)
+	assert.Equal(t, entity.ScheduledTransferActive, findTransfer(t, store, claimed.ID).Status)
+	assert.Empty(t, findRuns(t, store, claimed.ID))
+}
+
+func TestRunScheduledTransfersUseCase_ExecuteReleasesConflictingTransfers(t *testing.T) {
+	now := time.Now()
+	store := memory.NewStore()
+	transfer := schedule(t, store, "a2", 10_00, now, "")
+
+	createTransaction := &TransactionCreatorMock{}
+	createTransaction.On("Execute", mock.Anything, mock.Anything).Return(nil, &gateway.ConflictError{Entity: "account", ID: "a1"}).Once()
+	createTransaction.On("Execute", mock.Anything, mock.Anything).Return(&createtransaction.CreateTransactionOutputDTO{ID: "t1"}, nil)
+
+	uc := NewRunScheduledTransfersUseCase(memory.NewUow(store), createTransaction)
+
+	output, err := uc.Execute(context.Background(), RunScheduledTransfersInputDTO{Now: now})
+	assert.Nil(t, err)
+	assert.Empty(t, output.Runs)
+
+	output, err = uc.Execute(context.Background(), RunScheduledTransfersInputDTO{Now: now})
+	assert.Nil(t, err)
+	assert.Len(t, output.Runs, 1)
+	assert.Equal(t, entity.ScheduledTransferCompleted, findTransfer(t, store, transfer.ID).Status)
+}
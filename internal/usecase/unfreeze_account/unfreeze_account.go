@@ -0,0 +1,77 @@
+package unfreezeaccount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+type UnfreezeAccountInputDTO struct {
+	AccountID string
+}
+
+type UnfreezeAccountOutputDTO struct {
+	ID     string
+	Status string
+}
+
+type UnfreezeAccountUseCase struct {
+	Uow         uow.UnitOfWork
+	MaxAttempts int
+}
+
+func NewUnfreezeAccountUseCase(uow uow.UnitOfWork) *UnfreezeAccountUseCase {
+	return &UnfreezeAccountUseCase{
+		Uow:         uow,
+		MaxAttempts: posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *UnfreezeAccountUseCase) Execute(ctx context.Context, input UnfreezeAccountInputDTO) (*UnfreezeAccountOutputDTO, error) {
+	return posting.Retry(uc.MaxAttempts, nil, func() (*UnfreezeAccountOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
+}
+
+func (uc *UnfreezeAccountUseCase) execute(ctx context.Context, input UnfreezeAccountInputDTO) (*UnfreezeAccountOutputDTO, []events.Event, error) {
+	output := &UnfreezeAccountOutputDTO{}
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		accountRepository, err := uow.GetRepository[gateway.AccountGateway](ctx, u, gateway.AccountRepository)
+		if err != nil {
+			return err
+		}
+
+		account, err := accountRepository.FindByID(input.AccountID)
+		if err != nil {
+			if errors.Is(err, gateway.ErrNotFound) {
+				return fmt.Errorf("%w: %s", entity.ErrAccountNotFound, input.AccountID)
+			}
+			return err
+		}
+
+		err = account.Unfreeze()
+		if err != nil {
+			return err
+		}
+
+		err = accountRepository.UpdateStatus(account)
+		if err != nil {
+			return err
+		}
+
+		output.ID = account.ID
+		output.Status = string(account.Status)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return output, nil, nil
+}
@@ -0,0 +1,96 @@
+package unfreezeaccount
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingAccounts struct {
+	gateway.AccountGateway
+}
+
+func (failingAccounts) UpdateStatus(account *entity.Account) error {
+	return errors.New("database error")
+}
+
+func TestUnfreezeAccountUseCase_Execute(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+	assert.Nil(t, account.Freeze())
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateStatus(account))
+
+	uc := NewUnfreezeAccountUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), UnfreezeAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, output.ID)
+	assert.Equal(t, "active", output.Status)
+	account = memorytest.FindAccount(t, store, account.ID)
+	assert.Equal(t, entity.AccountStatus("active"), account.Status)
+	assert.Equal(t, 2, account.Version)
+}
+
+func TestUnfreezeAccountUseCase_ExecuteWithInvalidTransition(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+
+	uc := NewUnfreezeAccountUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), UnfreezeAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrInvalidStatusTransition)
+	assert.Equal(t, 0, memorytest.FindAccount(t, store, account.ID).Version)
+}
+
+func TestUnfreezeAccountUseCase_ExecuteWithAccountNotFound(t *testing.T) {
+	uc := NewUnfreezeAccountUseCase(memory.NewUow(memory.NewStore()))
+
+	output, err := uc.Execute(context.Background(), UnfreezeAccountInputDTO{AccountID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrAccountNotFound)
+}
+
+func TestUnfreezeAccountUseCase_ExecuteWithGatewayError(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+	assert.Nil(t, account.Freeze())
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateStatus(account))
+
+	uc := NewUnfreezeAccountUseCase(memorytest.Wrap(memory.NewUow(store), func(name string, repository interface{}) interface{} {
+		if name == gateway.AccountRepository {
+			return failingAccounts{AccountGateway: repository.(gateway.AccountGateway)}
+		}
+		return repository
+	}))
+
+	output, err := uc.Execute(context.Background(), UnfreezeAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, output)
+	assert.Equal(t, "database error", err.Error())
+}
+
+func TestUnfreezeAccountUseCase_ExecuteRetriesOnConflict(t *testing.T) {
+	store := memory.NewStore()
+	account := memorytest.NewAccount(t, store, "John Doe", 0)
+	assert.Nil(t, account.Freeze())
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateStatus(account))
+	conflicts := 1
+
+	uc := NewUnfreezeAccountUseCase(memorytest.WithConflicts(memory.NewUow(store), &conflicts))
+
+	output, err := uc.Execute(context.Background(), UnfreezeAccountInputDTO{AccountID: account.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, account.ID, output.ID)
+	assert.Equal(t, 0, conflicts)
+}
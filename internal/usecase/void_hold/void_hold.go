@@ -0,0 +1,98 @@
+package voidhold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+type VoidHoldInputDTO struct {
+	HoldID string
+}
+
+type VoidHoldOutputDTO struct {
+	HoldID string
+	Status string
+}
+
+// VoidHoldUseCase releases a hold without moving money. The BalanceUpdated
+// event of the account is written to the outbox, when the unit of work has
+// one, and sent to Dispatcher, if set, once the void is committed.
+type VoidHoldUseCase struct {
+	Uow         uow.UnitOfWork
+	MaxAttempts int
+	Dispatcher  events.Dispatcher
+}
+
+func NewVoidHoldUseCase(uow uow.UnitOfWork) *VoidHoldUseCase {
+	return &VoidHoldUseCase{
+		Uow:         uow,
+		MaxAttempts: posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *VoidHoldUseCase) Execute(ctx context.Context, input VoidHoldInputDTO) (*VoidHoldOutputDTO, error) {
+	return posting.Retry(uc.MaxAttempts, uc.Dispatcher, func() (*VoidHoldOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
+}
+
+func (uc *VoidHoldUseCase) execute(ctx context.Context, input VoidHoldInputDTO) (*VoidHoldOutputDTO, []events.Event, error) {
+	output := &VoidHoldOutputDTO{}
+	var pending []events.Event
+	err := uc.Uow.Do(ctx, func(u uow.UnitOfWork) error {
+		poster, err := posting.NewPoster(ctx, u)
+		if err != nil {
+			return err
+		}
+		holdRepository, err := uow.GetRepository[gateway.HoldGateway](ctx, u, gateway.HoldRepository)
+		if err != nil {
+			return err
+		}
+
+		hold, err := holdRepository.FindByID(input.HoldID)
+		if err != nil {
+			if errors.Is(err, gateway.ErrNotFound) {
+				return fmt.Errorf("%w: %s", entity.ErrHoldNotFound, input.HoldID)
+			}
+			return err
+		}
+
+		account, err := poster.FindAccount(hold.AccountID)
+		if err != nil {
+			return err
+		}
+
+		err = hold.Void(account, time.Now())
+		if err != nil {
+			return err
+		}
+
+		err = poster.UpdateBalance(account)
+		if err != nil {
+			return err
+		}
+
+		err = holdRepository.Update(hold)
+		if err != nil {
+			return err
+		}
+
+		output.HoldID = hold.ID
+		output.Status = string(hold.Status)
+		pending = poster.Events
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return output, pending, nil
+}
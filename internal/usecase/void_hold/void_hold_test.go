@@ -0,0 +1,96 @@
+package voidhold
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type DispatcherMock struct {
+	mock.Mock
+}
+
+func (m *DispatcherMock) Dispatch(events ...events.Event) {
+	m.Called(events)
+}
+
+func TestVoidHoldUseCase_Execute(t *testing.T) {
+	store, account, _ := memorytest.NewStore(t, 100_00, 0)
+	hold := memorytest.PlaceHold(t, store, account.ID, 60_00, time.Now().Add(time.Hour))
+
+	uc := NewVoidHoldUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), VoidHoldInputDTO{HoldID: hold.ID})
+
+	assert.Nil(t, err)
+	assert.Equal(t, hold.ID, output.HoldID)
+	assert.Equal(t, "voided", output.Status)
+	account = memorytest.FindAccount(t, store, account.ID)
+	assert.True(t, account.HeldAmount.IsZero())
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), account.Balance)
+	voided, err := memory.NewHoldGateway(store).FindByID(hold.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.HoldVoided, voided.Status)
+}
+
+func TestVoidHoldUseCase_ExecuteWithCapturedHold(t *testing.T) {
+	store, account, _ := memorytest.NewStore(t, 100_00, 0)
+	hold := memorytest.PlaceHold(t, store, account.ID, 60_00, time.Now().Add(time.Hour))
+	account = memorytest.FindAccount(t, store, account.ID)
+	merchant := memorytest.NewAccount(t, store, "Shop", 0)
+	_, err := hold.Capture(account, merchant, entity.NewMoney(60_00, entity.DefaultCurrency), time.Now())
+	assert.Nil(t, err)
+	assert.Nil(t, memory.NewAccountGateway(store).UpdateBalance(account))
+	assert.Nil(t, memory.NewHoldGateway(store).Update(hold))
+
+	uc := NewVoidHoldUseCase(memory.NewUow(store))
+
+	output, err := uc.Execute(context.Background(), VoidHoldInputDTO{HoldID: hold.ID})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrHoldNotActive)
+	assert.Equal(t, 2, memorytest.FindAccount(t, store, account.ID).Version)
+}
+
+func TestVoidHoldUseCase_ExecuteWithHoldNotFound(t *testing.T) {
+	uc := NewVoidHoldUseCase(memory.NewUow(memory.NewStore()))
+
+	output, err := uc.Execute(context.Background(), VoidHoldInputDTO{HoldID: "unknown"})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrHoldNotFound)
+}
+
+func TestVoidHoldUseCase_ExecuteEmitsBalanceUpdated(t *testing.T) {
+	store, account, _ := memorytest.NewStore(t, 100_00, 0)
+	hold := memorytest.PlaceHold(t, store, account.ID, 60_00, time.Now().Add(time.Hour))
+	dispatcher := &DispatcherMock{}
+	dispatcher.On("Dispatch", mock.Anything)
+
+	uc := NewVoidHoldUseCase(memory.NewUow(store))
+	uc.Dispatcher = dispatcher
+
+	_, err := uc.Execute(context.Background(), VoidHoldInputDTO{HoldID: hold.ID})
+
+	assert.Nil(t, err)
+	dispatcher.AssertNumberOfCalls(t, "Dispatch", 1)
+	dispatched := dispatcher.Calls[0].Arguments.Get(0).([]events.Event)
+	assert.Len(t, dispatched, 1)
+	updated := dispatched[0].(events.BalanceUpdated)
+	assert.Equal(t, account.ID, updated.AccountID)
+	assert.Equal(t, entity.Zero(entity.DefaultCurrency).Decimal(), updated.HeldAmount)
+	assert.Equal(t, 2, updated.Version)
+
+	messages, err := memory.NewOutboxGateway(store).FindUnsent(-1)
+	assert.Nil(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, events.BalanceUpdatedName, messages[0].EventName)
+	assert.Equal(t, account.ID, messages[0].Key)
+}
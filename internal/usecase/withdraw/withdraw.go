@@ -0,0 +1,62 @@
+package withdraw
+
+import (
+	"context"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/events"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+)
+
+type WithdrawInputDTO struct {
+	AccountID string
+	Amount    entity.Money
+}
+
+type WithdrawOutputDTO struct {
+	ID        string
+	AccountID string
+	Amount    entity.Money
+	Balance   entity.Money
+}
+
+// WithdrawUseCase pays money out of the wallet from a client account,
+// crediting it to the treasury account. Events are sent to Dispatcher, if
+// set, once the withdrawal is committed.
+type WithdrawUseCase struct {
+	Uow               uow.UnitOfWork
+	TreasuryAccountID string
+	MaxAttempts       int
+	Dispatcher        events.Dispatcher
+}
+
+func NewWithdrawUseCase(uow uow.UnitOfWork, treasuryAccountID string) *WithdrawUseCase {
+	return &WithdrawUseCase{
+		Uow:               uow,
+		TreasuryAccountID: treasuryAccountID,
+		MaxAttempts:       posting.DefaultMaxAttempts,
+	}
+}
+
+func (uc *WithdrawUseCase) Execute(ctx context.Context, input WithdrawInputDTO) (*WithdrawOutputDTO, error) {
+	return posting.Retry(uc.MaxAttempts, uc.Dispatcher, func() (*WithdrawOutputDTO, []events.Event, error) {
+		return uc.execute(ctx, input)
+	})
+}
+
+func (uc *WithdrawUseCase) execute(ctx context.Context, input WithdrawInputDTO) (*WithdrawOutputDTO, []events.Event, error) {
+	withdrawal, account, pending, err := posting.PostTreasury(ctx, uc.Uow, uc.TreasuryAccountID, input.AccountID, func(treasury, account *entity.Account) (*entity.Transaction, error) {
+		return entity.NewWithdrawal(account, treasury, input.Amount)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &WithdrawOutputDTO{
+		ID:        withdrawal.ID,
+		AccountID: account.ID,
+		Amount:    withdrawal.Amount,
+		Balance:   account.Balance,
+	}, pending, nil
+}
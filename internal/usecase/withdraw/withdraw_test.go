@@ -0,0 +1,88 @@
+package withdraw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway/memory/memorytest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/posting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithdrawUseCase_Execute(t *testing.T) {
+	store, treasury, account := memorytest.NewStore(t, 0, 100_00)
+
+	uc := NewWithdrawUseCase(memory.NewUow(store), treasury.ID)
+
+	output, err := uc.Execute(context.Background(), WithdrawInputDTO{
+		AccountID: account.ID,
+		Amount:    entity.NewMoney(30_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, output.ID)
+	assert.Equal(t, entity.NewMoney(70_00, entity.DefaultCurrency), output.Balance)
+	assert.Equal(t, entity.NewMoney(70_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, account.ID).Balance)
+	assert.Equal(t, entity.NewMoney(30_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, treasury.ID).Balance)
+
+	transaction, err := memory.NewTransactionGateway(store).FindByID(output.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.TransactionWithdrawal, transaction.Kind)
+}
+
+func TestWithdrawUseCase_ExecuteWithInsufficientFunds(t *testing.T) {
+	store, treasury, account := memorytest.NewStore(t, 0, 100_00)
+
+	uc := NewWithdrawUseCase(memory.NewUow(store), treasury.ID)
+
+	output, err := uc.Execute(context.Background(), WithdrawInputDTO{
+		AccountID: account.ID,
+		Amount:    entity.NewMoney(100_01, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, output)
+	assert.ErrorIs(t, err, entity.ErrInsufficientFunds)
+	assert.Equal(t, entity.NewMoney(100_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, account.ID).Balance)
+	transactions, err := memory.NewTransactionGateway(store).FindByAccountID(account.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, transactions)
+}
+
+func TestWithdrawUseCase_ExecuteWithTreasuryNotFound(t *testing.T) {
+	store, _, account := memorytest.NewStore(t, 0, 100_00)
+
+	for _, treasuryAccountID := range []string{"treasury", ""} {
+		uc := NewWithdrawUseCase(memory.NewUow(store), treasuryAccountID)
+
+		output, err := uc.Execute(context.Background(), WithdrawInputDTO{
+			AccountID: account.ID,
+			Amount:    entity.NewMoney(30_00, entity.DefaultCurrency),
+		})
+
+		assert.Nil(t, output)
+		assert.ErrorIs(t, err, posting.ErrTreasuryAccountNotConfigured)
+		assert.NotErrorIs(t, err, entity.ErrAccountNotFound)
+	}
+}
+
+func TestWithdrawUseCase_ExecuteRetriesOnConflict(t *testing.T) {
+	store, treasury, account := memorytest.NewStore(t, 0, 100_00)
+	conflicts := 1
+
+	uc := NewWithdrawUseCase(memorytest.WithConflicts(memory.NewUow(store), &conflicts), treasury.ID)
+
+	output, err := uc.Execute(context.Background(), WithdrawInputDTO{
+		AccountID: account.ID,
+		Amount:    entity.NewMoney(30_00, entity.DefaultCurrency),
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, output)
+	assert.Equal(t, 0, conflicts)
+	assert.Equal(t, entity.NewMoney(70_00, entity.DefaultCurrency), memorytest.FindAccount(t, store, account.ID).Balance)
+	transactions, err := memory.NewTransactionGateway(store).FindByAccountID(account.ID)
+	assert.Nil(t, err)
+	assert.Len(t, transactions, 1)
+}
@@ -0,0 +1,198 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	createaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_account"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/usecase/deposit"
+	getaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_account"
+	getbalance "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_balance"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/usecase/withdraw"
+)
+
+type createAccountRequest struct {
+	ClientID string `json:"client_id"`
+}
+
+type createAccountResponse struct {
+	ID string `json:"id"`
+}
+
+// accountResponse carries amounts as decimal strings in Currency.
+type accountResponse struct {
+	ID             string    `json:"id"`
+	ClientID       string    `json:"client_id"`
+	Balance        string    `json:"balance"`
+	HeldAmount     string    `json:"held_amount"`
+	OverdraftLimit string    `json:"overdraft_limit"`
+	Currency       string    `json:"currency"`
+	Status         string    `json:"status"`
+	Version        int       `json:"version"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// balanceResponse is the balance read from the balance projection, which can
+// lag behind the account.
+type balanceResponse struct {
+	AccountID         string    `json:"account_id"`
+	Balance           string    `json:"balance"`
+	HeldAmount        string    `json:"held_amount"`
+	Currency          string    `json:"currency"`
+	Version           int       `json:"version"`
+	LastTransactionID string    `json:"last_transaction_id,omitempty"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// cashRequest is the body of a deposit or a withdrawal. It accepts the amount
+// as a JSON number or a decimal string. Currency defaults to
+// entity.DefaultCurrency.
+type cashRequest struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+// cashResponse reports a deposit or a withdrawal along with the balance of
+// the account after it.
+type cashResponse struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id"`
+	Amount    string `json:"amount"`
+	Balance   string `json:"balance"`
+	Currency  string `json:"currency"`
+}
+
+type WebAccountHandler struct {
+	CreateAccountUseCase *createaccount.CreateAccountUseCase
+	GetAccountUseCase    *getaccount.GetAccountUseCase
+	GetBalanceUseCase    *getbalance.GetBalanceUseCase
+	DepositUseCase       *deposit.DepositUseCase
+	WithdrawUseCase      *withdraw.WithdrawUseCase
+}
+
+func NewWebAccountHandler(createAccountUseCase *createaccount.CreateAccountUseCase, getAccountUseCase *getaccount.GetAccountUseCase, getBalanceUseCase *getbalance.GetBalanceUseCase, depositUseCase *deposit.DepositUseCase, withdrawUseCase *withdraw.WithdrawUseCase) *WebAccountHandler {
+	return &WebAccountHandler{
+		CreateAccountUseCase: createAccountUseCase,
+		GetAccountUseCase:    getAccountUseCase,
+		GetBalanceUseCase:    getBalanceUseCase,
+		DepositUseCase:       depositUseCase,
+		WithdrawUseCase:      withdrawUseCase,
+	}
+}
+
+func (h *WebAccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	var request createAccountRequest
+	if err := decodeJSON(r, &request); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	output, err := h.CreateAccountUseCase.Execute(r.Context(), createaccount.CreateAccountInputDTO{ClientID: request.ClientID})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, createAccountResponse{ID: output.ID})
+}
+
+func (h *WebAccountHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	output, err := h.GetAccountUseCase.Execute(getaccount.GetAccountInputDTO{ID: r.PathValue("id")})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, accountResponse{
+		ID:             output.ID,
+		ClientID:       output.ClientID,
+		Balance:        output.Balance.Decimal(),
+		HeldAmount:     output.HeldAmount.Decimal(),
+		OverdraftLimit: output.OverdraftLimit.Decimal(),
+		Currency:       output.Balance.Currency(),
+		Status:         output.Status,
+		Version:        output.Version,
+		CreatedAt:      output.CreatedAt,
+	})
+}
+
+func (h *WebAccountHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	output, err := h.GetBalanceUseCase.Execute(getbalance.GetBalanceInputDTO{AccountID: r.PathValue("id")})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, balanceResponse{
+		AccountID:         output.AccountID,
+		Balance:           output.Balance.Decimal(),
+		HeldAmount:        output.HeldAmount.Decimal(),
+		Currency:          output.Balance.Currency(),
+		Version:           output.Version,
+		LastTransactionID: output.LastTransactionID,
+		UpdatedAt:         output.UpdatedAt,
+	})
+}
+
+func (h *WebAccountHandler) Deposit(w http.ResponseWriter, r *http.Request) {
+	amount, err := decodeCash(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	output, err := h.DepositUseCase.Execute(r.Context(), deposit.DepositInputDTO{
+		AccountID: r.PathValue("id"),
+		Amount:    amount,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, cashResponse{
+		ID:        output.ID,
+		AccountID: output.AccountID,
+		Amount:    output.Amount.Decimal(),
+		Balance:   output.Balance.Decimal(),
+		Currency:  output.Amount.Currency(),
+	})
+}
+
+func (h *WebAccountHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	amount, err := decodeCash(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	output, err := h.WithdrawUseCase.Execute(r.Context(), withdraw.WithdrawInputDTO{
+		AccountID: r.PathValue("id"),
+		Amount:    amount,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, cashResponse{
+		ID:        output.ID,
+		AccountID: output.AccountID,
+		Amount:    output.Amount.Decimal(),
+		Balance:   output.Balance.Decimal(),
+		Currency:  output.Amount.Currency(),
+	})
+}
+
+func decodeCash(r *http.Request) (entity.Money, error) {
+	var request cashRequest
+	if err := decodeJSON(r, &request); err != nil {
+		return entity.Money{}, err
+	}
+	if request.Currency == "" {
+		request.Currency = entity.DefaultCurrency
+	}
+	return entity.ParseMoney(request.Amount.String(), request.Currency)
+}
@@ -0,0 +1,71 @@
+package web
+
+import (
+	"net/http"
+
+	createclient "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_client"
+	getclient "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_client"
+)
+
+type createClientRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type clientResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Tier  string `json:"tier,omitempty"`
+}
+
+type WebClientHandler struct {
+	CreateClientUseCase *createclient.CreateClientUseCase
+	GetClientUseCase    *getclient.GetClientUseCase
+}
+
+func NewWebClientHandler(createClientUseCase *createclient.CreateClientUseCase, getClientUseCase *getclient.GetClientUseCase) *WebClientHandler {
+	return &WebClientHandler{
+		CreateClientUseCase: createClientUseCase,
+		GetClientUseCase:    getClientUseCase,
+	}
+}
+
+func (h *WebClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
+	var request createClientRequest
+	if err := decodeJSON(r, &request); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	output, err := h.CreateClientUseCase.Execute(r.Context(), createclient.CreateClientInputDTO{
+		Name:  request.Name,
+		Email: request.Email,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, clientResponse{
+		ID:    output.ID,
+		Name:  output.Name,
+		Email: output.Email,
+		Tier:  output.Tier,
+	})
+}
+
+func (h *WebClientHandler) GetClient(w http.ResponseWriter, r *http.Request) {
+	output, err := h.GetClientUseCase.Execute(getclient.GetClientInputDTO{ID: r.PathValue("id")})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, clientResponse{
+		ID:    output.ID,
+		Name:  output.Name,
+		Email: output.Email,
+		Tier:  output.Tier,
+	})
+}
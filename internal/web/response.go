@@ -0,0 +1,74 @@
+// Package web exposes the wallet use cases over HTTP with JSON bodies.
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+)
+
+var errInvalidBody = errors.New("invalid request body")
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func decodeJSON(r *http.Request, v any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%w: %s", errInvalidBody, err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("writing response: %v", err)
+	}
+}
+
+// writeError responds with the status matching err. Unexpected errors are
+// logged and reported without their details.
+func writeError(w http.ResponseWriter, err error) {
+	status := statusFor(err)
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		log.Printf("internal error: %v", err)
+		message = http.StatusText(status)
+	}
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+func statusFor(err error) int {
+	var validationErr *entity.ValidationError
+	switch {
+	case errors.Is(err, errInvalidBody),
+		errors.As(err, &validationErr),
+		errors.Is(err, entity.ErrInvalidMoney),
+		errors.Is(err, entity.ErrInvalidAmount):
+		return http.StatusBadRequest
+	case errors.Is(err, entity.ErrClientNotFound),
+		errors.Is(err, entity.ErrAccountNotFound),
+		errors.Is(err, entity.ErrTransactionNotFound),
+		errors.Is(err, gateway.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrIdempotencyKeyReused),
+		errors.Is(err, gateway.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrInsufficientFunds),
+		errors.Is(err, entity.ErrAccountNotActive),
+		errors.Is(err, entity.ErrCurrencyMismatch),
+		errors.Is(err, entity.ErrMoneyOverflow),
+		errors.Is(err, entity.ErrLimitExceeded):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
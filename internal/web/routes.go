@@ -0,0 +1,17 @@
+package web
+
+import "github.com/AntonioSabino/fc-ms-wallet/internal/web/webserver"
+
+// RegisterRoutes mounts the client, account and transaction endpoints on
+// server.
+func RegisterRoutes(server *webserver.WebServer, clients *WebClientHandler, accounts *WebAccountHandler, transactions *WebTransactionHandler) {
+	server.AddHandler("POST /clients", clients.CreateClient)
+	server.AddHandler("GET /clients/{id}", clients.GetClient)
+	server.AddHandler("POST /accounts", accounts.CreateAccount)
+	server.AddHandler("GET /accounts/{id}", accounts.GetAccount)
+	server.AddHandler("GET /accounts/{id}/balance", accounts.GetBalance)
+	server.AddHandler("POST /accounts/{id}/deposits", accounts.Deposit)
+	server.AddHandler("POST /accounts/{id}/withdrawals", accounts.Withdraw)
+	server.AddHandler("POST /transactions", transactions.CreateTransaction)
+	server.AddHandler("GET /transactions/{id}", transactions.GetTransaction)
+}
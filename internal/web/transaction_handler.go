@@ -0,0 +1,112 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	createtransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_transaction"
+	gettransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_transaction"
+)
+
+// HeaderIdempotencyKey makes a POST /transactions request safe to retry.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// createTransactionRequest accepts the amount as a JSON number or a decimal
+// string. Currency defaults to entity.DefaultCurrency.
+type createTransactionRequest struct {
+	AccountIDFrom string      `json:"account_id_from"`
+	AccountIDTo   string      `json:"account_id_to"`
+	Amount        json.Number `json:"amount"`
+	Currency      string      `json:"currency"`
+}
+
+type createTransactionResponse struct {
+	ID               string `json:"id"`
+	Amount           string `json:"amount"`
+	Fee              string `json:"fee"`
+	Total            string `json:"total"`
+	Currency         string `json:"currency"`
+	FeeTransactionID string `json:"fee_transaction_id,omitempty"`
+}
+
+type transactionResponse struct {
+	ID            string    `json:"id"`
+	Kind          string    `json:"kind"`
+	AccountIDFrom string    `json:"account_id_from"`
+	AccountIDTo   string    `json:"account_id_to"`
+	Amount        string    `json:"amount"`
+	Currency      string    `json:"currency"`
+	ReversalOf    string    `json:"reversal_of,omitempty"`
+	FeeOf         string    `json:"fee_of,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type WebTransactionHandler struct {
+	CreateTransactionUseCase *createtransaction.CreateTransactionUseCase
+	GetTransactionUseCase    *gettransaction.GetTransactionUseCase
+}
+
+func NewWebTransactionHandler(createTransactionUseCase *createtransaction.CreateTransactionUseCase, getTransactionUseCase *gettransaction.GetTransactionUseCase) *WebTransactionHandler {
+	return &WebTransactionHandler{
+		CreateTransactionUseCase: createTransactionUseCase,
+		GetTransactionUseCase:    getTransactionUseCase,
+	}
+}
+
+func (h *WebTransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
+	var request createTransactionRequest
+	if err := decodeJSON(r, &request); err != nil {
+		writeError(w, err)
+		return
+	}
+	if request.Currency == "" {
+		request.Currency = entity.DefaultCurrency
+	}
+	amount, err := entity.ParseMoney(request.Amount.String(), request.Currency)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	output, err := h.CreateTransactionUseCase.Execute(r.Context(), createtransaction.CreateTransactionInputDTO{
+		AccountIDFrom:  request.AccountIDFrom,
+		AccountIDTo:    request.AccountIDTo,
+		Amount:         amount,
+		IdempotencyKey: r.Header.Get(HeaderIdempotencyKey),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, createTransactionResponse{
+		ID:               output.ID,
+		Amount:           output.Amount.Decimal(),
+		Fee:              output.Fee.Decimal(),
+		Total:            output.Total.Decimal(),
+		Currency:         output.Amount.Currency(),
+		FeeTransactionID: output.FeeTransactionID,
+	})
+}
+
+func (h *WebTransactionHandler) GetTransaction(w http.ResponseWriter, r *http.Request) {
+	output, err := h.GetTransactionUseCase.Execute(gettransaction.GetTransactionInputDTO{ID: r.PathValue("id")})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, transactionResponse{
+		ID:            output.ID,
+		Kind:          output.Kind,
+		AccountIDFrom: output.AccountIDFrom,
+		AccountIDTo:   output.AccountIDTo,
+		Amount:        output.Amount.Decimal(),
+		Currency:      output.Amount.Currency(),
+		ReversalOf:    output.ReversalOf,
+		FeeOf:         output.FeeOf,
+		CreatedAt:     output.CreatedAt,
+	})
+}
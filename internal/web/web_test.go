@@ -0,0 +1,256 @@
+package web
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/database/databasetest"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/entity"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/gateway"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/pkg/uow"
+	createaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_account"
+	createclient "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_client"
+	createtransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/create_transaction"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/usecase/deposit"
+	getaccount "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_account"
+	getbalance "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_balance"
+	getclient "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_client"
+	gettransaction "github.com/AntonioSabino/fc-ms-wallet/internal/usecase/get_transaction"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/usecase/withdraw"
+	"github.com/AntonioSabino/fc-ms-wallet/internal/web/webserver"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebTestSuite struct {
+	suite.Suite
+	db       *sql.DB
+	balances *database.BalanceDB
+	deposit  *deposit.DepositUseCase
+	withdraw *withdraw.WithdrawUseCase
+	server   *webserver.WebServer
+}
+
+func (s *WebTestSuite) SetupTest() {
+	db := databasetest.NewDB(s.T())
+	s.db = db
+
+	clientDB := database.NewClientDB(db)
+	accountDB := database.NewAccountDB(db)
+	s.balances = database.NewBalanceDB(db)
+	u := uow.NewUow(db)
+	u.Register(gateway.ClientRepository, func(tx *sql.Tx) interface{} {
+		return database.NewClientDB(tx)
+	})
+	u.Register(gateway.AccountRepository, func(tx *sql.Tx) interface{} {
+		return database.NewAccountDB(tx)
+	})
+	u.Register(gateway.TransactionRepository, func(tx *sql.Tx) interface{} {
+		return database.NewTransactionDB(tx)
+	})
+	u.Register(gateway.LedgerRepository, func(tx *sql.Tx) interface{} {
+		return database.NewLedgerDB(tx)
+	})
+	u.Register(gateway.IdempotencyKeyRepository, func(tx *sql.Tx) interface{} {
+		return database.NewIdempotencyKeyDB(tx)
+	})
+
+	// Tests set the treasury account once they have created it.
+	s.deposit = deposit.NewDepositUseCase(u, "")
+	s.withdraw = withdraw.NewWithdrawUseCase(u, "")
+
+	s.server = webserver.NewWebServer(":0")
+	RegisterRoutes(s.server,
+		NewWebClientHandler(createclient.NewCreateClientUseCase(u), getclient.NewGetClientUseCase(clientDB)),
+		NewWebAccountHandler(createaccount.NewCreateAccountUseCase(u), getaccount.NewGetAccountUseCase(accountDB), getbalance.NewGetBalanceUseCase(s.balances), s.deposit, s.withdraw),
+		NewWebTransactionHandler(createtransaction.NewCreateTransactionUseCase(u), gettransaction.NewGetTransactionUseCase(database.NewTransactionDB(db))),
+	)
+}
+
+func TestWebTestSuite(t *testing.T) {
+	suite.Run(t, new(WebTestSuite))
+}
+
+func (s *WebTestSuite) do(method, path, body string, headers ...string) (*httptest.ResponseRecorder, map[string]any) {
+	request := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	for i := 0; i+1 < len(headers); i += 2 {
+		request.Header.Set(headers[i], headers[i+1])
+	}
+	recorder := httptest.NewRecorder()
+	s.server.Router.ServeHTTP(recorder, request)
+
+	var decoded map[string]any
+	if recorder.Body.Len() > 0 {
+		s.Nil(json.Unmarshal(recorder.Body.Bytes(), &decoded))
+	}
+	return recorder, decoded
+}
+
+func (s *WebTestSuite) createAccount(balance string) string {
+	response, client := s.do(http.MethodPost, "/clients", `{"name":"John Doe","email":"john@example.com"}`)
+	s.Equal(http.StatusCreated, response.Code)
+	response, account := s.do(http.MethodPost, "/accounts", fmt.Sprintf(`{"client_id":%q}`, client["id"]))
+	s.Equal(http.StatusCreated, response.Code)
+	id := account["id"].(string)
+	_, err := s.db.Exec("UPDATE accounts SET balance = ? WHERE id = ?", balance, id)
+	s.Nil(err)
+	return id
+}
+
+func (s *WebTestSuite) TestCreateAndGetClient() {
+	response, body := s.do(http.MethodPost, "/clients", `{"name":"John Doe","email":"john@example.com"}`)
+	s.Equal(http.StatusCreated, response.Code)
+	s.Equal("application/json", response.Header().Get("Content-Type"))
+	s.NotEmpty(body["id"])
+
+	response, body = s.do(http.MethodGet, "/clients/"+body["id"].(string), "")
+	s.Equal(http.StatusOK, response.Code)
+	s.Equal("John Doe", body["name"])
+	s.Equal("standard", body["tier"])
+}
+
+func (s *WebTestSuite) TestCreateClientWithInvalidInput() {
+	response, body := s.do(http.MethodPost, "/clients", `{"name":"","email":"john@example.com"}`)
+	s.Equal(http.StatusBadRequest, response.Code)
+	s.Equal("name is required", body["error"])
+
+	response, _ = s.do(http.MethodPost, "/clients", `{"name":`)
+	s.Equal(http.StatusBadRequest, response.Code)
+
+	response, _ = s.do(http.MethodPost, "/clients", `{"nickname":"John"}`)
+	s.Equal(http.StatusBadRequest, response.Code)
+}
+
+func (s *WebTestSuite) TestNotFound() {
+	response, _ := s.do(http.MethodPost, "/accounts", `{"client_id":"unknown"}`)
+	s.Equal(http.StatusNotFound, response.Code)
+
+	for _, path := range []string{"/clients/unknown", "/accounts/unknown", "/transactions/unknown"} {
+		response, body := s.do(http.MethodGet, path, "")
+		s.Equal(http.StatusNotFound, response.Code, path)
+		s.NotEmpty(body["error"])
+	}
+}
+
+func (s *WebTestSuite) TestGetBalance() {
+	updatedAt := time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)
+	s.Nil(s.balances.ApplyBalance(&entity.AccountBalance{
+		AccountID:  "account-1",
+		Balance:    entity.NewMoney(89_50, entity.DefaultCurrency),
+		HeldAmount: entity.NewMoney(10_00, entity.DefaultCurrency),
+		Version:    2,
+		UpdatedAt:  updatedAt,
+	}))
+	s.Nil(s.balances.ApplyTransaction("account-1", entity.DefaultCurrency, "transaction-1", updatedAt))
+
+	response, body := s.do(http.MethodGet, "/accounts/account-1/balance", "")
+	s.Equal(http.StatusOK, response.Code)
+	s.Equal("account-1", body["account_id"])
+	s.Equal("89.50", body["balance"])
+	s.Equal("10.00", body["held_amount"])
+	s.Equal("BRL", body["currency"])
+	s.Equal(float64(2), body["version"])
+	s.Equal("transaction-1", body["last_transaction_id"])
+	s.Equal("2024-01-05T09:00:00Z", body["updated_at"])
+
+	// Only a transaction of account-2 was projected, not its balance.
+	s.Nil(s.balances.ApplyTransaction("account-2", entity.DefaultCurrency, "transaction-1", updatedAt))
+	for _, path := range []string{"/accounts/account-2/balance", "/accounts/unknown/balance"} {
+		response, _ = s.do(http.MethodGet, path, "")
+		s.Equal(http.StatusNotFound, response.Code, path)
+	}
+}
+
+func (s *WebTestSuite) TestDepositAndWithdraw() {
+	treasury := s.createAccount("0")
+	s.deposit.TreasuryAccountID = treasury
+	s.withdraw.TreasuryAccountID = treasury
+	account := s.createAccount("0")
+
+	response, body := s.do(http.MethodPost, "/accounts/"+account+"/deposits", `{"amount":"25.00"}`)
+	s.Equal(http.StatusCreated, response.Code)
+	s.NotEmpty(body["id"])
+	s.Equal(account, body["account_id"])
+	s.Equal("25.00", body["amount"])
+	s.Equal("25.00", body["balance"])
+	s.Equal("BRL", body["currency"])
+
+	response, body = s.do(http.MethodPost, "/accounts/"+account+"/withdrawals", `{"amount":10}`)
+	s.Equal(http.StatusCreated, response.Code)
+	s.Equal("10.00", body["amount"])
+	s.Equal("15.00", body["balance"])
+
+	response, transaction := s.do(http.MethodGet, "/transactions/"+body["id"].(string), "")
+	s.Equal(http.StatusOK, response.Code)
+	s.Equal("withdrawal", transaction["kind"])
+	s.Equal(treasury, transaction["account_id_to"])
+
+	response, _ = s.do(http.MethodPost, "/accounts/"+account+"/withdrawals", `{"amount":20}`)
+	s.Equal(http.StatusUnprocessableEntity, response.Code)
+	response, _ = s.do(http.MethodPost, "/accounts/"+account+"/deposits", `{"amount":"0"}`)
+	s.Equal(http.StatusBadRequest, response.Code)
+	response, _ = s.do(http.MethodPost, "/accounts/unknown/deposits", `{"amount":1}`)
+	s.Equal(http.StatusNotFound, response.Code)
+
+	s.deposit.TreasuryAccountID = ""
+	response, _ = s.do(http.MethodPost, "/accounts/"+account+"/deposits", `{"amount":1}`)
+	s.Equal(http.StatusInternalServerError, response.Code)
+}
+
+func (s *WebTestSuite) TestCreateAndGetTransaction() {
+	from := s.createAccount("100")
+	to := s.createAccount("0")
+
+	response, body := s.do(http.MethodPost, "/transactions", fmt.Sprintf(`{"account_id_from":%q,"account_id_to":%q,"amount":"10.50"}`, from, to))
+	s.Equal(http.StatusCreated, response.Code)
+	s.Equal("10.50", body["amount"])
+	s.Equal("0.00", body["fee"])
+	s.Equal("10.50", body["total"])
+	s.Equal("BRL", body["currency"])
+
+	response, transaction := s.do(http.MethodGet, "/transactions/"+body["id"].(string), "")
+	s.Equal(http.StatusOK, response.Code)
+	s.Equal(from, transaction["account_id_from"])
+	s.Equal(to, transaction["account_id_to"])
+	s.Equal("transfer", transaction["kind"])
+
+	response, account := s.do(http.MethodGet, "/accounts/"+from, "")
+	s.Equal(http.StatusOK, response.Code)
+	s.Equal("89.50", account["balance"])
+	s.Equal("active", account["status"])
+}
+
+func (s *WebTestSuite) TestCreateTransactionErrors() {
+	from := s.createAccount("10")
+	to := s.createAccount("0")
+
+	response, _ := s.do(http.MethodPost, "/transactions", fmt.Sprintf(`{"account_id_from":%q,"account_id_to":%q,"amount":20}`, from, to))
+	s.Equal(http.StatusUnprocessableEntity, response.Code)
+
+	response, _ = s.do(http.MethodPost, "/transactions", fmt.Sprintf(`{"account_id_from":%q,"account_id_to":%q,"amount":"1.001"}`, from, to))
+	s.Equal(http.StatusBadRequest, response.Code)
+
+	response, _ = s.do(http.MethodPost, "/transactions", fmt.Sprintf(`{"account_id_from":%q,"account_id_to":"unknown","amount":1}`, from))
+	s.Equal(http.StatusNotFound, response.Code)
+}
+
+func (s *WebTestSuite) TestCreateTransactionWithIdempotencyKey() {
+	from := s.createAccount("100")
+	to := s.createAccount("0")
+	body := fmt.Sprintf(`{"account_id_from":%q,"account_id_to":%q,"amount":5}`, from, to)
+
+	first, created := s.do(http.MethodPost, "/transactions", body, HeaderIdempotencyKey, "key-1")
+	s.Equal(http.StatusCreated, first.Code)
+	second, replayed := s.do(http.MethodPost, "/transactions", body, HeaderIdempotencyKey, "key-1")
+	s.Equal(http.StatusCreated, second.Code)
+	s.Equal(created["id"], replayed["id"])
+
+	response, _ := s.do(http.MethodPost, "/transactions", fmt.Sprintf(`{"account_id_from":%q,"account_id_to":%q,"amount":6}`, from, to), HeaderIdempotencyKey, "key-1")
+	s.Equal(http.StatusConflict, response.Code)
+}
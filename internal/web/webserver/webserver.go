@@ -0,0 +1,73 @@
+// Package webserver runs an HTTP server that shuts down gracefully.
+package webserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// DefaultShutdownTimeout bounds how long in-flight requests are waited for
+// once the server is asked to stop.
+const DefaultShutdownTimeout = 10 * time.Second
+
+type WebServer struct {
+	Router          *http.ServeMux
+	Addr            string
+	ShutdownTimeout time.Duration
+}
+
+func NewWebServer(addr string) *WebServer {
+	return &WebServer{
+		Router:          http.NewServeMux(),
+		Addr:            addr,
+		ShutdownTimeout: DefaultShutdownTimeout,
+	}
+}
+
+// AddHandler registers handler for pattern, which follows the
+// http.ServeMux syntax, e.g. "POST /clients" or "GET /clients/{id}".
+func (s *WebServer) AddHandler(pattern string, handler http.HandlerFunc) {
+	s.Router.HandleFunc(pattern, handler)
+}
+
+// Start listens on Addr and serves until ctx is done, then stops accepting
+// connections and waits up to ShutdownTimeout for in-flight requests.
+func (s *WebServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve is Start on an existing listener.
+func (s *WebServer) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{
+		Handler:           s.Router,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
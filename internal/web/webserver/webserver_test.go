@@ -0,0 +1,63 @@
+package webserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeShutsDownGracefully(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := NewWebServer(listener.Addr().String())
+	server.AddHandler("GET /slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("done"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- server.Serve(ctx, listener) }()
+
+	responses := make(chan string)
+	go func() {
+		response, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err != nil {
+			responses <- err.Error()
+			return
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		responses <- string(body)
+	}()
+
+	<-started
+	cancel()
+	select {
+	case err := <-done:
+		t.Fatalf("server stopped with a request in flight: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+
+	assert.Equal(t, "done", <-responses)
+	assert.Nil(t, <-done)
+}
+
+func TestStartFailsWhenAddressIsTaken(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	err = NewWebServer(listener.Addr().String()).Start(context.Background())
+	assert.Error(t, err)
+}